@@ -4,14 +4,17 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
+	"github.com/teste-transfeera/internal/dict"
 	"github.com/teste-transfeera/internal/entity"
 	"github.com/teste-transfeera/internal/model"
-	"github.com/teste-transfeera/pkg/shared"
+	"github.com/teste-transfeera/internal/repository"
+	"github.com/teste-transfeera/pkg/validation"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -25,6 +28,21 @@ func main() {
 
 	rootCmd := commands["root"]
 	rootCmd.AddCommand(commands["seed"])
+
+	migrateCmd := commands["migrate"]
+	indexesCmd := commands["indexes"]
+	indexesCmd.Flags().Bool("check", false, "Only report the drift between declared and existing indexes")
+	indexesCmd.Flags().Bool("prune", false, "Drop existing indexes that are not declared")
+	migrateCmd.AddCommand(indexesCmd)
+	rootCmd.AddCommand(migrateCmd)
+
+	dictCmd := commands["dict"]
+	serveCmd := commands["serve"]
+	serveCmd.Flags().String("file", "dict.json", "JSON file with the Pix directory entries")
+	serveCmd.Flags().String("port", "8090", "Port to listen on")
+	dictCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(dictCmd)
+
 	err = rootCmd.Execute()
 	if err != nil {
 		log.Fatal(err)
@@ -41,33 +59,170 @@ var commands = map[string]*cobra.Command{
 		Short: "Seeds the database with 30 records of Receiver",
 		Run:   seed,
 	},
+	"migrate": {
+		Use:   "migrate",
+		Short: "Brings the database schema up to date",
+	},
+	"indexes": {
+		Use:   "indexes",
+		Short: "Creates the declared indexes of the Receiver collection and reports any drift",
+		Run:   migrateIndexes,
+	},
+	"dict": {
+		Use:   "dict",
+		Short: "Simulates the Pix directory (DICT)",
+	},
+	"serve": {
+		Use:   "serve",
+		Short: "Serves the entries of a JSON file as the Pix directory over HTTP",
+		Run:   serveDict,
+	},
 }
 
-func seed(cmd *cobra.Command, args []string) {
-	ctx := context.Background()
+func connect(ctx context.Context) *mongo.Database {
 	clientOptions := options.Client().ApplyURI(os.Getenv("DATABASE_URL"))
 	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
 		log.Fatal(err)
 	}
-	db := client.Database("transfeera").Collection("receiver")
+	return client.Database("transfeera")
+}
 
-	err = db.Drop(ctx)
+func seed(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+	database := connect(ctx)
+	db := database.Collection("receiver")
+
+	err := db.Drop(ctx)
 	if err != nil {
 		log.Fatal(err)
 	}
 	fmt.Println("Dropped Receiver collection")
 
+	err = database.Collection("receiver_history").Drop(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Dropped Receiver History collection")
+
+	err = database.Collection("transfer").Drop(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Dropped Transfer collection")
+
 	receiversToInsert := receivers()
+	for i, receiver := range receiversToInsert {
+		r := receiver.(model.Receiver)
+		r.FormattedIdentifier = validation.FormatIdentifier(r.Identifier)
+		r.Identifier = validation.NormalizeIdentifier(r.Identifier)
+		r.Email = validation.NormalizeEmail(r.Email)
+		r.Pix.FormattedKey = validation.FormatPixKey(r.Pix.Key, r.Pix.KeyType)
+		r.Pix.Key = validation.NormalizePixKey(r.Pix.Key, r.Pix.KeyType)
+		if account := r.BankAccount; account != nil {
+			account.Verified, err = validation.CheckBankAccount(account.BankCode, account.Agency, account.AgencyDigit, account.Account, account.AccountDigit)
+			if err != nil {
+				log.Fatalf("seed %s: %v", r.Name, err)
+			}
+		}
+		r.RefreshSearch()
+		receiversToInsert[i] = r
+	}
 
 	_, err = db.InsertMany(ctx, receiversToInsert)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	for _, declared := range repository.DatabaseIndexes {
+		_, err = repository.ApplyIndexes(ctx, database.Collection(declared.Collection), declared.Indexes, false)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	fmt.Println("Receivers inserted successfully!")
 }
 
+func migrateIndexes(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+	database := connect(ctx)
+
+	check, _ := cmd.Flags().GetBool("check")
+	prune, _ := cmd.Flags().GetBool("prune")
+
+	if !check {
+		err := repository.BackfillDeletedFlag(ctx, database.Collection("receiver"))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		skipped, err := repository.BackfillBankAccounts(ctx, database.Collection("receiver"))
+		if err != nil {
+			log.Fatal(err)
+		}
+		if skipped > 0 {
+			fmt.Printf("receiver: %d receivers keep their legacy bank fields, their bank name matches no institution\n", skipped)
+		}
+
+		conflicts, err := repository.BackfillCanonicalValues(ctx, database.Collection("receiver"))
+		if err != nil {
+			log.Fatal(err)
+		}
+		if conflicts > 0 {
+			fmt.Printf("receiver: %d receivers keep their legacy identifier and pix key, another receiver holds the canonical pix key\n", conflicts)
+		}
+
+		err = repository.BackfillSearchTerms(ctx, database.Collection("receiver"))
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	drifted := false
+	for _, declared := range repository.DatabaseIndexes {
+		collection := database.Collection(declared.Collection)
+
+		var drift repository.IndexDrift
+		var err error
+		if check {
+			drift, err = repository.CheckIndexes(ctx, collection, declared.Indexes)
+		} else {
+			drift, err = repository.ApplyIndexes(ctx, collection, declared.Indexes, prune)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, line := range drift.Report() {
+			fmt.Printf("%s: %s\n", declared.Collection, line)
+		}
+		drifted = drifted || !drift.IsEmpty()
+	}
+
+	if !drifted {
+		fmt.Println("Indexes are up to date")
+		return
+	}
+	if check {
+		os.Exit(1)
+	}
+	fmt.Println("Indexes migrated successfully!")
+}
+
+func serveDict(cmd *cobra.Command, args []string) {
+	file, _ := cmd.Flags().GetString("file")
+	port, _ := cmd.Flags().GetString("port")
+
+	directory, err := dict.NewFileDirectory(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Serving the Pix directory of %s on port %s\n", file, port)
+	log.Fatal(http.ListenAndServe(":"+port, dict.Handler(directory)))
+}
+
 func receivers() []interface{} {
 	return []interface{}{
 		model.Receiver{
@@ -75,10 +230,16 @@ func receivers() []interface{} {
 			Identifier: "290.551.590-26",
 			Name:       "Receiver 1",
 			Email:      "RECEIVER1@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Bradesco"),
-			Agency:     shared.GetPointerStr("0814-0"),
-			Account:    shared.GetPointerStr("01002713-9"),
-			Status:     string(entity.Draft),
+			BankAccount: &model.BankAccount{
+				BankCode:     "237",
+				ISPB:         "60746948",
+				Agency:       "0814",
+				AgencyDigit:  "1",
+				Account:      "01002713",
+				AccountDigit: "6",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Draft),
 			Pix: model.Pix{
 				KeyType: string(entity.CPF),
 				Key:     "290.551.590-26",
@@ -90,10 +251,16 @@ func receivers() []interface{} {
 			Identifier: "516.488.970-61",
 			Name:       "Receiver 2",
 			Email:      "RECEIVER2@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Banco do Brasil"),
-			Agency:     shared.GetPointerStr("8016"),
-			Account:    shared.GetPointerStr("1051790-1"),
-			Status:     string(entity.Validated),
+			BankAccount: &model.BankAccount{
+				BankCode:     "001",
+				ISPB:         "00000000",
+				Agency:       "8016",
+				AgencyDigit:  "0",
+				Account:      "1051790",
+				AccountDigit: "1",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Validated),
 			Pix: model.Pix{
 				KeyType: string(entity.CPF),
 				Key:     "516.488.970-61",
@@ -105,10 +272,16 @@ func receivers() []interface{} {
 			Identifier: "300.227.450-09",
 			Name:       "Receiver 3",
 			Email:      "RECEIVER3@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Bradesco"),
-			Agency:     shared.GetPointerStr("3073"),
-			Account:    shared.GetPointerStr("0771847-0"),
-			Status:     string(entity.Draft),
+			BankAccount: &model.BankAccount{
+				BankCode:     "237",
+				ISPB:         "60746948",
+				Agency:       "3073",
+				AgencyDigit:  "2",
+				Account:      "0771847",
+				AccountDigit: "0",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Draft),
 			Pix: model.Pix{
 				KeyType: string(entity.CPF),
 				Key:     "300.227.450-09",
@@ -120,10 +293,16 @@ func receivers() []interface{} {
 			Identifier: "395.354.370-97",
 			Name:       "Receiver 4",
 			Email:      "RECEIVER4@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Bradesco"),
-			Agency:     shared.GetPointerStr("2215"),
-			Account:    shared.GetPointerStr("1677453-7"),
-			Status:     string(entity.Validated),
+			BankAccount: &model.BankAccount{
+				BankCode:     "237",
+				ISPB:         "60746948",
+				Agency:       "2215",
+				AgencyDigit:  "2",
+				Account:      "1677453",
+				AccountDigit: "7",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Validated),
 			Pix: model.Pix{
 				KeyType: string(entity.CPF),
 				Key:     "395.354.370-97",
@@ -135,10 +314,15 @@ func receivers() []interface{} {
 			Identifier: "750.941.900-08",
 			Name:       "Receiver 5",
 			Email:      "RECEIVER5@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Santander"),
-			Agency:     shared.GetPointerStr("0485"),
-			Account:    shared.GetPointerStr("53311681-7"),
-			Status:     string(entity.Draft),
+			BankAccount: &model.BankAccount{
+				BankCode:     "033",
+				ISPB:         "90400888",
+				Agency:       "0485",
+				Account:      "53311681",
+				AccountDigit: "7",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Draft),
 			Pix: model.Pix{
 				KeyType: string(entity.CPF),
 				Key:     "750.941.900-08",
@@ -150,10 +334,16 @@ func receivers() []interface{} {
 			Identifier: "454.859.820-00",
 			Name:       "Receiver 6",
 			Email:      "RECEIVER6@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Banco do Brasil"),
-			Agency:     shared.GetPointerStr("0814-5"),
-			Account:    shared.GetPointerStr("5448"),
-			Status:     string(entity.Validated),
+			BankAccount: &model.BankAccount{
+				BankCode:     "001",
+				ISPB:         "00000000",
+				Agency:       "0814",
+				AgencyDigit:  "1",
+				Account:      "544",
+				AccountDigit: "4",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Validated),
 			Pix: model.Pix{
 				KeyType: string(entity.CPF),
 				Key:     "454.859.820-00",
@@ -165,10 +355,16 @@ func receivers() []interface{} {
 			Identifier: "40.424.263/0001-42",
 			Name:       "Receiver 7",
 			Email:      "RECEIVER7@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Bradesco"),
-			Agency:     shared.GetPointerStr("1674"),
-			Account:    shared.GetPointerStr("0722375-7"),
-			Status:     string(entity.Draft),
+			BankAccount: &model.BankAccount{
+				BankCode:     "237",
+				ISPB:         "60746948",
+				Agency:       "1674",
+				AgencyDigit:  "8",
+				Account:      "0722375",
+				AccountDigit: "7",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Draft),
 			Pix: model.Pix{
 				KeyType: string(entity.CNPJ),
 				Key:     "40.424.263/0001-42",
@@ -180,10 +376,16 @@ func receivers() []interface{} {
 			Identifier: "45.325.641/0001-54",
 			Name:       "Receiver 8",
 			Email:      "RECEIVER8@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Bradesco"),
-			Agency:     shared.GetPointerStr("1515"),
-			Account:    shared.GetPointerStr("1858481-6"),
-			Status:     string(entity.Validated),
+			BankAccount: &model.BankAccount{
+				BankCode:     "237",
+				ISPB:         "60746948",
+				Agency:       "1515",
+				AgencyDigit:  "6",
+				Account:      "1858481",
+				AccountDigit: "6",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Validated),
 			Pix: model.Pix{
 				KeyType: string(entity.CNPJ),
 				Key:     "45.325.641/0001-54",
@@ -195,10 +397,16 @@ func receivers() []interface{} {
 			Identifier: "08.219.094/0001-04",
 			Name:       "Receiver 9",
 			Email:      "RECEIVER9@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Banco do Brasil"),
-			Agency:     shared.GetPointerStr("8016"),
-			Account:    shared.GetPointerStr("298417-2"),
-			Status:     string(entity.Draft),
+			BankAccount: &model.BankAccount{
+				BankCode:     "001",
+				ISPB:         "00000000",
+				Agency:       "8016",
+				AgencyDigit:  "0",
+				Account:      "298417",
+				AccountDigit: "2",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Draft),
 			Pix: model.Pix{
 				KeyType: string(entity.CNPJ),
 				Key:     "08.219.094/0001-04",
@@ -210,10 +418,15 @@ func receivers() []interface{} {
 			Identifier: "60.686.639/0001-02",
 			Name:       "Receiver 10",
 			Email:      "RECEIVER10@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Itaú"),
-			Agency:     shared.GetPointerStr("5586"),
-			Account:    shared.GetPointerStr("49718-1"),
-			Status:     string(entity.Validated),
+			BankAccount: &model.BankAccount{
+				BankCode:     "341",
+				ISPB:         "60701190",
+				Agency:       "5586",
+				Account:      "49718",
+				AccountDigit: "1",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Validated),
 			Pix: model.Pix{
 				KeyType: string(entity.CNPJ),
 				Key:     "60.686.639/0001-02",
@@ -225,10 +438,16 @@ func receivers() []interface{} {
 			Identifier: "14.890.924/0001-15",
 			Name:       "Receiver 11",
 			Email:      "RECEIVER11@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Banco do Brasil"),
-			Agency:     shared.GetPointerStr("3320"),
-			Account:    shared.GetPointerStr("1179294-9"),
-			Status:     string(entity.Draft),
+			BankAccount: &model.BankAccount{
+				BankCode:     "001",
+				ISPB:         "00000000",
+				Agency:       "3320",
+				AgencyDigit:  "0",
+				Account:      "1179294",
+				AccountDigit: "9",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Draft),
 			Pix: model.Pix{
 				KeyType: string(entity.CNPJ),
 				Key:     "14.890.924/0001-15",
@@ -240,10 +459,15 @@ func receivers() []interface{} {
 			Identifier: "38.325.271/0001-90",
 			Name:       "Receiver 12",
 			Email:      "RECEIVER12@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Santander"),
-			Agency:     shared.GetPointerStr("0947"),
-			Account:    shared.GetPointerStr("43866736-7"),
-			Status:     string(entity.Validated),
+			BankAccount: &model.BankAccount{
+				BankCode:     "033",
+				ISPB:         "90400888",
+				Agency:       "0947",
+				Account:      "43866736",
+				AccountDigit: "7",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Validated),
 			Pix: model.Pix{
 				KeyType: string(entity.CNPJ),
 				Key:     "38.325.271/0001-90",
@@ -255,10 +479,16 @@ func receivers() []interface{} {
 			Identifier: "800.686.200-12",
 			Name:       "Receiver 13",
 			Email:      "RECEIVER13@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Banco do Brasil"),
-			Agency:     shared.GetPointerStr("1404"),
-			Account:    shared.GetPointerStr("1218287-7"),
-			Status:     string(entity.Draft),
+			BankAccount: &model.BankAccount{
+				BankCode:     "001",
+				ISPB:         "00000000",
+				Agency:       "1404",
+				AgencyDigit:  "4",
+				Account:      "1218287",
+				AccountDigit: "7",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Draft),
 			Pix: model.Pix{
 				KeyType: string(entity.Email),
 				Key:     "RECEIVER13@GMAIL.COM",
@@ -270,10 +500,15 @@ func receivers() []interface{} {
 			Identifier: "586.076.790-07",
 			Name:       "Receiver 14",
 			Email:      "RECEIVER14@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Santander"),
-			Agency:     shared.GetPointerStr("1728"),
-			Account:    shared.GetPointerStr("27645921-0"),
-			Status:     string(entity.Validated),
+			BankAccount: &model.BankAccount{
+				BankCode:     "033",
+				ISPB:         "90400888",
+				Agency:       "1728",
+				Account:      "27645921",
+				AccountDigit: "0",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Validated),
 			Pix: model.Pix{
 				KeyType: string(entity.Email),
 				Key:     "RECEIVER14@GMAIL.COM",
@@ -285,10 +520,15 @@ func receivers() []interface{} {
 			Identifier: "259.498.450-72",
 			Name:       "Receiver 15",
 			Email:      "RECEIVER15@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Santander"),
-			Agency:     shared.GetPointerStr("2210"),
-			Account:    shared.GetPointerStr("35155013-6"),
-			Status:     string(entity.Draft),
+			BankAccount: &model.BankAccount{
+				BankCode:     "033",
+				ISPB:         "90400888",
+				Agency:       "2210",
+				Account:      "35155013",
+				AccountDigit: "6",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Draft),
 			Pix: model.Pix{
 				KeyType: string(entity.Email),
 				Key:     "RECEIVER15@GMAIL.COM",
@@ -300,10 +540,15 @@ func receivers() []interface{} {
 			Identifier: "861.248.030-20",
 			Name:       "Receiver 16",
 			Email:      "RECEIVER16@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Santander"),
-			Agency:     shared.GetPointerStr("1194"),
-			Account:    shared.GetPointerStr("46976438-8"),
-			Status:     string(entity.Validated),
+			BankAccount: &model.BankAccount{
+				BankCode:     "033",
+				ISPB:         "90400888",
+				Agency:       "1194",
+				Account:      "46976438",
+				AccountDigit: "8",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Validated),
 			Pix: model.Pix{
 				KeyType: string(entity.Email),
 				Key:     "RECEIVER16@GMAIL.COM",
@@ -315,10 +560,15 @@ func receivers() []interface{} {
 			Identifier: "919.502.190-62",
 			Name:       "Receiver 17",
 			Email:      "RECEIVER17@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Santander"),
-			Agency:     shared.GetPointerStr("3731"),
-			Account:    shared.GetPointerStr("60764032-4"),
-			Status:     string(entity.Draft),
+			BankAccount: &model.BankAccount{
+				BankCode:     "033",
+				ISPB:         "90400888",
+				Agency:       "3731",
+				Account:      "60764032",
+				AccountDigit: "4",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Draft),
 			Pix: model.Pix{
 				KeyType: string(entity.Email),
 				Key:     "RECEIVER17@GMAIL.COM",
@@ -330,10 +580,16 @@ func receivers() []interface{} {
 			Identifier: "952.497.300-60",
 			Name:       "Receiver 18",
 			Email:      "RECEIVER18@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Bradesco"),
-			Agency:     shared.GetPointerStr("2961"),
-			Account:    shared.GetPointerStr("1276583-5"),
-			Status:     string(entity.Validated),
+			BankAccount: &model.BankAccount{
+				BankCode:     "237",
+				ISPB:         "60746948",
+				Agency:       "2961",
+				AgencyDigit:  "0",
+				Account:      "1276583",
+				AccountDigit: "5",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Validated),
 			Pix: model.Pix{
 				KeyType: string(entity.Email),
 				Key:     "RECEIVER18@GMAIL.COM",
@@ -345,13 +601,19 @@ func receivers() []interface{} {
 			Identifier: "84.181.527/0001-50",
 			Name:       "Receiver 19",
 			Email:      "RECEIVER19@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Banco do Brasil"),
-			Agency:     shared.GetPointerStr("2750"),
-			Account:    shared.GetPointerStr("122810-2"),
-			Status:     string(entity.Draft),
+			BankAccount: &model.BankAccount{
+				BankCode:     "001",
+				ISPB:         "00000000",
+				Agency:       "2750",
+				AgencyDigit:  "2",
+				Account:      "122810",
+				AccountDigit: "2",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Draft),
 			Pix: model.Pix{
 				KeyType: string(entity.Phone),
-				Key:     "5548",
+				Key:     "+5548991000019",
 			},
 			CreatedAt: time.Now(),
 		},
@@ -360,13 +622,19 @@ func receivers() []interface{} {
 			Identifier: "65.197.494/0001-91",
 			Name:       "Receiver 20",
 			Email:      "RECEIVER20@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Bradesco"),
-			Agency:     shared.GetPointerStr("0606"),
-			Account:    shared.GetPointerStr("0436294-2"),
-			Status:     string(entity.Validated),
+			BankAccount: &model.BankAccount{
+				BankCode:     "237",
+				ISPB:         "60746948",
+				Agency:       "0606",
+				AgencyDigit:  "8",
+				Account:      "0436294",
+				AccountDigit: "2",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Validated),
 			Pix: model.Pix{
 				KeyType: string(entity.Phone),
-				Key:     "5548",
+				Key:     "+5548991000020",
 			},
 			CreatedAt: time.Now(),
 		},
@@ -375,13 +643,18 @@ func receivers() []interface{} {
 			Identifier: "29.516.384/0001-81",
 			Name:       "Receiver 21",
 			Email:      "RECEIVER21@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Santander"),
-			Agency:     shared.GetPointerStr("0500"),
-			Account:    shared.GetPointerStr("50585125-8"),
-			Status:     string(entity.Draft),
+			BankAccount: &model.BankAccount{
+				BankCode:     "033",
+				ISPB:         "90400888",
+				Agency:       "0500",
+				Account:      "50585125",
+				AccountDigit: "8",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Draft),
 			Pix: model.Pix{
 				KeyType: string(entity.Phone),
-				Key:     "5548",
+				Key:     "+5548991000021",
 			},
 			CreatedAt: time.Now(),
 		},
@@ -390,13 +663,18 @@ func receivers() []interface{} {
 			Identifier: "24.269.544/0001-11",
 			Name:       "Receiver 22",
 			Email:      "RECEIVER22@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Itaú"),
-			Agency:     shared.GetPointerStr("0289"),
-			Account:    shared.GetPointerStr("0606476-0"),
-			Status:     string(entity.Validated),
+			BankAccount: &model.BankAccount{
+				BankCode:     "341",
+				ISPB:         "60701190",
+				Agency:       "0289",
+				Account:      "60647",
+				AccountDigit: "7",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Validated),
 			Pix: model.Pix{
 				KeyType: string(entity.Phone),
-				Key:     "5548",
+				Key:     "+5548991000022",
 			},
 			CreatedAt: time.Now(),
 		},
@@ -405,13 +683,18 @@ func receivers() []interface{} {
 			Identifier: "64.004.460/0001-70",
 			Name:       "Receiver 23",
 			Email:      "RECEIVER23@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Itaú"),
-			Agency:     shared.GetPointerStr("9688"),
-			Account:    shared.GetPointerStr("83438-2"),
-			Status:     string(entity.Draft),
+			BankAccount: &model.BankAccount{
+				BankCode:     "341",
+				ISPB:         "60701190",
+				Agency:       "9688",
+				Account:      "83438",
+				AccountDigit: "2",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Draft),
 			Pix: model.Pix{
 				KeyType: string(entity.Phone),
-				Key:     "5548",
+				Key:     "+5548991000023",
 			},
 			CreatedAt: time.Now(),
 		},
@@ -420,13 +703,19 @@ func receivers() []interface{} {
 			Identifier: "77.334.798/0001-32",
 			Name:       "Receiver 24",
 			Email:      "RECEIVER24@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Bradesco"),
-			Agency:     shared.GetPointerStr("3522"),
-			Account:    shared.GetPointerStr("0507968-3"),
-			Status:     string(entity.Validated),
+			BankAccount: &model.BankAccount{
+				BankCode:     "237",
+				ISPB:         "60746948",
+				Agency:       "3522",
+				AgencyDigit:  "P",
+				Account:      "0507968",
+				AccountDigit: "3",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Validated),
 			Pix: model.Pix{
 				KeyType: string(entity.Phone),
-				Key:     "5548",
+				Key:     "+5548991000024",
 			},
 			CreatedAt: time.Now(),
 		},
@@ -435,13 +724,18 @@ func receivers() []interface{} {
 			Identifier: "273.753.420-83",
 			Name:       "Receiver 25",
 			Email:      "RECEIVER25@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Santander"),
-			Agency:     shared.GetPointerStr("2030"),
-			Account:    shared.GetPointerStr("48638554-6"),
-			Status:     string(entity.Draft),
+			BankAccount: &model.BankAccount{
+				BankCode:     "033",
+				ISPB:         "90400888",
+				Agency:       "2030",
+				Account:      "48638554",
+				AccountDigit: "6",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Draft),
 			Pix: model.Pix{
 				KeyType: string(entity.RandomKey),
-				Key:     "41fcd1dc-ccf5-5ef3-97b8-6254ed1f5dbf",
+				Key:     "41fcd1dc-ccf5-5ef3-97b8-6254ed1f5d25",
 			},
 			CreatedAt: time.Now(),
 		},
@@ -450,13 +744,19 @@ func receivers() []interface{} {
 			Identifier: "300.258.870-92",
 			Name:       "Receiver 26",
 			Email:      "RECEIVER26@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Banco do Brasil"),
-			Agency:     shared.GetPointerStr("0732"),
-			Account:    shared.GetPointerStr("1266018-3"),
-			Status:     string(entity.Validated),
+			BankAccount: &model.BankAccount{
+				BankCode:     "001",
+				ISPB:         "00000000",
+				Agency:       "0732",
+				AgencyDigit:  "3",
+				Account:      "1266018",
+				AccountDigit: "3",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Validated),
 			Pix: model.Pix{
 				KeyType: string(entity.RandomKey),
-				Key:     "41fcd1dc-ccf5-5ef3-97b8-6254ed1f5dbf",
+				Key:     "41fcd1dc-ccf5-5ef3-97b8-6254ed1f5d26",
 			},
 			CreatedAt: time.Now(),
 		},
@@ -465,13 +765,19 @@ func receivers() []interface{} {
 			Identifier: "142.338.070-32",
 			Name:       "Receiver 27",
 			Email:      "RECEIVER27@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Bradesco"),
-			Agency:     shared.GetPointerStr("3376"),
-			Account:    shared.GetPointerStr("0128532-7"),
-			Status:     string(entity.Draft),
+			BankAccount: &model.BankAccount{
+				BankCode:     "237",
+				ISPB:         "60746948",
+				Agency:       "3376",
+				AgencyDigit:  "6",
+				Account:      "0128532",
+				AccountDigit: "7",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Draft),
 			Pix: model.Pix{
 				KeyType: string(entity.RandomKey),
-				Key:     "41fcd1dc-ccf5-5ef3-97b8-6254ed1f5dbf",
+				Key:     "41fcd1dc-ccf5-5ef3-97b8-6254ed1f5d27",
 			},
 			CreatedAt: time.Now(),
 		},
@@ -480,13 +786,18 @@ func receivers() []interface{} {
 			Identifier: "770.656.270-04",
 			Name:       "Receiver 28",
 			Email:      "RECEIVER28@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Santander"),
-			Agency:     shared.GetPointerStr("3332"),
-			Account:    shared.GetPointerStr("37155495-0"),
-			Status:     string(entity.Validated),
+			BankAccount: &model.BankAccount{
+				BankCode:     "033",
+				ISPB:         "90400888",
+				Agency:       "3332",
+				Account:      "37155495",
+				AccountDigit: "0",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Validated),
 			Pix: model.Pix{
 				KeyType: string(entity.RandomKey),
-				Key:     "41fcd1dc-ccf5-5ef3-97b8-6254ed1f5dbf",
+				Key:     "41fcd1dc-ccf5-5ef3-97b8-6254ed1f5d28",
 			},
 			CreatedAt: time.Now(),
 		},
@@ -495,13 +806,19 @@ func receivers() []interface{} {
 			Identifier: "760.572.110-22",
 			Name:       "Receiver 29",
 			Email:      "RECEIVER29@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Bradesco"),
-			Agency:     shared.GetPointerStr("6158"),
-			Account:    shared.GetPointerStr("0107178-5"),
-			Status:     string(entity.Draft),
+			BankAccount: &model.BankAccount{
+				BankCode:     "237",
+				ISPB:         "60746948",
+				Agency:       "6158",
+				AgencyDigit:  "1",
+				Account:      "0107178",
+				AccountDigit: "5",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Draft),
 			Pix: model.Pix{
 				KeyType: string(entity.RandomKey),
-				Key:     "41fcd1dc-ccf5-5ef3-97b8-6254ed1f5dbf",
+				Key:     "41fcd1dc-ccf5-5ef3-97b8-6254ed1f5d29",
 			},
 			CreatedAt: time.Now(),
 		},
@@ -510,13 +827,19 @@ func receivers() []interface{} {
 			Identifier: "788.253.700-40",
 			Name:       "Receiver 30",
 			Email:      "RECEIVER30@GMAIL.COM",
-			Bank:       shared.GetPointerStr("Banco do Brasil"),
-			Agency:     shared.GetPointerStr("4529"),
-			Account:    shared.GetPointerStr("54114-1"),
-			Status:     string(entity.Validated),
+			BankAccount: &model.BankAccount{
+				BankCode:     "001",
+				ISPB:         "00000000",
+				Agency:       "4529",
+				AgencyDigit:  "2",
+				Account:      "54114",
+				AccountDigit: "1",
+				AccountType:  string(entity.Checking),
+			},
+			Status: string(entity.Validated),
 			Pix: model.Pix{
 				KeyType: string(entity.RandomKey),
-				Key:     "41fcd1dc-ccf5-5ef3-97b8-6254ed1f5dbf",
+				Key:     "41fcd1dc-ccf5-5ef3-97b8-6254ed1f5d30",
 			},
 			CreatedAt: time.Now(),
 		},
@@ -2,20 +2,29 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"database/sql"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"github.com/teste-transfeera/internal/dict"
 	"github.com/teste-transfeera/internal/graph"
 	"github.com/teste-transfeera/internal/repository"
+	"github.com/teste-transfeera/internal/rest"
 	"github.com/teste-transfeera/internal/usecase"
+	"github.com/teste-transfeera/pkg/actor"
+	"github.com/teste-transfeera/pkg/cursor"
+	"github.com/teste-transfeera/pkg/pix"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -33,12 +42,24 @@ func main() {
 
 	ctx := context.Background()
 
-	collection := initDB(ctx)
-	receiverRepository := repository.NewReceiverRepository(collection, ctx)
+	receiverRepository, historyRepository, transferRepository := initRepository(ctx)
 
-	receiverUsecases := usecase.NewReceiverUseCases(receiverRepository)
+	options := []usecase.Option{usecase.WithConsistencyRules(consistencyRules())}
+	if city := os.Getenv("PIX_MERCHANT_CITY"); city != "" {
+		if _, err := (pix.BRCode{Key: "-", Name: "-", City: city}).Payload(); err != nil {
+			log.Fatalf("invalid PIX_MERCHANT_CITY: %v", err)
+		}
+		options = append(options, usecase.WithPixMerchantCity(city))
+	}
+	if directory := initPixDirectory(); directory != nil {
+		options = append(options, usecase.WithPixDirectory(directory))
+	}
 
-	initServer(port, receiverUsecases)
+	receiverUsecases := usecase.NewReceiverUseCases(receiverRepository, historyRepository, options...)
+	transferUsecases := usecase.NewTransferUseCases(transferRepository, receiverRepository)
+	cursors := cursor.NewCodec(cursorSecret())
+
+	initServer(port, receiverUsecases, transferUsecases, cursors)
 
 	done := make(chan os.Signal, 1)
 	signal.Notify(done, syscall.SIGINT, syscall.SIGTERM)
@@ -54,21 +75,27 @@ func main() {
 	fmt.Println("shutting down gracefully, press Ctrl+C again to force")
 }
 
-func initServer(port string, receiverUsecases usecase.ReceiverUseCases) {
+func initServer(port string, receiverUsecases usecase.ReceiverUseCases, transferUsecases usecase.TransferUseCases, cursors *cursor.Codec) {
 	router := gin.Default()
 
 	apiVersion1 := router.Group("api/v1")
-	apiVersion1.POST("/receiver", graphqlHandler(receiverUsecases))
+	apiVersion1.POST("/receiver", graphqlHandler(receiverUsecases, transferUsecases, cursors))
 	apiVersion1.GET("/playground", playgroundHandler())
+	apiVersion1.GET("/receiver/:id/pix-qrcode.png", rest.PixQRCode(receiverUsecases))
 
 	router.Run(port)
 }
 
-func graphqlHandler(receiverUsecases usecase.ReceiverUseCases) gin.HandlerFunc {
-	h := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: &graph.Resolver{ReceiverUseCases: receiverUsecases}}))
+func graphqlHandler(receiverUsecases usecase.ReceiverUseCases, transferUsecases usecase.TransferUseCases, cursors *cursor.Codec) gin.HandlerFunc {
+	resolver := &graph.Resolver{ReceiverUseCases: receiverUsecases, TransferUseCases: transferUsecases, Cursors: cursors}
+	h := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: resolver}))
+	h.SetErrorPresenter(graph.ErrorPresenter)
 
 	return func(c *gin.Context) {
-		h.ServeHTTP(c.Writer, c.Request)
+		// The X-Actor header names who is making the request, for the
+		// receiver history.
+		ctx := actor.WithActor(c.Request.Context(), c.GetHeader("X-Actor"))
+		h.ServeHTTP(c.Writer, c.Request.WithContext(ctx))
 	}
 }
 
@@ -80,8 +107,46 @@ func playgroundHandler() gin.HandlerFunc {
 	}
 }
 
-func initDB(ctx context.Context) *mongo.Collection {
-	clientOptions := options.Client().ApplyURI(os.Getenv("DATABASE_URL"))
+// initRepository picks the storage backend from the STORAGE variable:
+// "database" (the default), which uses the database in DATABASE_URL, or
+// "memory", which needs no database at all.
+func initRepository(ctx context.Context) (repository.ReceiverRepository, repository.HistoryRepository, repository.TransferRepository) {
+	switch os.Getenv("STORAGE") {
+	case "", "database", "mongodb":
+		return initDB(ctx, os.Getenv("DATABASE_URL"))
+	case "memory":
+		log.Println("using in-memory storage, data will be lost when the server stops")
+		return repository.NewMemoryReceiverRepository(), repository.NewMemoryHistoryRepository(), repository.NewMemoryTransferRepository()
+	default:
+		log.Fatalf("unknown STORAGE %q, expected database or memory", os.Getenv("STORAGE"))
+	}
+	return nil, nil, nil
+}
+
+// initDB connects to the database in url, choosing the backend by its scheme:
+// mongodb:// (or mongodb+srv://) for MongoDB and sqlite://<path> for an SQLite
+// file, which is created and migrated on start.
+func initDB(ctx context.Context, url string) (repository.ReceiverRepository, repository.HistoryRepository, repository.TransferRepository) {
+	timeouts := loadTimeouts()
+
+	switch {
+	case strings.HasPrefix(url, "mongodb://"), strings.HasPrefix(url, "mongodb+srv://"):
+		database := initMongo(ctx, url)
+		return repository.NewReceiverRepository(database.Collection("receiver"), timeouts),
+			repository.NewHistoryRepository(database.Collection("receiver_history"), timeouts),
+			repository.NewTransferRepository(database.Collection("transfer"), timeouts)
+	case strings.HasPrefix(url, "sqlite://"):
+		db := initSQLite(ctx, strings.TrimPrefix(url, "sqlite://"))
+		return repository.NewSQLiteReceiverRepository(db, timeouts), repository.NewSQLiteHistoryRepository(db, timeouts),
+			repository.NewSQLiteTransferRepository(db, timeouts)
+	default:
+		log.Fatal("unsupported DATABASE_URL, expected a mongodb:// or sqlite:// url")
+	}
+	return nil, nil, nil
+}
+
+func initMongo(ctx context.Context, url string) *mongo.Database {
+	clientOptions := options.Client().ApplyURI(url)
 	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
 		log.Fatal(err)
@@ -92,5 +157,164 @@ func initDB(ctx context.Context) *mongo.Collection {
 		log.Fatal(err)
 	}
 
-	return client.Database("transfeera").Collection("receiver")
+	database := client.Database("transfeera")
+
+	err = repository.BackfillDeletedFlag(ctx, database.Collection("receiver"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	skipped, err := repository.BackfillBankAccounts(ctx, database.Collection("receiver"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if skipped > 0 {
+		log.Printf("receiver: %d receivers keep their legacy bank fields, their bank name matches no institution", skipped)
+	}
+
+	conflicts, err := repository.BackfillCanonicalValues(ctx, database.Collection("receiver"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if conflicts > 0 {
+		log.Printf("receiver: %d receivers keep their legacy identifier and pix key, another receiver holds the canonical pix key", conflicts)
+	}
+
+	err = repository.BackfillSearchTerms(ctx, database.Collection("receiver"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	migrateIndexes(ctx, database)
+
+	return database
+}
+
+// migrateIndexes applies the declared indexes when DB_MIGRATE_INDEXES is
+// true. Otherwise it only applies the unique indexes, which the duplicate
+// checks rely on, and logs the remaining drift, leaving the change to the
+// migrate indexes command. Startup fails when a unique index cannot be built.
+func migrateIndexes(ctx context.Context, database *mongo.Database) {
+	for _, declared := range repository.DatabaseIndexes {
+		collection := database.Collection(declared.Collection)
+
+		var drift repository.IndexDrift
+		var err error
+		if os.Getenv("DB_MIGRATE_INDEXES") == "true" {
+			drift, err = repository.ApplyIndexes(ctx, collection, declared.Indexes, false)
+		} else {
+			_, err = repository.ApplyIndexes(ctx, collection, repository.UniqueIndexes(declared.Indexes), false)
+			if err != nil {
+				log.Fatalf("%s: unique indexes: %v", declared.Collection, err)
+			}
+			drift, err = repository.CheckIndexes(ctx, collection, declared.Indexes)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, line := range drift.Report() {
+			log.Printf("%s: %s", declared.Collection, line)
+		}
+	}
+}
+
+func initSQLite(ctx context.Context, path string) *sql.DB {
+	db, err := repository.OpenSQLite(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = repository.MigrateSQLite(ctx, db)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return db
+}
+
+// initPixDirectory picks the Pix directory from the PIX_DIRECTORY variable:
+// file://<path> for the simulator backed by a JSON file, or an http(s):// url
+// of a directory such as the one the CLI serves. When it is empty pix keys are
+// not checked.
+func initPixDirectory() dict.PixDirectory {
+	url := os.Getenv("PIX_DIRECTORY")
+	switch {
+	case url == "":
+		return nil
+	case strings.HasPrefix(url, "file://"):
+		directory, err := dict.NewFileDirectory(strings.TrimPrefix(url, "file://"))
+		if err != nil {
+			log.Fatal(err)
+		}
+		return directory
+	case strings.HasPrefix(url, "http://"), strings.HasPrefix(url, "https://"):
+		timeout := 5 * time.Second
+		if value := os.Getenv("PIX_DIRECTORY_TIMEOUT"); value != "" {
+			duration, err := time.ParseDuration(value)
+			if err != nil {
+				log.Fatalf("invalid PIX_DIRECTORY_TIMEOUT: %v", err)
+			}
+			timeout = duration
+		}
+		return dict.NewHTTPDirectory(url, timeout)
+	default:
+		log.Fatal("unsupported PIX_DIRECTORY, expected a file:// or http(s):// url")
+	}
+	return nil
+}
+
+// consistencyRules are the default rules with the severities changed by the
+// RECEIVER_RULES variable, such as PIX_KEY_PERSON_TYPE_MISMATCH=BLOCKING.
+func consistencyRules() []usecase.ConsistencyRule {
+	rules, err := usecase.ConfigureRules(usecase.DefaultConsistencyRules(), os.Getenv("RECEIVER_RULES"))
+	if err != nil {
+		log.Fatalf("invalid RECEIVER_RULES: %v", err)
+	}
+	return rules
+}
+
+func cursorSecret() []byte {
+	secret := os.Getenv("CURSOR_SECRET")
+	if secret != "" {
+		return []byte(secret)
+	}
+
+	log.Println("CURSOR_SECRET is not set, using a random secret: cursors will not survive a restart")
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		log.Fatal(err)
+	}
+	return random
+}
+
+// loadTimeouts reads the per-operation database deadlines from the
+// DB_TIMEOUT_<OPERATION> variables (e.g. DB_TIMEOUT_LIST=3s), keeping the
+// defaults for the ones that are not set.
+func loadTimeouts() repository.Timeouts {
+	timeouts := repository.DefaultTimeouts()
+
+	settings := map[string]*time.Duration{
+		"DB_TIMEOUT_CREATE":     &timeouts.Create,
+		"DB_TIMEOUT_LIST":       &timeouts.List,
+		"DB_TIMEOUT_COUNT":      &timeouts.Count,
+		"DB_TIMEOUT_FIND_BY_ID": &timeouts.FindById,
+		"DB_TIMEOUT_UPDATE":     &timeouts.Update,
+		"DB_TIMEOUT_DELETE":     &timeouts.Delete,
+	}
+
+	for name, timeout := range settings {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			log.Fatalf("invalid %s: %v", name, err)
+		}
+		*timeout = duration
+	}
+
+	return timeouts
 }
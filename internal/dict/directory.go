@@ -0,0 +1,29 @@
+// Package dict looks pix keys up in the Pix directory (DICT), which knows who
+// owns each key and the account it pays into.
+package dict
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrKeyNotFound = errors.New("Pix key is not registered in the Pix directory")
+
+// Entry is the registration of a pix key. OwnerDocument is the CPF or CNPJ of
+// the owner, in canonical form.
+type Entry struct {
+	Key           string `json:"key"`
+	KeyType       string `json:"keyType"`
+	OwnerDocument string `json:"ownerDocument"`
+	OwnerName     string `json:"ownerName"`
+	ISPB          string `json:"ispb"`
+	Agency        string `json:"agency"`
+	Account       string `json:"account"`
+	AccountType   string `json:"accountType"`
+}
+
+type PixDirectory interface {
+	// Lookup returns the entry of the key, or ErrKeyNotFound. The key may
+	// be in any form the key validation accepts.
+	Lookup(ctx context.Context, key string) (*Entry, error)
+}
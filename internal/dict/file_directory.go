@@ -0,0 +1,55 @@
+package dict
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/teste-transfeera/pkg/validation"
+)
+
+// FileDirectory simulates the Pix directory with the entries of a JSON file,
+// an array of Entry. It is meant for development and tests.
+type FileDirectory struct {
+	entries map[string]Entry
+}
+
+func NewFileDirectory(path string) (*FileDirectory, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("invalid Pix directory file %s: %w", path, err)
+	}
+
+	return NewMemoryDirectory(entries), nil
+}
+
+// NewMemoryDirectory is a FileDirectory with the entries given, keys and
+// documents being normalized as the receivers are. Keys without a type are
+// normalized as the first type they match.
+func NewMemoryDirectory(entries []Entry) *FileDirectory {
+	directory := &FileDirectory{entries: make(map[string]Entry, len(entries))}
+	for _, entry := range entries {
+		if entry.KeyType != "" {
+			entry.Key = validation.NormalizePixKey(entry.Key, entry.KeyType)
+		} else {
+			entry.Key = validation.NormalizeAnyPixKey(entry.Key)
+		}
+		entry.OwnerDocument = validation.NormalizeIdentifier(entry.OwnerDocument)
+		directory.entries[entry.Key] = entry
+	}
+	return directory
+}
+
+func (d *FileDirectory) Lookup(ctx context.Context, key string) (*Entry, error) {
+	entry, ok := d.entries[validation.NormalizeAnyPixKey(key)]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	return &entry, nil
+}
@@ -0,0 +1,65 @@
+package dict_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teste-transfeera/internal/dict"
+)
+
+func Test_Dict_FileDirectory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Should look up keys in any form of the file", func(t *testing.T) {
+		directory, err := dict.NewFileDirectory("../../dict.json")
+		assert.NoError(t, err)
+
+		entry, err := directory.Lookup(ctx, "29055159026")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &dict.Entry{
+			Key:           "29055159026",
+			KeyType:       "CPF",
+			OwnerDocument: "29055159026",
+			OwnerName:     "Receiver 1",
+			ISPB:          "60746948",
+			Agency:        "0814",
+			Account:       "01002713-9",
+			AccountType:   "CHECKING",
+		}, entry)
+	})
+
+	t.Run("Should normalize keys without type", func(t *testing.T) {
+		directory := dict.NewMemoryDirectory([]dict.Entry{
+			{Key: "Fulano@Transfeera.com", OwnerDocument: "11.222.333/0001-81"},
+		})
+
+		entry, err := directory.Lookup(ctx, "fulano@transfeera.com")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "11222333000181", entry.OwnerDocument)
+	})
+
+	t.Run("Should return ErrKeyNotFound for unknown keys", func(t *testing.T) {
+		directory := dict.NewMemoryDirectory(nil)
+
+		entry, err := directory.Lookup(ctx, "52998224725")
+
+		assert.Nil(t, entry)
+		assert.Equal(t, dict.ErrKeyNotFound, err)
+	})
+
+	t.Run("Should reject invalid files", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "dict.json")
+		os.WriteFile(path, []byte(`{"key": "52998224725"}`), 0o600)
+
+		_, err := dict.NewFileDirectory(path)
+		assert.ErrorContains(t, err, "invalid Pix directory file")
+
+		_, err = dict.NewFileDirectory(filepath.Join(t.TempDir(), "missing.json"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -0,0 +1,84 @@
+package dict
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// EntriesPath is where the HTTP directory serves the entries, followed by the
+// escaped key.
+const EntriesPath = "/entries/"
+
+// HTTPDirectory looks keys up in a Pix directory served over HTTP, such as
+// the one of Handler.
+type HTTPDirectory struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPDirectory looks keys up at baseURL, waiting at most timeout for each
+// lookup.
+func NewHTTPDirectory(baseURL string, timeout time.Duration) *HTTPDirectory {
+	return &HTTPDirectory{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func (d *HTTPDirectory) Lookup(ctx context.Context, key string) (*Entry, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.baseURL+EntriesPath+url.PathEscape(key), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrKeyNotFound
+	default:
+		return nil, fmt.Errorf("Pix directory returned status %d", res.StatusCode)
+	}
+
+	var entry Entry
+	if err := json.NewDecoder(res.Body).Decode(&entry); err != nil {
+		return nil, fmt.Errorf("invalid Pix directory response: %w", err)
+	}
+	return &entry, nil
+}
+
+// Handler serves the entries of directory over HTTP: GET EntriesPath + key
+// returns the entry as JSON, or 404 when the key is not registered. With a
+// FileDirectory it stands in for the real directory.
+func Handler(directory PixDirectory) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || !strings.HasPrefix(r.URL.Path, EntriesPath) {
+			http.NotFound(w, r)
+			return
+		}
+
+		entry, err := directory.Lookup(r.Context(), strings.TrimPrefix(r.URL.Path, EntriesPath))
+		if errors.Is(err, ErrKeyNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(entry)
+	})
+}
@@ -0,0 +1,52 @@
+package dict_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teste-transfeera/internal/dict"
+)
+
+func Test_Dict_HTTPDirectory(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(dict.Handler(dict.NewMemoryDirectory([]dict.Entry{
+		{Key: "+5548991000001", KeyType: "TELEFONE", OwnerDocument: "529.982.247-25", OwnerName: "Fulano de Tal", ISPB: "18236120"},
+	})))
+	defer server.Close()
+	directory := dict.NewHTTPDirectory(server.URL+"/", time.Second)
+
+	t.Run("Should look up keys served by Handler", func(t *testing.T) {
+		entry, err := directory.Lookup(ctx, "+5548991000001")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &dict.Entry{
+			Key:           "+5548991000001",
+			KeyType:       "TELEFONE",
+			OwnerDocument: "52998224725",
+			OwnerName:     "Fulano de Tal",
+			ISPB:          "18236120",
+		}, entry)
+	})
+
+	t.Run("Should return ErrKeyNotFound on 404", func(t *testing.T) {
+		entry, err := directory.Lookup(ctx, "fulano@transfeera.com")
+
+		assert.Nil(t, entry)
+		assert.Equal(t, dict.ErrKeyNotFound, err)
+	})
+
+	t.Run("Should return an error on other statuses", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer failing.Close()
+
+		_, err := dict.NewHTTPDirectory(failing.URL, time.Second).Lookup(ctx, "+5548991000001")
+
+		assert.EqualError(t, err, "Pix directory returned status 503")
+	})
+}
@@ -0,0 +1,39 @@
+package entity
+
+type AccountType string
+
+const (
+	Checking AccountType = "CHECKING"
+	Savings  AccountType = "SAVINGS"
+	Payment  AccountType = "PAYMENT"
+)
+
+// BankAccount is the receiver's account for transfers outside Pix. BankCode
+// is the three digit COMPE code and ISPB the bank's identifier in the
+// payments system. AgencyDigit is empty for banks whose agencies have no
+// check digit. Verified tells whether the check digits were verified with the
+// algorithm of the bank; accounts of other banks are only checked for format.
+type BankAccount struct {
+	BankCode     string
+	ISPB         string
+	Agency       string
+	AgencyDigit  string
+	Account      string
+	AccountDigit string
+	AccountType  AccountType
+	Verified     bool
+}
+
+// FormattedAgency is the agency with its check digit, as in 0814-0.
+func (a *BankAccount) FormattedAgency() string {
+	if a.AgencyDigit == "" {
+		return a.Agency
+	}
+	return a.Agency + "-" + a.AgencyDigit
+}
+
+// FormattedAccount is the account number with its check digit, as in
+// 01002713-9.
+func (a *BankAccount) FormattedAccount() string {
+	return a.Account + "-" + a.AccountDigit
+}
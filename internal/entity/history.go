@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+type HistoryOperation string
+
+const (
+	HistoryCreate HistoryOperation = "CREATE"
+	HistoryUpdate HistoryOperation = "UPDATE"
+	HistoryDelete HistoryOperation = "DELETE"
+	// HistoryStatusChange records a status transition, with its reason.
+	HistoryStatusChange HistoryOperation = "STATUS_CHANGE"
+)
+
+// FieldChange is the value of a receiver field before and after an
+// operation. A nil value means the field had no value on that side.
+type FieldChange struct {
+	Field  string
+	Before *string
+	After  *string
+}
+
+// HistoryEntry records who changed a receiver, when and how. Entries are
+// never updated or removed.
+type HistoryEntry struct {
+	ID         string
+	ReceiverID string
+	Actor      string
+	Operation  HistoryOperation
+	Changes    []FieldChange
+	// Reason is why the status changed. Only status changes have one.
+	Reason    string
+	CreatedAt time.Time
+}
+
+type HistoryPage struct {
+	Entries     []HistoryEntry
+	HasNextPage bool
+}
@@ -0,0 +1,74 @@
+package entity
+
+import "time"
+
+type SortField string
+
+const (
+	SortByID        SortField = "ID"
+	SortByName      SortField = "NAME"
+	SortByCreatedAt SortField = "CREATED_AT"
+	SortByUpdatedAt SortField = "UPDATED_AT"
+	SortByStatus    SortField = "STATUS"
+)
+
+type SortDirection string
+
+const (
+	Ascending  SortDirection = "ASC"
+	Descending SortDirection = "DESC"
+)
+
+type Sort struct {
+	Field     SortField
+	Direction SortDirection
+}
+
+var DefaultSort = Sort{Field: SortByID, Direction: Ascending}
+
+// Cursor points at a receiver within a given ordering. Value holds the sort
+// key of the receiver and is nil when the receiver has no value for it.
+type Cursor struct {
+	ID    string
+	Value *string
+}
+
+type PageRequest struct {
+	Limit    int
+	After    *Cursor
+	Before   *Cursor
+	Backward bool
+	Sort     Sort
+}
+
+type ReceiverPage struct {
+	Receivers       []Receiver
+	HasNextPage     bool
+	HasPreviousPage bool
+}
+
+func (r Receiver) CursorFor(sort Sort) Cursor {
+	cursor := Cursor{ID: r.ID}
+
+	switch sort.Field {
+	case SortByName:
+		cursor.Value = &r.Name
+	case SortByStatus:
+		status := string(r.Status)
+		cursor.Value = &status
+	case SortByCreatedAt:
+		cursor.Value = formatSortTime(r.CreatedAt)
+	case SortByUpdatedAt:
+		cursor.Value = formatSortTime(r.UpdatedAt)
+	}
+
+	return cursor
+}
+
+func formatSortTime(t time.Time) *string {
+	if t.IsZero() {
+		return nil
+	}
+	value := t.UTC().Format(time.RFC3339Nano)
+	return &value
+}
@@ -17,6 +17,8 @@ var mapKeyType = map[string]PixKeyType{"CPF": CPF, "CNPJ": CNPJ, "EMAIL": Email,
 type Pix struct {
 	KeyType PixKeyType
 	Key     string
+	// FormattedKey is the key as it is displayed. Key holds its canonical form.
+	FormattedKey string
 }
 
 func GetKeyType(keyType string) (PixKeyType, error) {
@@ -1,13 +1,29 @@
 package entity
 
+import "time"
+
 type Receiver struct {
 	ID         string
 	Identifier string
-	Name       string
-	Email      string
-	Pix        Pix
-	Bank       *string
-	Agency     *string
-	Account    *string
-	Status     Status
+	// FormattedIdentifier is the identifier with its display punctuation.
+	// Identifier holds only the digits and letters.
+	FormattedIdentifier string
+	Name                string
+	Email               string
+	Pix                 Pix
+	BankAccount         *BankAccount
+	Status              Status
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	Version             int64
+	// Warnings are the consistency rules the receiver breaks without being
+	// refused. They are not stored, the usecases evaluate them.
+	Warnings []Warning
+}
+
+// Warning is a broken consistency rule that does not block the receiver.
+type Warning struct {
+	Code    string
+	Field   string
+	Message string
 }
@@ -1,8 +1,131 @@
 package entity
 
+import "fmt"
+
 type Status string
 
 const (
 	Draft     Status = "Draft"
 	Validated Status = "Validated"
+	Blocked   Status = "Blocked"
+	Archived  Status = "Archived"
+)
+
+// Transition is a move of a receiver from one status to another.
+type Transition string
+
+const (
+	TransitionValidate Transition = "VALIDATE"
+	TransitionBlock    Transition = "BLOCK"
+	TransitionUnblock  Transition = "UNBLOCK"
+	TransitionArchive  Transition = "ARCHIVE"
 )
+
+// TransitionError is returned when a transition is not allowed from the
+// receiver's status or its guard fails.
+type TransitionError struct {
+	From       Status
+	Transition Transition
+	Reason     string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("Cannot %s receiver with status %s: %s", e.Transition, e.From, e.Reason)
+}
+
+// NotEditableError is returned when a receiver is updated in a status that
+// allows no field to change.
+type NotEditableError struct {
+	Status Status
+}
+
+func (e *NotEditableError) Error() string {
+	return fmt.Sprintf("Receiver with status %s cannot be updated", e.Status)
+}
+
+// transitions holds, for each transition, the statuses it starts from and
+// the status it leads to. Archive starts from every status but Archived.
+var transitions = map[Transition]struct {
+	from []Status
+	to   Status
+}{
+	TransitionValidate: {from: []Status{Draft}, to: Validated},
+	TransitionBlock:    {from: []Status{Validated}, to: Blocked},
+	TransitionUnblock:  {from: []Status{Blocked}, to: Validated},
+	TransitionArchive:  {from: []Status{Draft, Validated, Blocked}, to: Archived},
+}
+
+// editableFields are the receiver fields each status allows to update.
+// Blocked and Archived receivers cannot be updated.
+var editableFields = map[Status][]string{
+	Draft:     {"identifier", "name", "email", "pixKeyType", "pixKey", "bankAccount"},
+	Validated: {"email"},
+}
+
+// CanEdit reports whether a receiver with the status allows updating the
+// field, named as in the GraphQL input.
+func (s Status) CanEdit(field string) bool {
+	for _, editable := range editableFields[s] {
+		if editable == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Editable reports whether a receiver with the status allows updating any
+// field.
+func (s Status) Editable() bool {
+	return len(editableFields[s]) > 0
+}
+
+// Next returns the status the receiver moves to with the transition, or a
+// TransitionError when the transition does not start from its status or the
+// receiver does not meet the guard.
+func (r *Receiver) Next(transition Transition) (Status, error) {
+	rule, ok := transitions[transition]
+	if !ok {
+		return "", &TransitionError{From: r.Status, Transition: transition, Reason: "unknown transition"}
+	}
+
+	allowed := false
+	for _, from := range rule.from {
+		allowed = allowed || from == r.Status
+	}
+	if !allowed {
+		return "", &TransitionError{From: r.Status, Transition: transition, Reason: "not allowed from this status"}
+	}
+
+	if err := r.guard(transition); err != nil {
+		return "", err
+	}
+
+	return rule.to, nil
+}
+
+// guard checks the receiver data a transition depends on. Only validation
+// has a guard: a receiver is validated once its data is complete. The check
+// digits are verified by the usecase, since the validation package depends on
+// entity.
+func (r *Receiver) guard(transition Transition) error {
+	if transition != TransitionValidate {
+		return nil
+	}
+
+	missing := ""
+	switch {
+	case r.Identifier == "":
+		missing = "identifier"
+	case r.Name == "":
+		missing = "name"
+	case r.Email == "":
+		missing = "email"
+	case r.Pix.KeyType == "" || r.Pix.Key == "":
+		missing = "pix key"
+	}
+	if missing != "" {
+		return &TransitionError{From: r.Status, Transition: transition, Reason: fmt.Sprintf("%s is missing", missing)}
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/teste-transfeera/internal/entity"
+	"gopkg.in/stretchr/testify.v1/assert"
+)
+
+func completeReceiver(status entity.Status) *entity.Receiver {
+	return &entity.Receiver{
+		Identifier: "52998224725",
+		Name:       "Receiver 1",
+		Email:      "receiver1@gmail.com",
+		Pix:        entity.Pix{KeyType: entity.CPF, Key: "52998224725"},
+		Status:     status,
+	}
+}
+
+func Test_Status_Next_Success(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Should validate a complete Draft receiver", func(t *testing.T) {
+		status, err := completeReceiver(entity.Draft).Next(entity.TransitionValidate)
+		assert.Empty(err)
+		assert.Equal(entity.Validated, status)
+	})
+
+	t.Run("Should block a Validated receiver", func(t *testing.T) {
+		status, err := completeReceiver(entity.Validated).Next(entity.TransitionBlock)
+		assert.Empty(err)
+		assert.Equal(entity.Blocked, status)
+	})
+
+	t.Run("Should unblock a Blocked receiver", func(t *testing.T) {
+		status, err := completeReceiver(entity.Blocked).Next(entity.TransitionUnblock)
+		assert.Empty(err)
+		assert.Equal(entity.Validated, status)
+	})
+
+	t.Run("Should archive a receiver of any other status", func(t *testing.T) {
+		for _, from := range []entity.Status{entity.Draft, entity.Validated, entity.Blocked} {
+			status, err := completeReceiver(from).Next(entity.TransitionArchive)
+			assert.Empty(err)
+			assert.Equal(entity.Archived, status)
+		}
+	})
+}
+
+func Test_Status_Next_Error(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Should not block a Draft receiver", func(t *testing.T) {
+		_, err := completeReceiver(entity.Draft).Next(entity.TransitionBlock)
+		assert.Equal(&entity.TransitionError{From: entity.Draft, Transition: entity.TransitionBlock, Reason: "not allowed from this status"}, err)
+		assert.Equal("Cannot BLOCK receiver with status Draft: not allowed from this status", err.Error())
+	})
+
+	t.Run("Should not validate a Validated receiver again", func(t *testing.T) {
+		_, err := completeReceiver(entity.Validated).Next(entity.TransitionValidate)
+		assert.Equal(&entity.TransitionError{From: entity.Validated, Transition: entity.TransitionValidate, Reason: "not allowed from this status"}, err)
+	})
+
+	t.Run("Should not move an Archived receiver", func(t *testing.T) {
+		for _, transition := range []entity.Transition{entity.TransitionValidate, entity.TransitionBlock, entity.TransitionUnblock, entity.TransitionArchive} {
+			_, err := completeReceiver(entity.Archived).Next(transition)
+			assert.NotNil(err)
+		}
+	})
+
+	t.Run("Should not validate a receiver without pix key", func(t *testing.T) {
+		receiver := completeReceiver(entity.Draft)
+		receiver.Pix = entity.Pix{}
+
+		_, err := receiver.Next(entity.TransitionValidate)
+		assert.Equal(&entity.TransitionError{From: entity.Draft, Transition: entity.TransitionValidate, Reason: "pix key is missing"}, err)
+	})
+}
+
+func Test_Status_CanEdit(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(entity.Draft.CanEdit("pixKey"))
+	assert.True(entity.Validated.CanEdit("email"))
+	assert.False(entity.Validated.CanEdit("name"))
+	assert.False(entity.Blocked.CanEdit("email"))
+	assert.False(entity.Archived.CanEdit("email"))
+	assert.True(entity.Validated.Editable())
+	assert.False(entity.Blocked.Editable())
+}
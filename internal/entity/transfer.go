@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+type TransferMethod string
+
+const (
+	TransferPix TransferMethod = "PIX"
+	TransferTED TransferMethod = "TED"
+)
+
+type TransferStatus string
+
+const (
+	TransferCreated    TransferStatus = "CREATED"
+	TransferProcessing TransferStatus = "PROCESSING"
+	TransferFinished   TransferStatus = "FINISHED"
+	TransferFailed     TransferStatus = "FAILED"
+	TransferReturned   TransferStatus = "RETURNED"
+)
+
+// transferStatusFlow holds the statuses each transfer status moves to. A
+// finished transfer may still be returned by the receiving bank; failed and
+// returned transfers are final.
+var transferStatusFlow = map[TransferStatus][]TransferStatus{
+	TransferCreated:    {TransferProcessing},
+	TransferProcessing: {TransferFinished, TransferFailed, TransferReturned},
+	TransferFinished:   {TransferReturned},
+}
+
+// TransferStatusError is returned when a transfer cannot move from its status
+// to another.
+type TransferStatusError struct {
+	From TransferStatus
+	To   TransferStatus
+}
+
+func (e *TransferStatusError) Error() string {
+	return fmt.Sprintf("Cannot move transfer with status %s to %s", e.From, e.To)
+}
+
+// Transfer is a payout to a receiver. Amount is in cents. The destination is
+// copied from the receiver when the transfer is created, so later changes to
+// the receiver do not redirect it: PixKey for Pix transfers and BankAccount
+// for TED ones. IdempotencyKey is the optional key the client sent to make
+// retries of the creation return the same transfer.
+type Transfer struct {
+	ID             string
+	ReceiverID     string
+	Amount         int64
+	Method         TransferMethod
+	Status         TransferStatus
+	Description    string
+	PixKey         string
+	BankAccount    *BankAccount
+	IdempotencyKey string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// MoveTo checks that the transfer may move to the status, returning a
+// TransferStatusError otherwise.
+func (t *Transfer) MoveTo(status TransferStatus) error {
+	for _, next := range transferStatusFlow[t.Status] {
+		if next == status {
+			return nil
+		}
+	}
+	return &TransferStatusError{From: t.Status, To: status}
+}
+
+type TransferPage struct {
+	Transfers   []Transfer
+	HasNextPage bool
+}
@@ -0,0 +1,46 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/teste-transfeera/internal/entity"
+	"gopkg.in/stretchr/testify.v1/assert"
+)
+
+func Test_Transfer_MoveTo(t *testing.T) {
+	assert := assert.New(t)
+
+	t.Run("Should follow the status flow", func(t *testing.T) {
+		moves := []struct{ from, to entity.TransferStatus }{
+			{entity.TransferCreated, entity.TransferProcessing},
+			{entity.TransferProcessing, entity.TransferFinished},
+			{entity.TransferProcessing, entity.TransferFailed},
+			{entity.TransferProcessing, entity.TransferReturned},
+			{entity.TransferFinished, entity.TransferReturned},
+		}
+
+		for _, move := range moves {
+			transfer := &entity.Transfer{Status: move.from}
+			assert.Empty(transfer.MoveTo(move.to))
+		}
+	})
+
+	t.Run("Should not skip or leave final statuses", func(t *testing.T) {
+		moves := []struct{ from, to entity.TransferStatus }{
+			{entity.TransferCreated, entity.TransferFinished},
+			{entity.TransferProcessing, entity.TransferCreated},
+			{entity.TransferFailed, entity.TransferProcessing},
+			{entity.TransferReturned, entity.TransferFinished},
+		}
+
+		for _, move := range moves {
+			transfer := &entity.Transfer{Status: move.from}
+			assert.Equal(&entity.TransferStatusError{From: move.from, To: move.to}, transfer.MoveTo(move.to))
+		}
+	})
+
+	t.Run("Should describe the refused move", func(t *testing.T) {
+		err := (&entity.Transfer{Status: entity.TransferFailed}).MoveTo(entity.TransferProcessing)
+		assert.Equal("Cannot move transfer with status FAILED to PROCESSING", err.Error())
+	})
+}
@@ -0,0 +1,82 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/repository"
+	"github.com/teste-transfeera/internal/usecase"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const ERROR_CODE_TIMEOUT string = "TIMEOUT"
+const ERROR_CODE_CONFLICT string = "CONFLICT"
+const ERROR_CODE_DUPLICATE_PIX_KEY string = "DUPLICATE_PIX_KEY"
+const ERROR_CODE_DUPLICATE_RECEIVER string = "DUPLICATE_RECEIVER"
+const ERROR_CODE_INVALID_TRANSITION string = "INVALID_TRANSITION"
+const ERROR_CODE_RECEIVER_NOT_VALIDATED string = "RECEIVER_NOT_VALIDATED"
+
+func ErrorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	// A validation error is reported as one GraphQL error per field. All but
+	// the last are added to the response here, and the last is returned.
+	var validationErr *usecase.ValidationError
+	if errors.As(err, &validationErr) && len(validationErr.Errors) > 0 {
+		last := len(validationErr.Errors) - 1
+		for _, fieldErr := range validationErr.Errors[:last] {
+			graphql.AddError(ctx, fieldErr)
+		}
+		err = validationErr.Errors[last]
+	}
+
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+
+	var conflict *repository.VersionConflictError
+	var duplicate *repository.DuplicatePixKeyError
+	var duplicateReceiver *usecase.DuplicateReceiverError
+	var transitionErr *entity.TransitionError
+	var transferStatusErr *entity.TransferStatusError
+	var notEditable *entity.NotEditableError
+	var notValidated *usecase.ReceiverNotValidatedError
+	var keyReused *usecase.IdempotencyKeyReusedError
+	var fieldErr *usecase.FieldError
+	switch {
+	case errors.As(err, &fieldErr):
+		gqlErr.Message = fieldErr.Message
+		setErrorCode(gqlErr, fieldErr.Code)
+		gqlErr.Extensions["field"] = fieldErr.Field
+	case errors.Is(err, repository.ErrTimeout) || errors.Is(err, context.DeadlineExceeded):
+		setErrorCode(gqlErr, ERROR_CODE_TIMEOUT)
+	case errors.As(err, &conflict) || errors.Is(err, repository.ErrTransferStatusChanged):
+		setErrorCode(gqlErr, ERROR_CODE_CONFLICT)
+	case errors.As(err, &keyReused):
+		setErrorCode(gqlErr, ERROR_CODE_CONFLICT)
+		gqlErr.Extensions["field"] = "idempotencyKey"
+		gqlErr.Extensions["transferId"] = keyReused.TransferID
+	case errors.As(err, &duplicate):
+		setErrorCode(gqlErr, ERROR_CODE_DUPLICATE_PIX_KEY)
+		gqlErr.Extensions["field"] = "pixKey"
+		if duplicate.ReceiverID != "" {
+			gqlErr.Extensions["receiverId"] = duplicate.ReceiverID
+		}
+	case errors.As(err, &duplicateReceiver):
+		setErrorCode(gqlErr, ERROR_CODE_DUPLICATE_RECEIVER)
+		gqlErr.Extensions["field"] = "identifier"
+		gqlErr.Extensions["receiverId"] = duplicateReceiver.ReceiverID
+	case errors.As(err, &transitionErr) || errors.As(err, &transferStatusErr) || errors.As(err, &notEditable):
+		setErrorCode(gqlErr, ERROR_CODE_INVALID_TRANSITION)
+	case errors.As(err, &notValidated):
+		setErrorCode(gqlErr, ERROR_CODE_RECEIVER_NOT_VALIDATED)
+		gqlErr.Extensions["receiverId"] = notValidated.ID
+	}
+
+	return gqlErr
+}
+
+func setErrorCode(gqlErr *gqlerror.Error, code string) {
+	if gqlErr.Extensions == nil {
+		gqlErr.Extensions = map[string]interface{}{}
+	}
+	gqlErr.Extensions["code"] = code
+}
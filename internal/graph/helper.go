@@ -1,29 +1,111 @@
 package graph
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/usecase"
+	"github.com/teste-transfeera/pkg/bank"
+	"github.com/teste-transfeera/pkg/cursor"
+	"github.com/teste-transfeera/pkg/shared"
 )
 
 const TOTAL_PER_PAGE int = 10
 
+// MAX_PER_PAGE is the largest first or last a connection accepts.
+const MAX_PER_PAGE int = 100
+
 func ToOutput(entity entity.Receiver) *Receiver {
-	return &Receiver{
-		ID:         entity.ID,
-		Name:       entity.Name,
-		Email:      entity.Email,
-		Identifier: entity.Identifier,
+	output := &Receiver{
+		ID:                  entity.ID,
+		Name:                entity.Name,
+		Email:               entity.Email,
+		Identifier:          entity.Identifier,
+		FormattedIdentifier: entity.FormattedIdentifier,
 		Pix: &Pix{
-			KeyType: string(entity.Pix.KeyType),
-			Key:     entity.Pix.Key,
+			KeyType:      string(entity.Pix.KeyType),
+			Key:          entity.Pix.Key,
+			FormattedKey: entity.Pix.FormattedKey,
 		},
-		Bank:    entity.Bank,
-		Agency:  entity.Agency,
-		Account: entity.Account,
-		Status:  (*string)(&entity.Status),
+		Status:   (*string)(&entity.Status),
+		Version:  int(entity.Version),
+		Warnings: []*Warning{},
+	}
+	for _, warning := range entity.Warnings {
+		output.Warnings = append(output.Warnings, &Warning{Code: warning.Code, Field: warning.Field, Message: warning.Message})
 	}
+	if account := entity.BankAccount; account != nil {
+		output.BankAccount = ToBankAccountOutput(account)
+		output.Bank = output.BankAccount.BankName
+		if output.Bank == nil {
+			output.Bank = shared.GetPointerStr(account.BankCode)
+		}
+		output.Agency = shared.GetPointerStr(account.FormattedAgency())
+		output.Account = shared.GetPointerStr(account.FormattedAccount())
+	}
+
+	return output
 }
 
-func BuildFilter(status *string, name *string, keyType *string, key *string) map[string]string {
+// ToBankAccountOutput resolves the bank name from the directory. Accounts
+// stored before the ISPB was recorded take it from the directory as well.
+func ToBankAccountOutput(account *entity.BankAccount) *BankAccount {
+	output := &BankAccount{
+		BankCode:     account.BankCode,
+		Agency:       account.Agency,
+		Account:      account.Account,
+		AccountDigit: account.AccountDigit,
+		AccountType:  AccountType(account.AccountType),
+		Verified:     account.Verified,
+	}
+	if account.ISPB != "" {
+		output.Ispb = shared.GetPointerStr(account.ISPB)
+	}
+	if institution, ok := bank.ByCode(account.BankCode); ok {
+		output.BankName = shared.GetPointerStr(institution.ShortName)
+		if output.Ispb == nil {
+			output.Ispb = shared.GetPointerStr(institution.ISPB)
+		}
+	}
+	if account.AgencyDigit != "" {
+		output.AgencyDigit = shared.GetPointerStr(account.AgencyDigit)
+	}
+	return output
+}
+
+func ToBankOutput(institution bank.Institution) *Bank {
+	return &Bank{
+		Code:           institution.Code,
+		Ispb:           institution.ISPB,
+		ShortName:      institution.ShortName,
+		Name:           institution.Name,
+		PixParticipant: institution.PixParticipant,
+	}
+}
+
+// BuildBankAccountInput converts the GraphQL bank account, which is optional
+// in both receiver inputs.
+func BuildBankAccountInput(input *BankAccountInput) *usecase.BankAccountInput {
+	if input == nil {
+		return nil
+	}
+
+	return &usecase.BankAccountInput{
+		BankCode:     input.BankCode,
+		Agency:       input.Agency,
+		AgencyDigit:  shared.GetValueStr(input.AgencyDigit),
+		Account:      input.Account,
+		AccountDigit: input.AccountDigit,
+		AccountType:  string(input.AccountType),
+	}
+}
+
+func BuildFilter(status *string, name *string, keyType *string, key *string, search *string) map[string]string {
 	filter := make(map[string]string)
 
 	if status != nil {
@@ -38,6 +120,254 @@ func BuildFilter(status *string, name *string, keyType *string, key *string) map
 	if key != nil {
 		filter["key"] = *key
 	}
+	if search != nil {
+		filter["search"] = *search
+	}
 
 	return filter
 }
+
+func BuildSort(orderBy *ReceiverOrder) entity.Sort {
+	if orderBy == nil {
+		return entity.DefaultSort
+	}
+
+	return entity.Sort{
+		Field:     entity.SortField(orderBy.Field),
+		Direction: entity.SortDirection(orderBy.Direction),
+	}
+}
+
+// The kinds of cursor each connection issues. Cursors are signed with their
+// kind, so a receiver cursor is rejected by the history or transfers lists
+// even when their orderings match.
+const (
+	receiverCursor cursor.Kind = "receiver"
+	historyCursor  cursor.Kind = "history"
+	transferCursor cursor.Kind = "transfer"
+)
+
+func BuildPageRequest(codec *cursor.Codec, sort entity.Sort, first *int, after *string, last *int, before *string) (entity.PageRequest, error) {
+	page := entity.PageRequest{
+		Limit: TOTAL_PER_PAGE,
+		Sort:  sort,
+	}
+
+	if first != nil && last != nil {
+		return page, errors.New("first and last cannot be used together")
+	}
+
+	if first != nil {
+		if err := checkPageSize("first", *first); err != nil {
+			return page, err
+		}
+		page.Limit = *first
+	}
+
+	if last != nil {
+		if err := checkPageSize("last", *last); err != nil {
+			return page, err
+		}
+		page.Limit = *last
+		page.Backward = true
+	}
+
+	if after != nil {
+		decoded, err := codec.Decode(*after, receiverCursor, sort)
+		if err != nil {
+			return page, fmt.Errorf("Invalid after cursor: %w", err)
+		}
+		page.After = decoded
+	}
+
+	if before != nil {
+		decoded, err := codec.Decode(*before, receiverCursor, sort)
+		if err != nil {
+			return page, fmt.Errorf("Invalid before cursor: %w", err)
+		}
+		page.Before = decoded
+	}
+
+	return page, nil
+}
+
+// checkPageSize rejects page sizes that are negative or above MAX_PER_PAGE.
+func checkPageSize(name string, size int) error {
+	if size < 0 {
+		return fmt.Errorf("%s must not be negative", name)
+	}
+	if size > MAX_PER_PAGE {
+		return fmt.Errorf("%s must not be greater than %d", name, MAX_PER_PAGE)
+	}
+	return nil
+}
+
+func IsFieldSelected(ctx context.Context, field string) bool {
+	for _, selected := range graphql.CollectAllFields(ctx) {
+		if selected == field {
+			return true
+		}
+	}
+	return false
+}
+
+// historySort is the only ordering of a receiver history, oldest first.
+var historySort = entity.Sort{Field: entity.SortByID, Direction: entity.Ascending}
+
+func BuildHistoryInput(codec *cursor.Codec, id string, first *int, after *string) (*usecase.ListReceiverHistoryInput, error) {
+	input := &usecase.ListReceiverHistoryInput{
+		ReceiverId: id,
+		Limit:      TOTAL_PER_PAGE,
+	}
+
+	if first != nil {
+		if err := checkPageSize("first", *first); err != nil {
+			return nil, err
+		}
+		input.Limit = *first
+	}
+
+	if after != nil {
+		decoded, err := codec.Decode(*after, historyCursor, historySort)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid after cursor: %w", err)
+		}
+		input.After = &decoded.ID
+	}
+
+	return input, nil
+}
+
+func ToHistoryOutput(codec *cursor.Codec, page entity.HistoryPage) *ReceiverHistory {
+	edges := make([]*HistoryEdge, len(page.Entries))
+	for i, entry := range page.Entries {
+		changes := make([]*FieldChange, len(entry.Changes))
+		for j, change := range entry.Changes {
+			changes[j] = &FieldChange{
+				Field:  change.Field,
+				Before: change.Before,
+				After:  change.After,
+			}
+		}
+
+		var reason *string
+		if entry.Reason != "" {
+			reason = shared.GetPointerStr(entry.Reason)
+		}
+
+		edges[i] = &HistoryEdge{
+			Cursor: codec.Encode(historyCursor, entity.Cursor{ID: entry.ID}, historySort),
+			Node: &HistoryEntry{
+				ID:        entry.ID,
+				Actor:     entry.Actor,
+				Operation: HistoryOperation(entry.Operation),
+				Changes:   changes,
+				Reason:    reason,
+				CreatedAt: entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+			},
+		}
+	}
+
+	pageInfo := PageInfo{
+		HasNextPage:     &page.HasNextPage,
+		HasPreviousPage: shared.GetPointerBool(false),
+	}
+	if len(edges) > 0 {
+		pageInfo.StartCursor = edges[0].Cursor
+		pageInfo.EndCursor = edges[len(edges)-1].Cursor
+	}
+
+	return &ReceiverHistory{
+		Edges:    edges,
+		PageInfo: &pageInfo,
+	}
+}
+
+func BuildTransferInput(input NewTransfer) *usecase.CreateTransferInput {
+	return &usecase.CreateTransferInput{
+		ReceiverId:     input.ReceiverID,
+		Amount:         int64(input.Amount),
+		Method:         entity.TransferMethod(input.Method),
+		Description:    shared.GetValueStr(input.Description),
+		IdempotencyKey: shared.GetValueStr(input.IdempotencyKey),
+	}
+}
+
+// transferSort is the only ordering of listTransfers, newest first.
+var transferSort = entity.Sort{Field: entity.SortByID, Direction: entity.Descending}
+
+func BuildTransfersInput(codec *cursor.Codec, receiverID *string, status *TransferStatus, first *int, after *string) (*usecase.ListTransfersInput, error) {
+	input := &usecase.ListTransfersInput{
+		ReceiverId: shared.GetValueStr(receiverID),
+		Limit:      TOTAL_PER_PAGE,
+	}
+
+	if status != nil {
+		input.Status = entity.TransferStatus(*status)
+	}
+
+	if first != nil {
+		if err := checkPageSize("first", *first); err != nil {
+			return nil, err
+		}
+		input.Limit = *first
+	}
+
+	if after != nil {
+		decoded, err := codec.Decode(*after, transferCursor, transferSort)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid after cursor: %w", err)
+		}
+		input.After = &decoded.ID
+	}
+
+	return input, nil
+}
+
+func ToTransferOutput(transfer entity.Transfer) *Transfer {
+	output := &Transfer{
+		ID:         transfer.ID,
+		ReceiverID: transfer.ReceiverID,
+		Amount:     int(transfer.Amount),
+		Method:     TransferMethod(transfer.Method),
+		Status:     TransferStatus(transfer.Status),
+		CreatedAt:  transfer.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+	if transfer.Description != "" {
+		output.Description = shared.GetPointerStr(transfer.Description)
+	}
+	if transfer.PixKey != "" {
+		output.PixKey = shared.GetPointerStr(transfer.PixKey)
+	}
+	if transfer.BankAccount != nil {
+		output.BankAccount = ToBankAccountOutput(transfer.BankAccount)
+	}
+	if !transfer.UpdatedAt.IsZero() {
+		output.UpdatedAt = shared.GetPointerStr(transfer.UpdatedAt.UTC().Format(time.RFC3339Nano))
+	}
+	return output
+}
+
+func ToTransfersOutput(codec *cursor.Codec, page entity.TransferPage) *Transfers {
+	edges := make([]*TransferEdge, len(page.Transfers))
+	for i, transfer := range page.Transfers {
+		edges[i] = &TransferEdge{
+			Cursor: codec.Encode(transferCursor, entity.Cursor{ID: transfer.ID}, transferSort),
+			Node:   ToTransferOutput(transfer),
+		}
+	}
+
+	pageInfo := PageInfo{
+		HasNextPage:     &page.HasNextPage,
+		HasPreviousPage: shared.GetPointerBool(false),
+	}
+	if len(edges) > 0 {
+		pageInfo.StartCursor = edges[0].Cursor
+		pageInfo.EndCursor = edges[len(edges)-1].Cursor
+	}
+
+	return &Transfers{
+		Edges:    edges,
+		PageInfo: &pageInfo,
+	}
+}
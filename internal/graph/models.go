@@ -2,52 +2,465 @@
 
 package graph
 
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type Bank struct {
+	Code           string `json:"code"`
+	Ispb           string `json:"ispb"`
+	ShortName      string `json:"shortName"`
+	Name           string `json:"name"`
+	PixParticipant bool   `json:"pixParticipant"`
+}
+
+type BankAccount struct {
+	BankCode     string      `json:"bankCode"`
+	Ispb         *string     `json:"ispb"`
+	BankName     *string     `json:"bankName"`
+	Agency       string      `json:"agency"`
+	AgencyDigit  *string     `json:"agencyDigit"`
+	Account      string      `json:"account"`
+	AccountDigit string      `json:"accountDigit"`
+	AccountType  AccountType `json:"accountType"`
+	Verified     bool        `json:"verified"`
+}
+
+type BankAccountInput struct {
+	BankCode     string      `json:"bankCode"`
+	Agency       string      `json:"agency"`
+	AgencyDigit  *string     `json:"agencyDigit"`
+	Account      string      `json:"account"`
+	AccountDigit string      `json:"accountDigit"`
+	AccountType  AccountType `json:"accountType"`
+}
+
+type ChangeReceiverStatus struct {
+	ID              string `json:"id"`
+	Reason          string `json:"reason"`
+	ExpectedVersion *int   `json:"expectedVersion"`
+}
+
+type ChangeTransferStatus struct {
+	ID     string         `json:"id"`
+	Status TransferStatus `json:"status"`
+}
+
 type Edge struct {
 	Cursor string    `json:"cursor"`
 	Node   *Receiver `json:"node"`
 }
 
+type FieldChange struct {
+	Field  string  `json:"field"`
+	Before *string `json:"before"`
+	After  *string `json:"after"`
+}
+
+type HistoryEdge struct {
+	Cursor string        `json:"cursor"`
+	Node   *HistoryEntry `json:"node"`
+}
+
+type HistoryEntry struct {
+	ID        string           `json:"id"`
+	Actor     string           `json:"actor"`
+	Operation HistoryOperation `json:"operation"`
+	Changes   []*FieldChange   `json:"changes"`
+	Reason    *string          `json:"reason"`
+	CreatedAt string           `json:"createdAt"`
+}
+
 type NewReceiver struct {
-	Identifier string `json:"identifier"`
-	Name       string `json:"name"`
-	Email      string `json:"email"`
-	PixKeyType string `json:"pixKeyType"`
-	PixKey     string `json:"pixKey"`
+	Identifier  string             `json:"identifier"`
+	Name        string             `json:"name"`
+	Email       string             `json:"email"`
+	PixKeyType  string             `json:"pixKeyType"`
+	PixKey      string             `json:"pixKey"`
+	BankAccount *BankAccountInput  `json:"bankAccount"`
+	OnDuplicate *DuplicateStrategy `json:"onDuplicate"`
+}
+
+type NewTransfer struct {
+	ReceiverID     string         `json:"receiverId"`
+	Amount         int            `json:"amount"`
+	Method         TransferMethod `json:"method"`
+	Description    *string        `json:"description"`
+	IdempotencyKey *string        `json:"idempotencyKey"`
 }
 
 type PageInfo struct {
-	StartCursor string `json:"startCursor"`
-	EndCursor   string `json:"endCursor"`
-	HasNextPage *bool  `json:"hasNextPage"`
+	StartCursor     string `json:"startCursor"`
+	EndCursor       string `json:"endCursor"`
+	HasNextPage     *bool  `json:"hasNextPage"`
+	HasPreviousPage *bool  `json:"hasPreviousPage"`
 }
 
 type Pix struct {
-	KeyType string `json:"keyType"`
-	Key     string `json:"key"`
+	KeyType      string `json:"keyType"`
+	Key          string `json:"key"`
+	FormattedKey string `json:"formattedKey"`
+}
+
+type ReceiverHistory struct {
+	Edges    []*HistoryEdge `json:"edges"`
+	PageInfo *PageInfo      `json:"pageInfo"`
 }
 
-type Receiver struct {
-	ID         string  `json:"id"`
-	Identifier string  `json:"identifier"`
-	Name       string  `json:"name"`
-	Email      string  `json:"email"`
-	Pix        *Pix    `json:"pix"`
-	Bank       *string `json:"bank"`
-	Agency     *string `json:"agency"`
-	Account    *string `json:"account"`
-	Status     *string `json:"status"`
+type ReceiverOrder struct {
+	Field     ReceiverOrderField `json:"field"`
+	Direction OrderDirection     `json:"direction"`
 }
 
 type Receivers struct {
-	Edges    []*Edge   `json:"edges"`
-	PageInfo *PageInfo `json:"pageInfo"`
+	Edges      []*Edge   `json:"edges"`
+	PageInfo   *PageInfo `json:"pageInfo"`
+	TotalCount *int      `json:"totalCount"`
+}
+
+type Transfer struct {
+	ID          string         `json:"id"`
+	ReceiverID  string         `json:"receiverId"`
+	Amount      int            `json:"amount"`
+	Method      TransferMethod `json:"method"`
+	Status      TransferStatus `json:"status"`
+	Description *string        `json:"description"`
+	PixKey      *string        `json:"pixKey"`
+	BankAccount *BankAccount   `json:"bankAccount"`
+	CreatedAt   string         `json:"createdAt"`
+	UpdatedAt   *string        `json:"updatedAt"`
+}
+
+type TransferEdge struct {
+	Cursor string    `json:"cursor"`
+	Node   *Transfer `json:"node"`
+}
+
+type Transfers struct {
+	Edges    []*TransferEdge `json:"edges"`
+	PageInfo *PageInfo       `json:"pageInfo"`
 }
 
 type UpdateReceiver struct {
-	ID         string  `json:"id"`
-	Identifier *string `json:"identifier"`
-	Name       *string `json:"name"`
-	Email      *string `json:"email"`
-	PixKeyType *string `json:"pixKeyType"`
-	PixKey     *string `json:"pixKey"`
+	ID              string            `json:"id"`
+	Identifier      *string           `json:"identifier"`
+	Name            *string           `json:"name"`
+	Email           *string           `json:"email"`
+	PixKeyType      *string           `json:"pixKeyType"`
+	PixKey          *string           `json:"pixKey"`
+	BankAccount     *BankAccountInput `json:"bankAccount"`
+	ExpectedVersion *int              `json:"expectedVersion"`
+}
+
+type Warning struct {
+	Code    string `json:"code"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type AccountType string
+
+const (
+	AccountTypeChecking AccountType = "CHECKING"
+	AccountTypeSavings  AccountType = "SAVINGS"
+	AccountTypePayment  AccountType = "PAYMENT"
+)
+
+var AllAccountType = []AccountType{
+	AccountTypeChecking,
+	AccountTypeSavings,
+	AccountTypePayment,
+}
+
+func (e AccountType) IsValid() bool {
+	switch e {
+	case AccountTypeChecking, AccountTypeSavings, AccountTypePayment:
+		return true
+	}
+	return false
+}
+
+func (e AccountType) String() string {
+	return string(e)
+}
+
+func (e *AccountType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AccountType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AccountType", str)
+	}
+	return nil
+}
+
+func (e AccountType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type DuplicateStrategy string
+
+const (
+	DuplicateStrategyFail           DuplicateStrategy = "FAIL"
+	DuplicateStrategyReturnExisting DuplicateStrategy = "RETURN_EXISTING"
+	DuplicateStrategyUpdateDraft    DuplicateStrategy = "UPDATE_DRAFT"
+)
+
+var AllDuplicateStrategy = []DuplicateStrategy{
+	DuplicateStrategyFail,
+	DuplicateStrategyReturnExisting,
+	DuplicateStrategyUpdateDraft,
+}
+
+func (e DuplicateStrategy) IsValid() bool {
+	switch e {
+	case DuplicateStrategyFail, DuplicateStrategyReturnExisting, DuplicateStrategyUpdateDraft:
+		return true
+	}
+	return false
+}
+
+func (e DuplicateStrategy) String() string {
+	return string(e)
+}
+
+func (e *DuplicateStrategy) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DuplicateStrategy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DuplicateStrategy", str)
+	}
+	return nil
+}
+
+func (e DuplicateStrategy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type HistoryOperation string
+
+const (
+	HistoryOperationCreate       HistoryOperation = "CREATE"
+	HistoryOperationUpdate       HistoryOperation = "UPDATE"
+	HistoryOperationDelete       HistoryOperation = "DELETE"
+	HistoryOperationStatusChange HistoryOperation = "STATUS_CHANGE"
+)
+
+var AllHistoryOperation = []HistoryOperation{
+	HistoryOperationCreate,
+	HistoryOperationUpdate,
+	HistoryOperationDelete,
+	HistoryOperationStatusChange,
+}
+
+func (e HistoryOperation) IsValid() bool {
+	switch e {
+	case HistoryOperationCreate, HistoryOperationUpdate, HistoryOperationDelete, HistoryOperationStatusChange:
+		return true
+	}
+	return false
+}
+
+func (e HistoryOperation) String() string {
+	return string(e)
+}
+
+func (e *HistoryOperation) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = HistoryOperation(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid HistoryOperation", str)
+	}
+	return nil
+}
+
+func (e HistoryOperation) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type OrderDirection string
+
+const (
+	OrderDirectionAsc  OrderDirection = "ASC"
+	OrderDirectionDesc OrderDirection = "DESC"
+)
+
+var AllOrderDirection = []OrderDirection{
+	OrderDirectionAsc,
+	OrderDirectionDesc,
+}
+
+func (e OrderDirection) IsValid() bool {
+	switch e {
+	case OrderDirectionAsc, OrderDirectionDesc:
+		return true
+	}
+	return false
+}
+
+func (e OrderDirection) String() string {
+	return string(e)
+}
+
+func (e *OrderDirection) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderDirection", str)
+	}
+	return nil
+}
+
+func (e OrderDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ReceiverOrderField string
+
+const (
+	ReceiverOrderFieldName      ReceiverOrderField = "NAME"
+	ReceiverOrderFieldCreatedAt ReceiverOrderField = "CREATED_AT"
+	ReceiverOrderFieldUpdatedAt ReceiverOrderField = "UPDATED_AT"
+	ReceiverOrderFieldStatus    ReceiverOrderField = "STATUS"
+)
+
+var AllReceiverOrderField = []ReceiverOrderField{
+	ReceiverOrderFieldName,
+	ReceiverOrderFieldCreatedAt,
+	ReceiverOrderFieldUpdatedAt,
+	ReceiverOrderFieldStatus,
+}
+
+func (e ReceiverOrderField) IsValid() bool {
+	switch e {
+	case ReceiverOrderFieldName, ReceiverOrderFieldCreatedAt, ReceiverOrderFieldUpdatedAt, ReceiverOrderFieldStatus:
+		return true
+	}
+	return false
+}
+
+func (e ReceiverOrderField) String() string {
+	return string(e)
+}
+
+func (e *ReceiverOrderField) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReceiverOrderField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReceiverOrderField", str)
+	}
+	return nil
+}
+
+func (e ReceiverOrderField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type TransferMethod string
+
+const (
+	TransferMethodPix TransferMethod = "PIX"
+	TransferMethodTed TransferMethod = "TED"
+)
+
+var AllTransferMethod = []TransferMethod{
+	TransferMethodPix,
+	TransferMethodTed,
+}
+
+func (e TransferMethod) IsValid() bool {
+	switch e {
+	case TransferMethodPix, TransferMethodTed:
+		return true
+	}
+	return false
+}
+
+func (e TransferMethod) String() string {
+	return string(e)
+}
+
+func (e *TransferMethod) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TransferMethod(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TransferMethod", str)
+	}
+	return nil
+}
+
+func (e TransferMethod) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type TransferStatus string
+
+const (
+	TransferStatusCreated    TransferStatus = "CREATED"
+	TransferStatusProcessing TransferStatus = "PROCESSING"
+	TransferStatusFinished   TransferStatus = "FINISHED"
+	TransferStatusFailed     TransferStatus = "FAILED"
+	TransferStatusReturned   TransferStatus = "RETURNED"
+)
+
+var AllTransferStatus = []TransferStatus{
+	TransferStatusCreated,
+	TransferStatusProcessing,
+	TransferStatusFinished,
+	TransferStatusFailed,
+	TransferStatusReturned,
+}
+
+func (e TransferStatus) IsValid() bool {
+	switch e {
+	case TransferStatusCreated, TransferStatusProcessing, TransferStatusFinished, TransferStatusFailed, TransferStatusReturned:
+		return true
+	}
+	return false
+}
+
+func (e TransferStatus) String() string {
+	return string(e)
+}
+
+func (e *TransferStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TransferStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TransferStatus", str)
+	}
+	return nil
+}
+
+func (e TransferStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
 }
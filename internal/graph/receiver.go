@@ -0,0 +1,19 @@
+package graph
+
+// Receiver is bound in gqlgen.yml instead of generated, so that history,
+// which takes arguments, is always loaded by its own resolver.
+type Receiver struct {
+	ID                  string       `json:"id"`
+	Identifier          string       `json:"identifier"`
+	FormattedIdentifier string       `json:"formattedIdentifier"`
+	Name                string       `json:"name"`
+	Email               string       `json:"email"`
+	Pix                 *Pix         `json:"pix"`
+	Bank                *string      `json:"bank"`
+	Agency              *string      `json:"agency"`
+	Account             *string      `json:"account"`
+	BankAccount         *BankAccount `json:"bankAccount"`
+	Status              *string      `json:"status"`
+	Version             int          `json:"version"`
+	Warnings            []*Warning   `json:"warnings"`
+}
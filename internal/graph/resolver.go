@@ -1,7 +1,12 @@
 package graph
 
 import (
+	"context"
+
+	"github.com/teste-transfeera/internal/entity"
 	"github.com/teste-transfeera/internal/usecase"
+	"github.com/teste-transfeera/pkg/cursor"
+	"github.com/teste-transfeera/pkg/shared"
 )
 
 // This file will not be regenerated automatically.
@@ -10,4 +15,40 @@ import (
 
 type Resolver struct {
 	ReceiverUseCases usecase.ReceiverUseCases
+	TransferUseCases usecase.TransferUseCases
+	Cursors          *cursor.Codec
+}
+
+// listHistory backs both receiverHistory and the history field of Receiver.
+func (r *Resolver) listHistory(ctx context.Context, id string, first *int, after *string) (*ReceiverHistory, error) {
+	input, err := BuildHistoryInput(r.Cursors, id, first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.ReceiverUseCases.ListHistory(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return ToHistoryOutput(r.Cursors, *result), nil
+}
+
+// changeStatus backs the mutations that move a receiver between statuses.
+func (r *Resolver) changeStatus(ctx context.Context, input ChangeReceiverStatus, transition entity.Transition) (*Receiver, error) {
+	usecaseInput := &usecase.ChangeReceiverStatusInput{
+		Id:         input.ID,
+		Transition: transition,
+		Reason:     input.Reason,
+	}
+	if input.ExpectedVersion != nil {
+		usecaseInput.ExpectedVersion = shared.GetPointerInt64(int64(*input.ExpectedVersion))
+	}
+
+	result, err := r.ReceiverUseCases.ChangeStatus(ctx, usecaseInput)
+	if err != nil {
+		return nil, err
+	}
+
+	return ToOutput(*result), nil
 }
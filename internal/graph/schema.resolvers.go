@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/teste-transfeera/internal/entity"
 	"github.com/teste-transfeera/internal/usecase"
 	"github.com/teste-transfeera/pkg/shared"
 )
@@ -15,14 +16,34 @@ import (
 // CreateReceiver is the resolver for the createReceiver field.
 func (r *mutationResolver) CreateReceiver(ctx context.Context, input NewReceiver) (*Receiver, error) {
 	usecaseInput := &usecase.CreateReceiverInput{
-		Name:       input.Name,
-		Email:      input.Email,
-		Identifier: input.Identifier,
-		PixKeyType: input.PixKeyType,
-		PixKey:     input.PixKey,
+		Name:        input.Name,
+		Email:       input.Email,
+		Identifier:  input.Identifier,
+		PixKeyType:  input.PixKeyType,
+		PixKey:      input.PixKey,
+		BankAccount: BuildBankAccountInput(input.BankAccount),
+	}
+	if input.OnDuplicate != nil {
+		usecaseInput.OnDuplicate = usecase.DuplicateStrategy(*input.OnDuplicate)
+	}
+
+	result, err := r.ReceiverUseCases.Create(ctx, usecaseInput)
+	if err != nil {
+		return nil, err
+	}
+
+	return ToOutput(*result), nil
+}
+
+// CreateReceiverFromBRCode is the resolver for the createReceiverFromBRCode field.
+func (r *mutationResolver) CreateReceiverFromBRCode(ctx context.Context, payload string, identifier string, email string) (*Receiver, error) {
+	usecaseInput := &usecase.CreateReceiverFromBRCodeInput{
+		Payload:    payload,
+		Identifier: identifier,
+		Email:      email,
 	}
 
-	result, err := r.ReceiverUseCases.Create(usecaseInput)
+	result, err := r.ReceiverUseCases.CreateFromBRCode(ctx, usecaseInput)
 	if err != nil {
 		return nil, err
 	}
@@ -36,7 +57,7 @@ func (r *mutationResolver) DeleteReceivers(ctx context.Context, ids []string) (s
 		Ids: ids,
 	}
 
-	err := r.ReceiverUseCases.Delete(usecaseInput)
+	err := r.ReceiverUseCases.Delete(ctx, usecaseInput)
 	if err != nil {
 		return "", err
 	}
@@ -46,23 +67,69 @@ func (r *mutationResolver) DeleteReceivers(ctx context.Context, ids []string) (s
 }
 
 // UpdateReceiver is the resolver for the updateReceiver field.
-func (r *mutationResolver) UpdateReceiver(ctx context.Context, input UpdateReceiver) (string, error) {
+func (r *mutationResolver) UpdateReceiver(ctx context.Context, input UpdateReceiver) (*Receiver, error) {
 	usecaseInput := &usecase.UpdateReceiverInput{
-		Id:         input.ID,
-		Name:       shared.GetValueStr(input.Name),
-		Email:      shared.GetValueStr(input.Email),
-		Identifier: shared.GetValueStr(input.Identifier),
-		PixKeyType: shared.GetValueStr(input.PixKeyType),
-		PixKey:     shared.GetValueStr(input.PixKey),
+		Id:          input.ID,
+		Name:        shared.GetValueStr(input.Name),
+		Email:       shared.GetValueStr(input.Email),
+		Identifier:  shared.GetValueStr(input.Identifier),
+		PixKeyType:  shared.GetValueStr(input.PixKeyType),
+		PixKey:      shared.GetValueStr(input.PixKey),
+		BankAccount: BuildBankAccountInput(input.BankAccount),
+	}
+	if input.ExpectedVersion != nil {
+		usecaseInput.ExpectedVersion = shared.GetPointerInt64(int64(*input.ExpectedVersion))
 	}
 
-	err := r.ReceiverUseCases.Update(usecaseInput)
+	result, err := r.ReceiverUseCases.Update(ctx, usecaseInput)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	result := fmt.Sprintf("Updated %s successfully", input.ID)
-	return result, nil
+	return ToOutput(*result), nil
+}
+
+// ValidateReceiver is the resolver for the validateReceiver field.
+func (r *mutationResolver) ValidateReceiver(ctx context.Context, input ChangeReceiverStatus) (*Receiver, error) {
+	return r.changeStatus(ctx, input, entity.TransitionValidate)
+}
+
+// BlockReceiver is the resolver for the blockReceiver field.
+func (r *mutationResolver) BlockReceiver(ctx context.Context, input ChangeReceiverStatus) (*Receiver, error) {
+	return r.changeStatus(ctx, input, entity.TransitionBlock)
+}
+
+// UnblockReceiver is the resolver for the unblockReceiver field.
+func (r *mutationResolver) UnblockReceiver(ctx context.Context, input ChangeReceiverStatus) (*Receiver, error) {
+	return r.changeStatus(ctx, input, entity.TransitionUnblock)
+}
+
+// ArchiveReceiver is the resolver for the archiveReceiver field.
+func (r *mutationResolver) ArchiveReceiver(ctx context.Context, input ChangeReceiverStatus) (*Receiver, error) {
+	return r.changeStatus(ctx, input, entity.TransitionArchive)
+}
+
+// CreateTransfer is the resolver for the createTransfer field.
+func (r *mutationResolver) CreateTransfer(ctx context.Context, input NewTransfer) (*Transfer, error) {
+	result, err := r.TransferUseCases.Create(ctx, BuildTransferInput(input))
+	if err != nil {
+		return nil, err
+	}
+
+	return ToTransferOutput(*result), nil
+}
+
+// ChangeTransferStatus is the resolver for the changeTransferStatus field.
+func (r *mutationResolver) ChangeTransferStatus(ctx context.Context, input ChangeTransferStatus) (*Transfer, error) {
+	result, err := r.TransferUseCases.ChangeStatus(ctx, &usecase.ChangeTransferStatusInput{
+		Id:     input.ID,
+		Status: entity.TransferStatus(input.Status),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ToTransferOutput(*result), nil
 }
 
 // Receiver is the resolver for the receiver field.
@@ -71,7 +138,7 @@ func (r *queryResolver) Receiver(ctx context.Context, id string) (*Receiver, err
 		Id: id,
 	}
 
-	result, err := r.ReceiverUseCases.ListById(usecaseInput)
+	result, err := r.ReceiverUseCases.ListById(ctx, usecaseInput)
 	if err != nil {
 		return nil, err
 	}
@@ -79,71 +146,116 @@ func (r *queryResolver) Receiver(ctx context.Context, id string) (*Receiver, err
 	return ToOutput(*result), nil
 }
 
+// ReceiverHistory is the resolver for the receiverHistory field.
+func (r *queryResolver) ReceiverHistory(ctx context.Context, id string, first *int, after *string) (*ReceiverHistory, error) {
+	return r.listHistory(ctx, id, first, after)
+}
+
 // ListReceivers is the resolver for the listReceivers field.
-func (r *queryResolver) ListReceivers(ctx context.Context, first *int, after *string, status *string, name *string, keyType *string, key *string) (*Receivers, error) {
-	filter := BuildFilter(status, name, keyType, key)
-	receivers, err := r.ReceiverUseCases.List(filter)
+func (r *queryResolver) ListReceivers(ctx context.Context, first *int, after *string, last *int, before *string, orderBy *ReceiverOrder, status *string, name *string, keyType *string, key *string, search *string) (*Receivers, error) {
+	filter := BuildFilter(status, name, keyType, key, search)
+	sort := BuildSort(orderBy)
+	page, err := BuildPageRequest(r.Cursors, sort, first, after, last, before)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(receivers) == 0 {
-		return &Receivers{
-			Edges:    []*Edge{},
-			PageInfo: &PageInfo{},
-		}, nil
+	result, err := r.ReceiverUseCases.List(ctx, filter, page)
+	if err != nil {
+		return nil, err
 	}
 
-	totalPerPage := TOTAL_PER_PAGE
-	if first != nil {
-		totalPerPage = *first
+	edges := make([]*Edge, len(result.Receivers))
+	for i, receiver := range result.Receivers {
+		edges[i] = &Edge{
+			Cursor: r.Cursors.Encode(receiverCursor, receiver.CursorFor(sort), sort),
+			Node:   ToOutput(receiver),
+		}
 	}
 
-	isInCurrentPage := true
-	var cursor string
-	if after != nil {
-		cursor, err = shared.DecodeBase64(*after)
-		if cursor != "" {
-			isInCurrentPage = false
-		}
+	pageInfo := PageInfo{
+		HasNextPage:     &result.HasNextPage,
+		HasPreviousPage: &result.HasPreviousPage,
+	}
+	if len(edges) > 0 {
+		pageInfo.StartCursor = edges[0].Cursor
+		pageInfo.EndCursor = edges[len(edges)-1].Cursor
 	}
 
-	count := 0
-	hasNextPage := false
-	edges := make([]*Edge, totalPerPage)
-	for i, receiver := range receivers {
-		hasReachedTotalPerPage := count == totalPerPage
+	receivers := Receivers{
+		Edges:    edges,
+		PageInfo: &pageInfo,
+	}
 
-		if isInCurrentPage && !hasReachedTotalPerPage {
-			edges[count] = &Edge{
-				Cursor: shared.EncodeBase64([]byte(receiver.ID)),
-				Node:   ToOutput(receiver),
-			}
-			count++
+	if IsFieldSelected(ctx, "totalCount") {
+		total, err := r.ReceiverUseCases.Count(ctx, filter)
+		if err != nil {
+			return nil, err
 		}
+		totalCount := int(total)
+		receivers.TotalCount = &totalCount
+	}
 
-		if receiver.ID == cursor {
-			isInCurrentPage = true
-		}
+	return &receivers, nil
+}
 
-		if hasReachedTotalPerPage {
-			hasNextPage = len(receivers) > i
-			break
-		}
+// Banks is the resolver for the banks field.
+func (r *queryResolver) Banks(ctx context.Context, search *string) ([]*Bank, error) {
+	result, err := r.ReceiverUseCases.ListBanks(ctx, &usecase.ListBanksInput{Search: shared.GetValueStr(search)})
+	if err != nil {
+		return nil, err
 	}
 
-	pageInfo := PageInfo{
-		StartCursor: shared.EncodeBase64([]byte(edges[0].Node.ID)),
-		EndCursor:   shared.EncodeBase64([]byte(edges[count-1].Node.ID)),
-		HasNextPage: &hasNextPage,
+	banks := make([]*Bank, 0, len(result))
+	for _, institution := range result {
+		banks = append(banks, ToBankOutput(institution))
 	}
 
-	result := Receivers{
-		Edges:    edges[:count],
-		PageInfo: &pageInfo,
+	return banks, nil
+}
+
+// ReceiverPixBRCode is the resolver for the receiverPixBRCode field.
+func (r *queryResolver) ReceiverPixBRCode(ctx context.Context, id string, amount *int, txid *string, description *string) (string, error) {
+	input := &usecase.GeneratePixBRCodeInput{
+		Id:          id,
+		Txid:        shared.GetValueStr(txid),
+		Description: shared.GetValueStr(description),
+	}
+	if amount != nil {
+		input.Amount = shared.GetPointerInt64(int64(*amount))
 	}
 
-	return &result, nil
+	return r.ReceiverUseCases.GeneratePixBRCode(ctx, input)
+}
+
+// Transfer is the resolver for the transfer field.
+func (r *queryResolver) Transfer(ctx context.Context, id string) (*Transfer, error) {
+	result, err := r.TransferUseCases.ListById(ctx, &usecase.ListTransferByIdInput{Id: id})
+	if err != nil {
+		return nil, err
+	}
+
+	return ToTransferOutput(*result), nil
+}
+
+// ListTransfers is the resolver for the listTransfers field.
+func (r *queryResolver) ListTransfers(ctx context.Context, receiverID *string, status *TransferStatus, first *int, after *string) (*Transfers, error) {
+	input, err := BuildTransfersInput(r.Cursors, receiverID, status, first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.TransferUseCases.List(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return ToTransfersOutput(r.Cursors, *result), nil
+}
+
+// History is the resolver for the history field.
+func (r *receiverResolver) History(ctx context.Context, obj *Receiver, first *int, after *string) (*ReceiverHistory, error) {
+	return r.listHistory(ctx, obj.ID, first, after)
 }
 
 // Mutation returns MutationResolver implementation.
@@ -152,5 +264,9 @@ func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
 // Query returns QueryResolver implementation.
 func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
 
+// Receiver returns ReceiverResolver implementation.
+func (r *Resolver) Receiver() ReceiverResolver { return &receiverResolver{r} }
+
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type receiverResolver struct{ *Resolver }
@@ -8,18 +8,30 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/teste-transfeera/internal/entity"
 	"github.com/teste-transfeera/internal/graph"
+	"github.com/teste-transfeera/internal/repository"
 	"github.com/teste-transfeera/internal/usecase"
 	"github.com/teste-transfeera/mocks"
+	"github.com/teste-transfeera/pkg/bank"
+	"github.com/teste-transfeera/pkg/cursor"
 	"github.com/teste-transfeera/pkg/shared"
 )
 
+// The kinds the resolvers sign their cursors with.
+var (
+	receiverCursor = cursor.Kind("receiver")
+	historyCursor  = cursor.Kind("history")
+	transferCursor = cursor.Kind("transfer")
+)
+
 type graphQLRequest struct {
 	Query string `json:"query"`
 }
@@ -35,11 +47,11 @@ func Test_Resolvers_CreateReceiver_Success(t *testing.T) {
 	t.Run("Resolve CreateReceiver successfully", func(t *testing.T) {
 		// Arrange
 		input := graph.NewReceiver{
-			Identifier: "111.111.111-11",
+			Identifier: "529.982.247-25",
 			Name:       "Receiver 1",
 			Email:      "RECEIVER1@GMAIL.COM",
 			PixKeyType: "CPF",
-			PixKey:     "111.111.111-11",
+			PixKey:     "529.982.247-25",
 		}
 		mockInput := &usecase.CreateReceiverInput{
 			Identifier: input.Identifier,
@@ -51,13 +63,13 @@ func Test_Resolvers_CreateReceiver_Success(t *testing.T) {
 		id := uuid.New().String()
 		mockOutput := &entity.Receiver{
 			ID:         id,
-			Identifier: "111.111.111-11",
+			Identifier: "529.982.247-25",
 			Name:       "Receiver 1",
 			Email:      "RECEIVER1@GMAIL.COM",
 			Status:     entity.Draft,
 			Pix: entity.Pix{
 				KeyType: entity.CPF,
-				Key:     "111.111.111-11",
+				Key:     "529.982.247-25",
 			},
 		}
 
@@ -65,12 +77,13 @@ func Test_Resolvers_CreateReceiver_Success(t *testing.T) {
 			ID:         id,
 			Name:       "Receiver 1",
 			Email:      "RECEIVER1@GMAIL.COM",
-			Identifier: "111.111.111-11",
+			Identifier: "529.982.247-25",
 			Pix: &graph.Pix{
 				KeyType: "CPF",
-				Key:     "111.111.111-11",
+				Key:     "529.982.247-25",
 			},
-			Status: shared.GetPointerStr(string(entity.Draft)),
+			Status:   shared.GetPointerStr(string(entity.Draft)),
+			Warnings: []*graph.Warning{},
 		}
 		var result struct {
 			Data struct {
@@ -80,7 +93,7 @@ func Test_Resolvers_CreateReceiver_Success(t *testing.T) {
 		result.Data.CreateReceiver = expectedResult
 		expectedResultBytes, err := json.Marshal(result)
 
-		useCase.On("Create", mockInput).Return(mockOutput, nil).Once()
+		useCase.On("Create", mock.Anything, mockInput).Return(mockOutput, nil).Once()
 
 		// Act
 		query := `
@@ -94,16 +107,35 @@ func Test_Resolvers_CreateReceiver_Success(t *testing.T) {
 					}) {
 					id
 					identifier
+					formattedIdentifier
 					name
 					email
 					pix {
 						keyType
 						key
+						formattedKey
 					}
 					bank
 					agency
 					account
+					bankAccount {
+						bankCode
+						ispb
+						bankName
+						agency
+						agencyDigit
+						account
+						accountDigit
+						accountType
+						verified
+					}
 					status
+					version
+					warnings {
+						code
+						field
+						message
+					}
 				}
 			}
 		`
@@ -126,6 +158,7 @@ func Test_Resolvers_CreateReceiver_Success(t *testing.T) {
 func Test_Resolvers_CreateReceiver_Error(t *testing.T) {
 	useCase := &mocks.ReceiverUseCases{}
 	h := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: &graph.Resolver{ReceiverUseCases: useCase}}))
+	h.SetErrorPresenter(graph.ErrorPresenter)
 	router := gin.Default()
 	router.POST("/api/v1/receiver", func(c *gin.Context) {
 		h.ServeHTTP(c.Writer, c.Request)
@@ -134,11 +167,11 @@ func Test_Resolvers_CreateReceiver_Error(t *testing.T) {
 	t.Run("Resolve CreateReceiver with error from usecase", func(t *testing.T) {
 		// Arrange
 		input := graph.NewReceiver{
-			Identifier: "111.111.111-11",
+			Identifier: "529.982.247-25",
 			Name:       "Receiver 1",
 			Email:      "RECEIVER1@GMAIL.COM",
 			PixKeyType: "CPF",
-			PixKey:     "111.111.111-11",
+			PixKey:     "529.982.247-25",
 		}
 		mockInput := &usecase.CreateReceiverInput{
 			Identifier: input.Identifier,
@@ -149,7 +182,7 @@ func Test_Resolvers_CreateReceiver_Error(t *testing.T) {
 		}
 		expectedError := `{"errors":[{"message":"error","path":["createReceiver"]}],"data":{"createReceiver":null}}`
 
-		useCase.On("Create", mockInput).Return(nil, errors.New("error")).Once()
+		useCase.On("Create", mock.Anything, mockInput).Return(nil, errors.New("error")).Once()
 
 		// Act
 		query := `
@@ -163,16 +196,35 @@ func Test_Resolvers_CreateReceiver_Error(t *testing.T) {
 					}) {
 					id
 					identifier
+					formattedIdentifier
 					name
 					email
 					pix {
 						keyType
 						key
+						formattedKey
 					}
 					bank
 					agency
 					account
+					bankAccount {
+						bankCode
+						ispb
+						bankName
+						agency
+						agencyDigit
+						account
+						accountDigit
+						accountType
+						verified
+					}
 					status
+					version
+					warnings {
+						code
+						field
+						message
+					}
 				}
 			}
 		`
@@ -189,6 +241,89 @@ func Test_Resolvers_CreateReceiver_Error(t *testing.T) {
 		assert.Equal(t, []byte(expectedError), rr.Body.Bytes())
 		useCase.AssertExpectations(t)
 	})
+
+	t.Run("Resolve CreateReceiver with validation error returns one error per field", func(t *testing.T) {
+		// Arrange
+		validationErr := &usecase.ValidationError{Errors: []*usecase.FieldError{
+			{Code: usecase.ERROR_CODE_REQUIRED, Field: "name", Message: "Name is required"},
+			{Code: usecase.ERROR_CODE_INVALID_CHECK_DIGITS, Field: "identifier", Message: "Invalid Identifier: CPF check digits are invalid"},
+		}}
+		expectedError := `{"errors":[` +
+			`{"message":"Name is required","path":["createReceiver"],"extensions":{"code":"REQUIRED","field":"name"}},` +
+			`{"message":"Invalid Identifier: CPF check digits are invalid","path":["createReceiver"],"extensions":{"code":"INVALID_CHECK_DIGITS","field":"identifier"}}` +
+			`],"data":{"createReceiver":null}}`
+
+		useCase.On("Create", mock.Anything, mock.Anything).Return(nil, validationErr).Once()
+
+		// Act
+		query := `
+			mutation {
+				createReceiver(input: {
+					name: "",
+					email: "receiver1@gmail.com",
+					identifier: "529.982.247-24",
+					pixKeyType: "CPF",
+					pixKey: "529.982.247-25",
+					}) {
+					id
+				}
+			}
+		`
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedError, rr.Body.String())
+		useCase.AssertExpectations(t)
+	})
+
+	t.Run("Resolve CreateReceiver with duplicate identifier returns DUPLICATE_RECEIVER code", func(t *testing.T) {
+		// Arrange
+		mockInput := &usecase.CreateReceiverInput{
+			Identifier:  "529.982.247-25",
+			Name:        "Receiver 1",
+			Email:       "RECEIVER1@GMAIL.COM",
+			PixKeyType:  "CPF",
+			PixKey:      "529.982.247-25",
+			OnDuplicate: usecase.DuplicateFail,
+		}
+		expectedError := `{"errors":[{"message":"Receiver 63fbbe585c3c3b8ab3a647aa with identifier 52998224725 already exists","path":["createReceiver"],"extensions":{"code":"DUPLICATE_RECEIVER","field":"identifier","receiverId":"63fbbe585c3c3b8ab3a647aa"}}],"data":{"createReceiver":null}}`
+
+		useCase.On("Create", mock.Anything, mockInput).Return(nil, &usecase.DuplicateReceiverError{Identifier: "52998224725", ReceiverID: "63fbbe585c3c3b8ab3a647aa", Status: entity.Draft}).Once()
+
+		// Act
+		query := `
+			mutation {
+				createReceiver(input: {
+					name: "%s",
+					email: "%s",
+					identifier: "%s",
+					pixKeyType: "%s",
+					pixKey: "%s",
+					onDuplicate: FAIL
+					}) {
+					id
+				}
+			}
+		`
+		query = fmt.Sprintf(query, mockInput.Name, mockInput.Email, mockInput.Identifier, mockInput.PixKeyType, mockInput.PixKey)
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedError, rr.Body.String())
+		useCase.AssertExpectations(t)
+	})
 }
 
 func Test_Resolvers_DeleteReceivers_Success(t *testing.T) {
@@ -214,7 +349,7 @@ func Test_Resolvers_DeleteReceivers_Success(t *testing.T) {
 		result.Data.DeleteReceivers = expectedResult
 		expectedResultBytes, err := json.Marshal(result)
 
-		useCase.On("Delete", mockInput).Return(nil).Once()
+		useCase.On("Delete", mock.Anything, mockInput).Return(nil).Once()
 
 		// Act
 		query := `
@@ -251,7 +386,7 @@ func Test_Resolvers_DeleteReceivers_Success(t *testing.T) {
 		result.Data.DeleteReceivers = expectedResult
 		expectedResultBytes, err := json.Marshal(result)
 
-		useCase.On("Delete", mockInput).Return(nil).Once()
+		useCase.On("Delete", mock.Anything, mockInput).Return(nil).Once()
 
 		// Act
 		query := `
@@ -291,7 +426,7 @@ func Test_Resolvers_DeleteReceivers_Error(t *testing.T) {
 
 		expectedError := `{"errors":[{"message":"error","path":["deleteReceivers"]}],"data":{"deleteReceivers":""}}`
 
-		useCase.On("Delete", mockInput).Return(errors.New("error")).Once()
+		useCase.On("Delete", mock.Anything, mockInput).Return(errors.New("error")).Once()
 
 		// Act
 		query := `
@@ -333,16 +468,19 @@ func Test_Resolvers_UpdateReceiver_Success(t *testing.T) {
 			Name: shared.GetValueStr(input.Name),
 		}
 
-		expectedResult := fmt.Sprintf("Updated %s successfully", mockInput.Id)
-		var result struct {
-			Data struct {
-				UpdateReceiver string `json:"updateReceiver"`
-			} `json:"data"`
+		mockOutput := &entity.Receiver{
+			ID:         mockInput.Id,
+			Identifier: "52998224725",
+			Name:       "Receiver 1",
+			Email:      "receiver1@gmail.com",
+			Status:     entity.Draft,
+			Pix:        entity.Pix{KeyType: entity.CPF, Key: "52998224725"},
+			Version:    3,
+			Warnings:   []entity.Warning{},
 		}
-		result.Data.UpdateReceiver = expectedResult
-		expectedResultBytes, err := json.Marshal(result)
+		expectedResult := `{"data":{"updateReceiver":{"id":"63fbbe585c3c3b8ab3a647aa","name":"Receiver 1","version":3,"warnings":[]}}}`
 
-		useCase.On("Update", mockInput).Return(nil).Once()
+		useCase.On("Update", mock.Anything, mockInput).Return(mockOutput, nil).Once()
 
 		// Act
 		query := `
@@ -350,7 +488,16 @@ func Test_Resolvers_UpdateReceiver_Success(t *testing.T) {
 				updateReceiver(input: {
 					id: "%s",
 					name: "%s"
-					})
+					}) {
+					id
+					name
+					version
+					warnings {
+						code
+						field
+						message
+					}
+				}
 			}
 		`
 		query = fmt.Sprintf(query, mockInput.Id, mockInput.Name)
@@ -363,20 +510,20 @@ func Test_Resolvers_UpdateReceiver_Success(t *testing.T) {
 
 		// Assert
 		assert.NoError(t, err)
-		assert.Equal(t, expectedResultBytes, rr.Body.Bytes())
+		assert.Equal(t, expectedResult, rr.Body.String())
 		assert.Equal(t, http.StatusOK, rr.Code)
 		useCase.AssertExpectations(t)
 	})
 
-	t.Run("Resolve UpdateReceiver successfully with all fields", func(t *testing.T) {
+	t.Run("Resolve UpdateReceiver successfully with all fields and warnings", func(t *testing.T) {
 		// Arrange
 		input := graph.UpdateReceiver{
 			ID:         "63fbbe585c3c3b8ab3a647aa",
-			Identifier: shared.GetPointerStr("111.111.111-11"),
+			Identifier: shared.GetPointerStr("529.982.247-25"),
 			Name:       shared.GetPointerStr("Receiver 1"),
 			Email:      shared.GetPointerStr("RECEIVER1@GMAIL.COM"),
 			PixKeyType: shared.GetPointerStr("CPF"),
-			PixKey:     shared.GetPointerStr("111.111.111-11"),
+			PixKey:     shared.GetPointerStr("529.982.247-25"),
 		}
 		mockInput := &usecase.UpdateReceiverInput{
 			Id:         input.ID,
@@ -387,16 +534,25 @@ func Test_Resolvers_UpdateReceiver_Success(t *testing.T) {
 			PixKey:     shared.GetValueStr(input.PixKey),
 		}
 
-		expectedResult := fmt.Sprintf("Updated %s successfully", mockInput.Id)
-		var result struct {
-			Data struct {
-				UpdateReceiver string `json:"updateReceiver"`
-			} `json:"data"`
+		mockOutput := &entity.Receiver{
+			ID:         mockInput.Id,
+			Identifier: "52998224725",
+			Name:       "Receiver 1",
+			Email:      "receiver1@gmail.com",
+			Status:     entity.Draft,
+			Pix:        entity.Pix{KeyType: entity.CPF, Key: "52998224725"},
+			Version:    3,
+			Warnings: []entity.Warning{
+				{
+					Code:    usecase.RULE_PIX_KEY_PERSON_TYPE_MISMATCH,
+					Field:   "pixKeyType",
+					Message: "CPF Pix Key belongs to a person, but the identifier is the CNPJ of a company",
+				},
+			},
 		}
-		result.Data.UpdateReceiver = expectedResult
-		expectedResultBytes, err := json.Marshal(result)
+		expectedResult := `{"data":{"updateReceiver":{"id":"63fbbe585c3c3b8ab3a647aa","name":"Receiver 1","version":3,"warnings":[{"code":"PIX_KEY_PERSON_TYPE_MISMATCH","field":"pixKeyType","message":"CPF Pix Key belongs to a person, but the identifier is the CNPJ of a company"}]}}}`
 
-		useCase.On("Update", mockInput).Return(nil).Once()
+		useCase.On("Update", mock.Anything, mockInput).Return(mockOutput, nil).Once()
 
 		// Act
 		query := `
@@ -408,7 +564,16 @@ func Test_Resolvers_UpdateReceiver_Success(t *testing.T) {
 					identifier: "%s",
 					pixKeyType: "%s",
 					pixKey: "%s",
-					})
+					}) {
+					id
+					name
+					version
+					warnings {
+						code
+						field
+						message
+					}
+				}
 			}
 		`
 		query = fmt.Sprintf(query, mockInput.Id, mockInput.Name, mockInput.Email, mockInput.Identifier, mockInput.PixKeyType, mockInput.PixKey)
@@ -421,7 +586,7 @@ func Test_Resolvers_UpdateReceiver_Success(t *testing.T) {
 
 		// Assert
 		assert.NoError(t, err)
-		assert.Equal(t, expectedResultBytes, rr.Body.Bytes())
+		assert.Equal(t, expectedResult, rr.Body.String())
 		assert.Equal(t, http.StatusOK, rr.Code)
 		useCase.AssertExpectations(t)
 	})
@@ -430,6 +595,7 @@ func Test_Resolvers_UpdateReceiver_Success(t *testing.T) {
 func Test_Resolvers_UpdateReceiver_Error(t *testing.T) {
 	useCase := &mocks.ReceiverUseCases{}
 	h := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: &graph.Resolver{ReceiverUseCases: useCase}}))
+	h.SetErrorPresenter(graph.ErrorPresenter)
 	router := gin.Default()
 	router.POST("/api/v1/receiver", func(c *gin.Context) {
 		h.ServeHTTP(c.Writer, c.Request)
@@ -446,9 +612,9 @@ func Test_Resolvers_UpdateReceiver_Error(t *testing.T) {
 			Name: shared.GetValueStr(input.Name),
 		}
 
-		expectedError := `{"errors":[{"message":"error","path":["updateReceiver"]}],"data":{"updateReceiver":""}}`
+		expectedError := `{"errors":[{"message":"error","path":["updateReceiver"]}],"data":{"updateReceiver":null}}`
 
-		useCase.On("Update", mockInput).Return(errors.New("error")).Once()
+		useCase.On("Update", mock.Anything, mockInput).Return(nil, errors.New("error")).Once()
 
 		// Act
 		query := `
@@ -456,7 +622,52 @@ func Test_Resolvers_UpdateReceiver_Error(t *testing.T) {
 				updateReceiver(input: {
 					id: "%s",
 					name: "%s"
-					})
+					}) {
+					id
+				}
+			}
+		`
+		query = fmt.Sprintf(query, mockInput.Id, mockInput.Name)
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(expectedError), rr.Body.Bytes())
+		assert.Equal(t, http.StatusOK, rr.Code)
+		useCase.AssertExpectations(t)
+	})
+
+	t.Run("Resolve UpdateReceiver with outdated expected version returns CONFLICT code", func(t *testing.T) {
+		// Arrange
+		input := graph.UpdateReceiver{
+			ID:   "63fbbe585c3c3b8ab3a647aa",
+			Name: shared.GetPointerStr("Receiver 1"),
+		}
+		mockInput := &usecase.UpdateReceiverInput{
+			Id:              input.ID,
+			Name:            shared.GetValueStr(input.Name),
+			ExpectedVersion: shared.GetPointerInt64(3),
+		}
+
+		expectedError := `{"errors":[{"message":"Receiver 63fbbe585c3c3b8ab3a647aa was modified by another request, expected version 3","path":["updateReceiver"],"extensions":{"code":"CONFLICT"}}],"data":{"updateReceiver":null}}`
+
+		useCase.On("Update", mock.Anything, mockInput).Return(nil, &repository.VersionConflictError{ID: input.ID, ExpectedVersion: 3}).Once()
+
+		// Act
+		query := `
+			mutation {
+				updateReceiver(input: {
+					id: "%s",
+					name: "%s",
+					expectedVersion: 3
+					}) {
+					id
+				}
 			}
 		`
 		query = fmt.Sprintf(query, mockInput.Id, mockInput.Name)
@@ -473,6 +684,155 @@ func Test_Resolvers_UpdateReceiver_Error(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rr.Code)
 		useCase.AssertExpectations(t)
 	})
+
+	t.Run("Resolve UpdateReceiver with a pix key in use returns DUPLICATE_PIX_KEY code", func(t *testing.T) {
+		// Arrange
+		input := graph.UpdateReceiver{
+			ID:     "63fbbe585c3c3b8ab3a647aa",
+			PixKey: shared.GetPointerStr("receiver2@gmail.com"),
+		}
+		mockInput := &usecase.UpdateReceiverInput{
+			Id:     input.ID,
+			PixKey: shared.GetValueStr(input.PixKey),
+		}
+
+		expectedError := `{"errors":[{"message":"Pix Key receiver2@gmail.com is already used by receiver 63fbbe585c3c3b8ab3a647ab","path":["updateReceiver"],"extensions":{"code":"DUPLICATE_PIX_KEY","field":"pixKey","receiverId":"63fbbe585c3c3b8ab3a647ab"}}],"data":{"updateReceiver":null}}`
+
+		useCase.On("Update", mock.Anything, mockInput).Return(nil, &repository.DuplicatePixKeyError{Key: "receiver2@gmail.com", ReceiverID: "63fbbe585c3c3b8ab3a647ab"}).Once()
+
+		// Act
+		query := `
+			mutation {
+				updateReceiver(input: {
+					id: "%s",
+					pixKey: "%s"
+					}) {
+					id
+				}
+			}
+		`
+		query = fmt.Sprintf(query, mockInput.Id, mockInput.PixKey)
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(expectedError), rr.Body.Bytes())
+		assert.Equal(t, http.StatusOK, rr.Code)
+		useCase.AssertExpectations(t)
+	})
+}
+
+func Test_Resolvers_ChangeReceiverStatus_Success(t *testing.T) {
+	useCase := &mocks.ReceiverUseCases{}
+	h := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: &graph.Resolver{ReceiverUseCases: useCase}}))
+	router := gin.Default()
+	router.POST("/api/v1/receiver", func(c *gin.Context) {
+		h.ServeHTTP(c.Writer, c.Request)
+	})
+
+	t.Run("Resolve BlockReceiver successfully", func(t *testing.T) {
+		// Arrange
+		mockInput := &usecase.ChangeReceiverStatusInput{
+			Id:              "63fbbe585c3c3b8ab3a647aa",
+			Transition:      entity.TransitionBlock,
+			Reason:          "Suspected fraud",
+			ExpectedVersion: shared.GetPointerInt64(2),
+		}
+		mockOutput := &entity.Receiver{
+			ID:         mockInput.Id,
+			Identifier: "52998224725",
+			Name:       "Receiver 1",
+			Email:      "receiver1@gmail.com",
+			Status:     entity.Blocked,
+			Pix: entity.Pix{
+				KeyType: entity.CPF,
+				Key:     "52998224725",
+			},
+			Version: 3,
+		}
+		expectedResult := `{"data":{"blockReceiver":{"id":"63fbbe585c3c3b8ab3a647aa","status":"Blocked","version":3}}}`
+
+		useCase.On("ChangeStatus", mock.Anything, mockInput).Return(mockOutput, nil).Once()
+
+		// Act
+		query := `
+			mutation {
+				blockReceiver(input: {
+					id: "%s",
+					reason: "%s",
+					expectedVersion: 2
+					}) {
+					id
+					status
+					version
+				}
+			}
+		`
+		query = fmt.Sprintf(query, mockInput.Id, mockInput.Reason)
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, rr.Body.String())
+		assert.Equal(t, http.StatusOK, rr.Code)
+		useCase.AssertExpectations(t)
+	})
+}
+
+func Test_Resolvers_ChangeReceiverStatus_Error(t *testing.T) {
+	useCase := &mocks.ReceiverUseCases{}
+	h := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: &graph.Resolver{ReceiverUseCases: useCase}}))
+	h.SetErrorPresenter(graph.ErrorPresenter)
+	router := gin.Default()
+	router.POST("/api/v1/receiver", func(c *gin.Context) {
+		h.ServeHTTP(c.Writer, c.Request)
+	})
+
+	t.Run("Resolve UnblockReceiver not allowed from the status returns INVALID_TRANSITION code", func(t *testing.T) {
+		// Arrange
+		mockInput := &usecase.ChangeReceiverStatusInput{
+			Id:         "63fbbe585c3c3b8ab3a647aa",
+			Transition: entity.TransitionUnblock,
+			Reason:     "Fraud ruled out",
+		}
+		expectedError := `{"errors":[{"message":"Cannot UNBLOCK receiver with status Draft: not allowed from this status","path":["unblockReceiver"],"extensions":{"code":"INVALID_TRANSITION"}}],"data":{"unblockReceiver":null}}`
+
+		useCase.On("ChangeStatus", mock.Anything, mockInput).Return(nil, &entity.TransitionError{From: entity.Draft, Transition: entity.TransitionUnblock, Reason: "not allowed from this status"}).Once()
+
+		// Act
+		query := `
+			mutation {
+				unblockReceiver(input: {
+					id: "%s",
+					reason: "%s"
+					}) {
+					id
+				}
+			}
+		`
+		query = fmt.Sprintf(query, mockInput.Id, mockInput.Reason)
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedError, rr.Body.String())
+		useCase.AssertExpectations(t)
+	})
 }
 
 func Test_Resolvers_Receiver_Success(t *testing.T) {
@@ -490,27 +850,55 @@ func Test_Resolvers_Receiver_Success(t *testing.T) {
 			Id: id,
 		}
 		mockOutput := &entity.Receiver{
-			ID:         id,
-			Identifier: "111.111.111-11",
-			Name:       "Receiver 1",
-			Email:      "RECEIVER1@GMAIL.COM",
-			Status:     entity.Draft,
+			ID:                  id,
+			Identifier:          "52998224725",
+			FormattedIdentifier: "529.982.247-25",
+			Name:                "Receiver 1",
+			Email:               "receiver1@gmail.com",
+			Status:              entity.Draft,
 			Pix: entity.Pix{
-				KeyType: entity.CPF,
-				Key:     "111.111.111-11",
+				KeyType:      entity.CPF,
+				Key:          "52998224725",
+				FormattedKey: "529.982.247-25",
+			},
+			BankAccount: &entity.BankAccount{
+				BankCode:     "001",
+				Agency:       "0814",
+				AgencyDigit:  "0",
+				Account:      "12345",
+				AccountDigit: "6",
+				AccountType:  entity.Checking,
+				Verified:     true,
 			},
 		}
 
 		expectedResult := graph.Receiver{
-			ID:         id,
-			Name:       "Receiver 1",
-			Email:      "RECEIVER1@GMAIL.COM",
-			Identifier: "111.111.111-11",
+			ID:                  id,
+			Name:                "Receiver 1",
+			Email:               "receiver1@gmail.com",
+			Identifier:          "52998224725",
+			FormattedIdentifier: "529.982.247-25",
 			Pix: &graph.Pix{
-				KeyType: "CPF",
-				Key:     "111.111.111-11",
+				KeyType:      "CPF",
+				Key:          "52998224725",
+				FormattedKey: "529.982.247-25",
+			},
+			Bank:    shared.GetPointerStr("BCO DO BRASIL S.A."),
+			Agency:  shared.GetPointerStr("0814-0"),
+			Account: shared.GetPointerStr("12345-6"),
+			BankAccount: &graph.BankAccount{
+				BankCode:     "001",
+				Ispb:         shared.GetPointerStr("00000000"),
+				BankName:     shared.GetPointerStr("BCO DO BRASIL S.A."),
+				Agency:       "0814",
+				AgencyDigit:  shared.GetPointerStr("0"),
+				Account:      "12345",
+				AccountDigit: "6",
+				AccountType:  graph.AccountTypeChecking,
+				Verified:     true,
 			},
-			Status: shared.GetPointerStr(string(entity.Draft)),
+			Status:   shared.GetPointerStr(string(entity.Draft)),
+			Warnings: []*graph.Warning{},
 		}
 		var result struct {
 			Data struct {
@@ -520,7 +908,7 @@ func Test_Resolvers_Receiver_Success(t *testing.T) {
 		result.Data.Receiver = expectedResult
 		expectedResultBytes, err := json.Marshal(result)
 
-		useCase.On("ListById", mockInput).Return(mockOutput, nil).Once()
+		useCase.On("ListById", mock.Anything, mockInput).Return(mockOutput, nil).Once()
 
 		// Act
 		query := `
@@ -528,16 +916,35 @@ func Test_Resolvers_Receiver_Success(t *testing.T) {
 				receiver(id: "%s") {
 					id
 					identifier
+					formattedIdentifier
 					name
 					email
 					pix {
 						keyType
 						key
+						formattedKey
 					}
 					bank
 					agency
 					account
+					bankAccount {
+						bankCode
+						ispb
+						bankName
+						agency
+						agencyDigit
+						account
+						accountDigit
+						accountType
+						verified
+					}
 					status
+					version
+					warnings {
+						code
+						field
+						message
+					}
 				}
 			}
 		`
@@ -560,6 +967,7 @@ func Test_Resolvers_Receiver_Success(t *testing.T) {
 func Test_Resolvers_Receiver_Error(t *testing.T) {
 	useCase := &mocks.ReceiverUseCases{}
 	h := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: &graph.Resolver{ReceiverUseCases: useCase}}))
+	h.SetErrorPresenter(graph.ErrorPresenter)
 	router := gin.Default()
 	router.POST("/api/v1/receiver", func(c *gin.Context) {
 		h.ServeHTTP(c.Writer, c.Request)
@@ -573,7 +981,7 @@ func Test_Resolvers_Receiver_Error(t *testing.T) {
 		}
 		expectedError := `{"errors":[{"message":"error","path":["receiver"]}],"data":{"receiver":null}}`
 
-		useCase.On("ListById", mockInput).Return(nil, errors.New("error")).Once()
+		useCase.On("ListById", mock.Anything, mockInput).Return(nil, errors.New("error")).Once()
 
 		// Act
 		query := `
@@ -581,16 +989,35 @@ func Test_Resolvers_Receiver_Error(t *testing.T) {
 				receiver(id: "%s") {
 					id
 					identifier
+					formattedIdentifier
 					name
 					email
 					pix {
 						keyType
 						key
+						formattedKey
 					}
 					bank
 					agency
 					account
+					bankAccount {
+						bankCode
+						ispb
+						bankName
+						agency
+						agencyDigit
+						account
+						accountDigit
+						accountType
+						verified
+					}
 					status
+					version
+					warnings {
+						code
+						field
+						message
+					}
 				}
 			}
 		`
@@ -608,82 +1035,123 @@ func Test_Resolvers_Receiver_Error(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rr.Code)
 		useCase.AssertExpectations(t)
 	})
-}
 
-func Test_Resolvers_ListReceivers_Success(t *testing.T) {
-	useCase := &mocks.ReceiverUseCases{}
-	h := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: &graph.Resolver{ReceiverUseCases: useCase}}))
-	router := gin.Default()
-	router.POST("/api/v1/receiver", func(c *gin.Context) {
+	t.Run("Resolve Receiver with timeout from usecase returns TIMEOUT code", func(t *testing.T) {
+		// Arrange
+		id := "63f8c8d6c6ce914b5b00b88e"
+		mockInput := &usecase.ListReceiverByIdInput{
+			Id: id,
+		}
+		expectedError := `{"errors":[{"message":"Database operation timed out","path":["receiver"],"extensions":{"code":"TIMEOUT"}}],"data":{"receiver":null}}`
+
+		useCase.On("ListById", mock.Anything, mockInput).Return(nil, repository.ErrTimeout).Once()
+
+		// Act
+		query := `
+			query receiver {
+				receiver(id: "%s") {
+					id
+				}
+			}
+		`
+		query = fmt.Sprintf(query, id)
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(expectedError), rr.Body.Bytes())
+		assert.Equal(t, http.StatusOK, rr.Code)
+		useCase.AssertExpectations(t)
+	})
+}
+
+func Test_Resolvers_ListReceivers_Success(t *testing.T) {
+	useCase := &mocks.ReceiverUseCases{}
+	cursors := cursor.NewCodec([]byte("secret"))
+	h := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: &graph.Resolver{ReceiverUseCases: useCase, Cursors: cursors}}))
+	router := gin.Default()
+	router.POST("/api/v1/receiver", func(c *gin.Context) {
 		h.ServeHTTP(c.Writer, c.Request)
 	})
 
 	t.Run("Resolve ListReceivers successfully", func(t *testing.T) {
 		// Arrange
-		filter := graph.BuildFilter(nil, nil, nil, nil)
+		filter := graph.BuildFilter(nil, nil, nil, nil, nil)
+		page := entity.PageRequest{Limit: graph.TOTAL_PER_PAGE, Sort: entity.DefaultSort}
 		id1 := uuid.New().String()
 		id2 := uuid.New().String()
 		mockOutput := []entity.Receiver{
 			{
 				ID:         id1,
-				Identifier: "111.111.111-11",
+				Identifier: "529.982.247-25",
 				Name:       "Receiver 1",
 				Email:      "RECEIVER1@GMAIL.COM",
 				Status:     entity.Draft,
 				Pix: entity.Pix{
 					KeyType: entity.CPF,
-					Key:     "111.111.111-11",
+					Key:     "529.982.247-25",
 				},
 			},
 			{
 				ID:         id2,
-				Identifier: "222.222.222-22",
+				Identifier: "123.456.789-09",
 				Name:       "Receiver 2",
 				Email:      "RECEIVER2@GMAIL.COM",
 				Status:     entity.Draft,
 				Pix: entity.Pix{
 					KeyType: entity.CPF,
-					Key:     "222.222.222-22",
+					Key:     "123.456.789-09",
 				},
 			},
 		}
+		totalCount := 2
+		hasPreviousPage := false
 		var b bool = false
 		expectedResult := &graph.Receivers{
 			Edges: []*graph.Edge{
 				{
-					Cursor: shared.EncodeBase64([]byte(id1)),
+					Cursor: cursors.Encode(receiverCursor, entity.Cursor{ID: id1}, entity.DefaultSort),
 					Node: &graph.Receiver{
 						ID:         id1,
-						Identifier: "111.111.111-11",
+						Identifier: "529.982.247-25",
 						Name:       "Receiver 1",
 						Email:      "RECEIVER1@GMAIL.COM",
 						Pix: &graph.Pix{
 							KeyType: "CPF",
-							Key:     "111.111.111-11",
+							Key:     "529.982.247-25",
 						},
-						Status: shared.GetPointerStr(string(entity.Draft)),
+						Status:   shared.GetPointerStr(string(entity.Draft)),
+						Warnings: []*graph.Warning{},
 					},
 				},
 				{
-					Cursor: shared.EncodeBase64([]byte(id2)),
+					Cursor: cursors.Encode(receiverCursor, entity.Cursor{ID: id2}, entity.DefaultSort),
 					Node: &graph.Receiver{
 						ID:         id2,
-						Identifier: "222.222.222-22",
+						Identifier: "123.456.789-09",
 						Name:       "Receiver 2",
 						Email:      "RECEIVER2@GMAIL.COM",
 						Pix: &graph.Pix{
 							KeyType: "CPF",
-							Key:     "222.222.222-22",
+							Key:     "123.456.789-09",
 						},
-						Status: shared.GetPointerStr(string(entity.Draft)),
+						Status:   shared.GetPointerStr(string(entity.Draft)),
+						Warnings: []*graph.Warning{},
 					},
 				},
 			},
 			PageInfo: &graph.PageInfo{
-				StartCursor: shared.EncodeBase64([]byte(id1)),
-				EndCursor:   shared.EncodeBase64([]byte(id2)),
-				HasNextPage: &b,
+				StartCursor:     cursors.Encode(receiverCursor, entity.Cursor{ID: id1}, entity.DefaultSort),
+				EndCursor:       cursors.Encode(receiverCursor, entity.Cursor{ID: id2}, entity.DefaultSort),
+				HasNextPage:     &b,
+				HasPreviousPage: &hasPreviousPage,
 			},
+			TotalCount: &totalCount,
 		}
 		var result struct {
 			Data struct {
@@ -693,7 +1161,8 @@ func Test_Resolvers_ListReceivers_Success(t *testing.T) {
 		result.Data.ListReceivers = expectedResult
 		expectedResultBytes, err := json.Marshal(result)
 
-		useCase.On("List", filter).Return(mockOutput, nil).Once()
+		useCase.On("List", mock.Anything, filter, page).Return(&entity.ReceiverPage{Receivers: mockOutput}, nil).Once()
+		useCase.On("Count", mock.Anything, filter).Return(int64(totalCount), nil).Once()
 
 		// Act
 		query := `
@@ -704,23 +1173,44 @@ func Test_Resolvers_ListReceivers_Success(t *testing.T) {
 						node {
 							id
 							identifier
+							formattedIdentifier
 							name
 							email
 							pix {
 								keyType
 								key
+								formattedKey
 							}
 							bank
 							agency
 							account
+							bankAccount {
+								bankCode
+								ispb
+								bankName
+								agency
+								agencyDigit
+								account
+								accountDigit
+								accountType
+								verified
+							}
 							status
+							version
+							warnings {
+								code
+								field
+								message
+							}
 						}
 					}
 					pageInfo {
 						startCursor
 						endCursor
 						hasNextPage
+						hasPreviousPage
 					}
+					totalCount
 				}
 			}
 		`
@@ -740,11 +1230,18 @@ func Test_Resolvers_ListReceivers_Success(t *testing.T) {
 
 	t.Run("Resolve ListReceivers with 0 receivers", func(t *testing.T) {
 		// Arrange
-		filter := graph.BuildFilter(nil, nil, nil, nil)
+		filter := graph.BuildFilter(nil, nil, nil, nil, nil)
+		page := entity.PageRequest{Limit: graph.TOTAL_PER_PAGE, Sort: entity.DefaultSort}
 		mockOutput := []entity.Receiver{}
+		totalCount := 0
+		b := false
 		expectedResult := &graph.Receivers{
-			Edges:    []*graph.Edge{},
-			PageInfo: &graph.PageInfo{},
+			Edges: []*graph.Edge{},
+			PageInfo: &graph.PageInfo{
+				HasNextPage:     &b,
+				HasPreviousPage: &b,
+			},
+			TotalCount: &totalCount,
 		}
 		var result struct {
 			Data struct {
@@ -754,7 +1251,8 @@ func Test_Resolvers_ListReceivers_Success(t *testing.T) {
 		result.Data.ListReceivers = expectedResult
 		expectedResultBytes, err := json.Marshal(result)
 
-		useCase.On("List", filter).Return(mockOutput, nil).Once()
+		useCase.On("List", mock.Anything, filter, page).Return(&entity.ReceiverPage{Receivers: mockOutput}, nil).Once()
+		useCase.On("Count", mock.Anything, filter).Return(int64(totalCount), nil).Once()
 
 		// Act
 		query := `
@@ -765,23 +1263,44 @@ func Test_Resolvers_ListReceivers_Success(t *testing.T) {
 						node {
 							id
 							identifier
+							formattedIdentifier
 							name
 							email
 							pix {
 								keyType
 								key
+								formattedKey
 							}
 							bank
 							agency
 							account
+							bankAccount {
+								bankCode
+								ispb
+								bankName
+								agency
+								agencyDigit
+								account
+								accountDigit
+								accountType
+								verified
+							}
 							status
+							version
+							warnings {
+								code
+								field
+								message
+							}
 						}
 					}
 					pageInfo {
 						startCursor
 						endCursor
 						hasNextPage
+						hasPreviousPage
 					}
+					totalCount
 				}
 			}
 		`
@@ -801,31 +1320,32 @@ func Test_Resolvers_ListReceivers_Success(t *testing.T) {
 
 	t.Run("Resolve ListReceivers with first 3 receivers", func(t *testing.T) {
 		// Arrange
-		filter := graph.BuildFilter(nil, nil, nil, nil)
+		filter := graph.BuildFilter(nil, nil, nil, nil, nil)
+		page := entity.PageRequest{Limit: 3, Sort: entity.DefaultSort}
 		id1 := uuid.New().String()
 		id2 := uuid.New().String()
 		id3 := uuid.New().String()
 		mockOutput := []entity.Receiver{
 			{
 				ID:         id1,
-				Identifier: "111.111.111-11",
+				Identifier: "529.982.247-25",
 				Name:       "Receiver 1",
 				Email:      "RECEIVER1@GMAIL.COM",
 				Status:     entity.Draft,
 				Pix: entity.Pix{
 					KeyType: entity.CPF,
-					Key:     "111.111.111-11",
+					Key:     "529.982.247-25",
 				},
 			},
 			{
 				ID:         id2,
-				Identifier: "222.222.222-22",
+				Identifier: "123.456.789-09",
 				Name:       "Receiver 2",
 				Email:      "RECEIVER2@GMAIL.COM",
 				Status:     entity.Draft,
 				Pix: entity.Pix{
 					KeyType: entity.CPF,
-					Key:     "222.222.222-22",
+					Key:     "123.456.789-09",
 				},
 			},
 			{
@@ -839,62 +1359,44 @@ func Test_Resolvers_ListReceivers_Success(t *testing.T) {
 					Key:     "333.333.333-33",
 				},
 			},
-			{
-				ID:         uuid.New().String(),
-				Identifier: "444.444.444-4",
-				Name:       "Receiver 4",
-				Email:      "RECEIVER4@GMAIL.COM",
-				Status:     entity.Draft,
-				Pix: entity.Pix{
-					KeyType: entity.CPF,
-					Key:     "444.444.444-44",
-				},
-			},
-			{
-				ID:         uuid.New().String(),
-				Identifier: "555.555.555-55",
-				Name:       "Receiver 5",
-				Email:      "RECEIVER5@GMAIL.COM",
-				Status:     entity.Draft,
-				Pix: entity.Pix{
-					KeyType: entity.CPF,
-					Key:     "555.555.555-55",
-				},
-			},
 		}
+		totalCount := 5
+		hasPreviousPage := false
 		var b bool = true
 		expectedResult := &graph.Receivers{
 			Edges: []*graph.Edge{
 				{
-					Cursor: shared.EncodeBase64([]byte(id1)),
+					Cursor: cursors.Encode(receiverCursor, entity.Cursor{ID: id1}, entity.DefaultSort),
 					Node: &graph.Receiver{
 						ID:         id1,
-						Identifier: "111.111.111-11",
+						Identifier: "529.982.247-25",
 						Name:       "Receiver 1",
 						Email:      "RECEIVER1@GMAIL.COM",
 						Pix: &graph.Pix{
 							KeyType: "CPF",
-							Key:     "111.111.111-11",
+							Key:     "529.982.247-25",
 						},
-						Status: shared.GetPointerStr(string(entity.Draft)),
+						Status:   shared.GetPointerStr(string(entity.Draft)),
+						Warnings: []*graph.Warning{},
 					},
 				},
 				{
-					Cursor: shared.EncodeBase64([]byte(id2)),
+					Cursor: cursors.Encode(receiverCursor, entity.Cursor{ID: id2}, entity.DefaultSort),
 					Node: &graph.Receiver{
 						ID:         id2,
-						Identifier: "222.222.222-22",
+						Identifier: "123.456.789-09",
 						Name:       "Receiver 2",
 						Email:      "RECEIVER2@GMAIL.COM",
 						Pix: &graph.Pix{
 							KeyType: "CPF",
-							Key:     "222.222.222-22",
+							Key:     "123.456.789-09",
 						},
-						Status: shared.GetPointerStr(string(entity.Draft)),
+						Status:   shared.GetPointerStr(string(entity.Draft)),
+						Warnings: []*graph.Warning{},
 					},
 				},
 				{
-					Cursor: shared.EncodeBase64([]byte(id3)),
+					Cursor: cursors.Encode(receiverCursor, entity.Cursor{ID: id3}, entity.DefaultSort),
 					Node: &graph.Receiver{
 						ID:         id3,
 						Identifier: "333.333.333-33",
@@ -904,15 +1406,18 @@ func Test_Resolvers_ListReceivers_Success(t *testing.T) {
 							KeyType: "CPF",
 							Key:     "333.333.333-33",
 						},
-						Status: shared.GetPointerStr(string(entity.Draft)),
+						Status:   shared.GetPointerStr(string(entity.Draft)),
+						Warnings: []*graph.Warning{},
 					},
 				},
 			},
 			PageInfo: &graph.PageInfo{
-				StartCursor: shared.EncodeBase64([]byte(id1)),
-				EndCursor:   shared.EncodeBase64([]byte(id3)),
-				HasNextPage: &b,
+				StartCursor:     cursors.Encode(receiverCursor, entity.Cursor{ID: id1}, entity.DefaultSort),
+				EndCursor:       cursors.Encode(receiverCursor, entity.Cursor{ID: id3}, entity.DefaultSort),
+				HasNextPage:     &b,
+				HasPreviousPage: &hasPreviousPage,
 			},
+			TotalCount: &totalCount,
 		}
 		var result struct {
 			Data struct {
@@ -922,7 +1427,8 @@ func Test_Resolvers_ListReceivers_Success(t *testing.T) {
 		result.Data.ListReceivers = expectedResult
 		expectedResultBytes, err := json.Marshal(result)
 
-		useCase.On("List", filter).Return(mockOutput, nil).Once()
+		useCase.On("List", mock.Anything, filter, page).Return(&entity.ReceiverPage{Receivers: mockOutput, HasNextPage: true}, nil).Once()
+		useCase.On("Count", mock.Anything, filter).Return(int64(totalCount), nil).Once()
 
 		// Act
 		query := `
@@ -933,23 +1439,44 @@ func Test_Resolvers_ListReceivers_Success(t *testing.T) {
 						node {
 							id
 							identifier
+							formattedIdentifier
 							name
 							email
 							pix {
 								keyType
 								key
+								formattedKey
 							}
 							bank
 							agency
 							account
+							bankAccount {
+								bankCode
+								ispb
+								bankName
+								agency
+								agencyDigit
+								account
+								accountDigit
+								accountType
+								verified
+							}
 							status
+							version
+							warnings {
+								code
+								field
+								message
+							}
 						}
 					}
 					pageInfo {
 						startCursor
 						endCursor
 						hasNextPage
+						hasPreviousPage
 					}
+					totalCount
 				}
 			}
 		`
@@ -969,44 +1496,12 @@ func Test_Resolvers_ListReceivers_Success(t *testing.T) {
 
 	t.Run("Resolve ListReceivers with next 3 receivers", func(t *testing.T) {
 		// Arrange
-		filter := graph.BuildFilter(nil, nil, nil, nil)
+		filter := graph.BuildFilter(nil, nil, nil, nil, nil)
 		id3 := uuid.New().String()
 		id4 := uuid.New().String()
 		id5 := uuid.New().String()
+		page := entity.PageRequest{Limit: 3, After: &entity.Cursor{ID: id3}, Sort: entity.DefaultSort}
 		mockOutput := []entity.Receiver{
-			{
-				ID:         uuid.New().String(),
-				Identifier: "111.111.111-11",
-				Name:       "Receiver 1",
-				Email:      "RECEIVER1@GMAIL.COM",
-				Status:     entity.Draft,
-				Pix: entity.Pix{
-					KeyType: entity.CPF,
-					Key:     "111.111.111-11",
-				},
-			},
-			{
-				ID:         uuid.New().String(),
-				Identifier: "222.222.222-22",
-				Name:       "Receiver 2",
-				Email:      "RECEIVER2@GMAIL.COM",
-				Status:     entity.Draft,
-				Pix: entity.Pix{
-					KeyType: entity.CPF,
-					Key:     "222.222.222-22",
-				},
-			},
-			{
-				ID:         id3,
-				Identifier: "333.333.333-33",
-				Name:       "Receiver 3",
-				Email:      "RECEIVER3@GMAIL.COM",
-				Status:     entity.Draft,
-				Pix: entity.Pix{
-					KeyType: entity.CPF,
-					Key:     "333.333.333-33",
-				},
-			},
 			{
 				ID:         id4,
 				Identifier: "444.444.444-44",
@@ -1030,11 +1525,13 @@ func Test_Resolvers_ListReceivers_Success(t *testing.T) {
 				},
 			},
 		}
+		totalCount := 5
+		hasPreviousPage := true
 		var b bool = false
 		expectedResult := &graph.Receivers{
 			Edges: []*graph.Edge{
 				{
-					Cursor: shared.EncodeBase64([]byte(id4)),
+					Cursor: cursors.Encode(receiverCursor, entity.Cursor{ID: id4}, entity.DefaultSort),
 					Node: &graph.Receiver{
 						ID:         id4,
 						Identifier: "444.444.444-44",
@@ -1044,11 +1541,12 @@ func Test_Resolvers_ListReceivers_Success(t *testing.T) {
 							KeyType: "CPF",
 							Key:     "444.444.444-44",
 						},
-						Status: shared.GetPointerStr(string(entity.Draft)),
+						Status:   shared.GetPointerStr(string(entity.Draft)),
+						Warnings: []*graph.Warning{},
 					},
 				},
 				{
-					Cursor: shared.EncodeBase64([]byte(id5)),
+					Cursor: cursors.Encode(receiverCursor, entity.Cursor{ID: id5}, entity.DefaultSort),
 					Node: &graph.Receiver{
 						ID:         id5,
 						Identifier: "555.555.555-55",
@@ -1058,15 +1556,18 @@ func Test_Resolvers_ListReceivers_Success(t *testing.T) {
 							KeyType: "CPF",
 							Key:     "555.555.555-55",
 						},
-						Status: shared.GetPointerStr(string(entity.Draft)),
+						Status:   shared.GetPointerStr(string(entity.Draft)),
+						Warnings: []*graph.Warning{},
 					},
 				},
 			},
 			PageInfo: &graph.PageInfo{
-				StartCursor: shared.EncodeBase64([]byte(id4)),
-				EndCursor:   shared.EncodeBase64([]byte(id5)),
-				HasNextPage: &b,
+				StartCursor:     cursors.Encode(receiverCursor, entity.Cursor{ID: id4}, entity.DefaultSort),
+				EndCursor:       cursors.Encode(receiverCursor, entity.Cursor{ID: id5}, entity.DefaultSort),
+				HasNextPage:     &b,
+				HasPreviousPage: &hasPreviousPage,
 			},
+			TotalCount: &totalCount,
 		}
 		var result struct {
 			Data struct {
@@ -1076,7 +1577,8 @@ func Test_Resolvers_ListReceivers_Success(t *testing.T) {
 		result.Data.ListReceivers = expectedResult
 		expectedResultBytes, err := json.Marshal(result)
 
-		useCase.On("List", filter).Return(mockOutput, nil).Once()
+		useCase.On("List", mock.Anything, filter, page).Return(&entity.ReceiverPage{Receivers: mockOutput, HasPreviousPage: true}, nil).Once()
+		useCase.On("Count", mock.Anything, filter).Return(int64(totalCount), nil).Once()
 
 		// Act
 		query := `
@@ -1087,27 +1589,48 @@ func Test_Resolvers_ListReceivers_Success(t *testing.T) {
 						node {
 							id
 							identifier
+							formattedIdentifier
 							name
 							email
 							pix {
 								keyType
 								key
+								formattedKey
 							}
 							bank
 							agency
 							account
+							bankAccount {
+								bankCode
+								ispb
+								bankName
+								agency
+								agencyDigit
+								account
+								accountDigit
+								accountType
+								verified
+							}
 							status
+							version
+							warnings {
+								code
+								field
+								message
+							}
 						}
 					}
 					pageInfo {
 						startCursor
 						endCursor
 						hasNextPage
+						hasPreviousPage
 					}
+					totalCount
 				}
 			}
 		`
-		query = fmt.Sprintf(query, shared.EncodeBase64([]byte(id3)))
+		query = fmt.Sprintf(query, cursors.Encode(receiverCursor, entity.Cursor{ID: id3}, entity.DefaultSort))
 		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
 
 		rr := httptest.NewRecorder()
@@ -1121,11 +1644,199 @@ func Test_Resolvers_ListReceivers_Success(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rr.Code)
 		useCase.AssertExpectations(t)
 	})
+
+	t.Run("Resolve ListReceivers with last 2 receivers before cursor", func(t *testing.T) {
+		// Arrange
+		filter := graph.BuildFilter(nil, nil, nil, nil, nil)
+		id2 := uuid.New().String()
+		id3 := uuid.New().String()
+		id4 := uuid.New().String()
+		page := entity.PageRequest{Limit: 2, Before: &entity.Cursor{ID: id4}, Backward: true, Sort: entity.DefaultSort}
+		mockOutput := []entity.Receiver{
+			{
+				ID:         id2,
+				Identifier: "123.456.789-09",
+				Name:       "Receiver 2",
+				Email:      "RECEIVER2@GMAIL.COM",
+				Status:     entity.Draft,
+				Pix: entity.Pix{
+					KeyType: entity.CPF,
+					Key:     "123.456.789-09",
+				},
+			},
+			{
+				ID:         id3,
+				Identifier: "333.333.333-33",
+				Name:       "Receiver 3",
+				Email:      "RECEIVER3@GMAIL.COM",
+				Status:     entity.Draft,
+				Pix: entity.Pix{
+					KeyType: entity.CPF,
+					Key:     "333.333.333-33",
+				},
+			},
+		}
+		expectedResult := fmt.Sprintf(
+			`{"data":{"listReceivers":{"edges":[{"cursor":"%s","node":{"id":"%s"}},{"cursor":"%s","node":{"id":"%s"}}],"pageInfo":{"startCursor":"%s","endCursor":"%s","hasNextPage":true,"hasPreviousPage":true}}}}`,
+			cursors.Encode(receiverCursor, entity.Cursor{ID: id2}, entity.DefaultSort), id2,
+			cursors.Encode(receiverCursor, entity.Cursor{ID: id3}, entity.DefaultSort), id3,
+			cursors.Encode(receiverCursor, entity.Cursor{ID: id2}, entity.DefaultSort),
+			cursors.Encode(receiverCursor, entity.Cursor{ID: id3}, entity.DefaultSort),
+		)
+
+		useCase.On("List", mock.Anything, filter, page).Return(&entity.ReceiverPage{Receivers: mockOutput, HasNextPage: true, HasPreviousPage: true}, nil).Once()
+
+		// Act
+		query := `
+			query {
+				listReceivers(last: 2, before: "%s") {
+					edges {
+						cursor
+						node {
+							id
+						}
+					}
+					pageInfo {
+						startCursor
+						endCursor
+						hasNextPage
+						hasPreviousPage
+					}
+				}
+			}
+		`
+		query = fmt.Sprintf(query, cursors.Encode(receiverCursor, entity.Cursor{ID: id4}, entity.DefaultSort))
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, string(rr.Body.Bytes()))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		useCase.AssertExpectations(t)
+	})
+
+	t.Run("Resolve ListReceivers ordered by name descending", func(t *testing.T) {
+		// Arrange
+		filter := graph.BuildFilter(nil, nil, nil, nil, nil)
+		sort := entity.Sort{Field: entity.SortByName, Direction: entity.Descending}
+		id1 := uuid.New().String()
+		id2 := uuid.New().String()
+		page := entity.PageRequest{
+			Limit: 1,
+			After: &entity.Cursor{ID: id1, Value: shared.GetPointerStr("Receiver 2")},
+			Sort:  sort,
+		}
+		mockOutput := []entity.Receiver{
+			{
+				ID:         id2,
+				Identifier: "529.982.247-25",
+				Name:       "Receiver 1",
+				Email:      "RECEIVER1@GMAIL.COM",
+				Status:     entity.Draft,
+				Pix: entity.Pix{
+					KeyType: entity.CPF,
+					Key:     "529.982.247-25",
+				},
+			},
+		}
+		endCursor := cursors.Encode(receiverCursor, entity.Cursor{ID: id2, Value: shared.GetPointerStr("Receiver 1")}, sort)
+		expectedResult := fmt.Sprintf(
+			`{"data":{"listReceivers":{"edges":[{"cursor":"%s","node":{"name":"Receiver 1"}}],"pageInfo":{"hasNextPage":false,"hasPreviousPage":true}}}}`,
+			endCursor,
+		)
+
+		useCase.On("List", mock.Anything, filter, page).Return(&entity.ReceiverPage{Receivers: mockOutput, HasPreviousPage: true}, nil).Once()
+
+		// Act
+		query := `
+			query {
+				listReceivers(first: 1, after: "%s", orderBy: {field: NAME, direction: DESC}) {
+					edges {
+						cursor
+						node {
+							name
+						}
+					}
+					pageInfo {
+						hasNextPage
+						hasPreviousPage
+					}
+				}
+			}
+		`
+		query = fmt.Sprintf(query, cursors.Encode(receiverCursor, *page.After, sort))
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, string(rr.Body.Bytes()))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		useCase.AssertExpectations(t)
+	})
+
+	t.Run("Resolve ListReceivers filtered by search", func(t *testing.T) {
+		// Arrange
+		filter := graph.BuildFilter(nil, nil, nil, nil, shared.GetPointerStr("joao"))
+		page := entity.PageRequest{Limit: graph.TOTAL_PER_PAGE, Sort: entity.DefaultSort}
+		id1 := uuid.New().String()
+		mockOutput := []entity.Receiver{
+			{
+				ID:         id1,
+				Identifier: "529.982.247-25",
+				Name:       "João da Silva LTDA",
+				Email:      "JOAO@GMAIL.COM",
+				Status:     entity.Draft,
+				Pix: entity.Pix{
+					KeyType: entity.CPF,
+					Key:     "529.982.247-25",
+				},
+			},
+		}
+		expectedResult := `{"data":{"listReceivers":{"edges":[{"node":{"name":"João da Silva LTDA"}}]}}}`
+
+		useCase.On("List", mock.Anything, filter, page).Return(&entity.ReceiverPage{Receivers: mockOutput}, nil).Once()
+
+		// Act
+		query := `
+			query {
+				listReceivers(search: "joao") {
+					edges {
+						node {
+							name
+						}
+					}
+				}
+			}
+		`
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, string(rr.Body.Bytes()))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		useCase.AssertExpectations(t)
+	})
 }
 
 func Test_Resolvers_ListReceivers_Error(t *testing.T) {
 	useCase := &mocks.ReceiverUseCases{}
-	h := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: &graph.Resolver{ReceiverUseCases: useCase}}))
+	cursors := cursor.NewCodec([]byte("secret"))
+	h := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: &graph.Resolver{ReceiverUseCases: useCase, Cursors: cursors}}))
 	router := gin.Default()
 	router.POST("/api/v1/receiver", func(c *gin.Context) {
 		h.ServeHTTP(c.Writer, c.Request)
@@ -1133,10 +1844,11 @@ func Test_Resolvers_ListReceivers_Error(t *testing.T) {
 
 	t.Run("Resolve ListReceivers with error from usecase", func(t *testing.T) {
 		// Arrange
-		filter := graph.BuildFilter(nil, nil, nil, nil)
+		filter := graph.BuildFilter(nil, nil, nil, nil, nil)
+		page := entity.PageRequest{Limit: graph.TOTAL_PER_PAGE, Sort: entity.DefaultSort}
 		expectedError := `{"errors":[{"message":"error","path":["listReceivers"]}],"data":{"listReceivers":null}}`
 
-		useCase.On("List", filter).Return(nil, errors.New("error")).Once()
+		useCase.On("List", mock.Anything, filter, page).Return(nil, errors.New("error")).Once()
 
 		// Act
 		query := `
@@ -1147,23 +1859,44 @@ func Test_Resolvers_ListReceivers_Error(t *testing.T) {
 						node {
 							id
 							identifier
+							formattedIdentifier
 							name
 							email
 							pix {
 								keyType
 								key
+								formattedKey
 							}
 							bank
 							agency
 							account
+							bankAccount {
+								bankCode
+								ispb
+								bankName
+								agency
+								agencyDigit
+								account
+								accountDigit
+								accountType
+								verified
+							}
 							status
+							version
+							warnings {
+								code
+								field
+								message
+							}
 						}
 					}
 					pageInfo {
 						startCursor
 						endCursor
 						hasNextPage
+						hasPreviousPage
 					}
+					totalCount
 				}
 			}
 		`
@@ -1179,4 +1912,1053 @@ func Test_Resolvers_ListReceivers_Error(t *testing.T) {
 		assert.Equal(t, []byte(expectedError), rr.Body.Bytes())
 		useCase.AssertExpectations(t)
 	})
+
+	t.Run("Resolve ListReceivers with first and last returns error", func(t *testing.T) {
+		// Arrange
+		expectedError := `{"errors":[{"message":"first and last cannot be used together","path":["listReceivers"]}],"data":{"listReceivers":null}}`
+
+		// Act
+		query := `
+			query {
+				listReceivers(first: 2, last: 2) {
+					edges {
+						cursor
+					}
+				}
+			}
+		`
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(expectedError), rr.Body.Bytes())
+		useCase.AssertExpectations(t)
+	})
+
+	t.Run("Resolve ListReceivers with first above the maximum returns error", func(t *testing.T) {
+		// Arrange
+		expectedError := `{"errors":[{"message":"first must not be greater than 100","path":["listReceivers"]}],"data":{"listReceivers":null}}`
+
+		// Act
+		query := `
+			query {
+				listReceivers(first: 1000000) {
+					edges {
+						cursor
+					}
+				}
+			}
+		`
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(expectedError), rr.Body.Bytes())
+		useCase.AssertExpectations(t)
+	})
+
+	t.Run("Resolve ListReceivers with forged cursor returns error", func(t *testing.T) {
+		// Arrange
+		forged := cursor.NewCodec([]byte("forged")).Encode(receiverCursor, entity.Cursor{ID: uuid.New().String()}, entity.DefaultSort)
+		expectedError := `{"errors":[{"message":"Invalid after cursor: Cursor signature is invalid","path":["listReceivers"]}],"data":{"listReceivers":null}}`
+
+		// Act
+		query := `
+			query {
+				listReceivers(after: "%s") {
+					edges {
+						cursor
+					}
+				}
+			}
+		`
+		query = fmt.Sprintf(query, forged)
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(expectedError), rr.Body.Bytes())
+		useCase.AssertExpectations(t)
+	})
+
+	t.Run("Resolve ListReceivers with cursor from another ordering returns error", func(t *testing.T) {
+		// Arrange
+		stale := cursors.Encode(receiverCursor, entity.Cursor{ID: uuid.New().String()}, entity.DefaultSort)
+		expectedError := `{"errors":[{"message":"Invalid before cursor: Cursor was issued for a different ordering","path":["listReceivers"]}],"data":{"listReceivers":null}}`
+
+		// Act
+		query := `
+			query {
+				listReceivers(last: 2, before: "%s", orderBy: {field: CREATED_AT}) {
+					edges {
+						cursor
+					}
+				}
+			}
+		`
+		query = fmt.Sprintf(query, stale)
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(expectedError), rr.Body.Bytes())
+		useCase.AssertExpectations(t)
+	})
+}
+
+func Test_Resolvers_ReceiverHistory_Success(t *testing.T) {
+	useCase := &mocks.ReceiverUseCases{}
+	cursors := cursor.NewCodec([]byte("secret"))
+	historySort := entity.Sort{Field: entity.SortByID, Direction: entity.Ascending}
+	h := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: &graph.Resolver{ReceiverUseCases: useCase, Cursors: cursors}}))
+	router := gin.Default()
+	router.POST("/api/v1/receiver", func(c *gin.Context) {
+		h.ServeHTTP(c.Writer, c.Request)
+	})
+
+	t.Run("Resolve ReceiverHistory successfully", func(t *testing.T) {
+		// Arrange
+		id := "63f8c8d6c6ce914b5b00b88e"
+		entryID := "1"
+		after := cursors.Encode(historyCursor, entity.Cursor{ID: "0"}, historySort)
+		mockInput := &usecase.ListReceiverHistoryInput{
+			ReceiverId: id,
+			Limit:      1,
+			After:      shared.GetPointerStr("0"),
+		}
+		createdAt := time.Date(2023, 2, 24, 12, 0, 0, 0, time.UTC)
+		mockOutput := &entity.HistoryPage{
+			Entries: []entity.HistoryEntry{
+				{
+					ID:         entryID,
+					ReceiverID: id,
+					Actor:      "maria@transfeera.com",
+					Operation:  entity.HistoryUpdate,
+					Changes: []entity.FieldChange{
+						{Field: "name", Before: shared.GetPointerStr("Receiver 1"), After: shared.GetPointerStr("Receiver 2")},
+					},
+					CreatedAt: createdAt,
+				},
+			},
+			HasNextPage: true,
+		}
+
+		entryCursor := cursors.Encode(historyCursor, entity.Cursor{ID: entryID}, historySort)
+		expectedResult := graph.ReceiverHistory{
+			Edges: []*graph.HistoryEdge{
+				{
+					Cursor: entryCursor,
+					Node: &graph.HistoryEntry{
+						ID:        entryID,
+						Actor:     "maria@transfeera.com",
+						Operation: graph.HistoryOperationUpdate,
+						Changes: []*graph.FieldChange{
+							{Field: "name", Before: shared.GetPointerStr("Receiver 1"), After: shared.GetPointerStr("Receiver 2")},
+						},
+						CreatedAt: "2023-02-24T12:00:00Z",
+					},
+				},
+			},
+			PageInfo: &graph.PageInfo{
+				StartCursor:     entryCursor,
+				EndCursor:       entryCursor,
+				HasNextPage:     shared.GetPointerBool(true),
+				HasPreviousPage: shared.GetPointerBool(false),
+			},
+		}
+		var result struct {
+			Data struct {
+				ReceiverHistory graph.ReceiverHistory `json:"receiverHistory"`
+			} `json:"data"`
+		}
+		result.Data.ReceiverHistory = expectedResult
+		expectedResultBytes, err := json.Marshal(result)
+
+		useCase.On("ListHistory", mock.Anything, mockInput).Return(mockOutput, nil).Once()
+
+		// Act
+		query := `
+			query {
+				receiverHistory(id: "%s", first: 1, after: "%s") {
+					edges {
+						cursor
+						node {
+							id
+							actor
+							operation
+							changes {
+								field
+								before
+								after
+							}
+							reason
+							createdAt
+						}
+					}
+					pageInfo {
+						startCursor
+						endCursor
+						hasNextPage
+						hasPreviousPage
+					}
+				}
+			}
+		`
+		query = fmt.Sprintf(query, id, after)
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResultBytes, rr.Body.Bytes())
+		assert.Equal(t, http.StatusOK, rr.Code)
+		useCase.AssertExpectations(t)
+	})
+
+	t.Run("Resolve history field of Receiver successfully", func(t *testing.T) {
+		// Arrange
+		id := "63f8c8d6c6ce914b5b00b88e"
+		useCase.On("ListById", mock.Anything, &usecase.ListReceiverByIdInput{Id: id}).Return(&entity.Receiver{ID: id}, nil).Once()
+		useCase.On("ListHistory", mock.Anything, &usecase.ListReceiverHistoryInput{ReceiverId: id, Limit: graph.TOTAL_PER_PAGE}).
+			Return(&entity.HistoryPage{Entries: []entity.HistoryEntry{}}, nil).Once()
+		expectedResult := `{"data":{"receiver":{"id":"63f8c8d6c6ce914b5b00b88e","history":{"edges":[],"pageInfo":{"hasNextPage":false}}}}}`
+
+		// Act
+		query := `
+			query {
+				receiver(id: "%s") {
+					id
+					history {
+						edges {
+							cursor
+						}
+						pageInfo {
+							hasNextPage
+						}
+					}
+				}
+			}
+		`
+		query = fmt.Sprintf(query, id)
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(expectedResult), rr.Body.Bytes())
+		assert.Equal(t, http.StatusOK, rr.Code)
+		useCase.AssertExpectations(t)
+	})
+}
+
+func Test_Resolvers_ReceiverHistory_Error(t *testing.T) {
+	useCase := &mocks.ReceiverUseCases{}
+	cursors := cursor.NewCodec([]byte("secret"))
+	h := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: &graph.Resolver{ReceiverUseCases: useCase, Cursors: cursors}}))
+	router := gin.Default()
+	router.POST("/api/v1/receiver", func(c *gin.Context) {
+		h.ServeHTTP(c.Writer, c.Request)
+	})
+
+	t.Run("Resolve ReceiverHistory with error from usecase", func(t *testing.T) {
+		// Arrange
+		id := "63f8c8d6c6ce914b5b00b88e"
+		mockInput := &usecase.ListReceiverHistoryInput{ReceiverId: id, Limit: graph.TOTAL_PER_PAGE}
+		expectedError := `{"errors":[{"message":"error","path":["receiverHistory"]}],"data":{"receiverHistory":null}}`
+
+		useCase.On("ListHistory", mock.Anything, mockInput).Return(nil, errors.New("error")).Once()
+
+		// Act
+		query := `
+			query {
+				receiverHistory(id: "%s") {
+					edges {
+						cursor
+					}
+				}
+			}
+		`
+		query = fmt.Sprintf(query, id)
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(expectedError), rr.Body.Bytes())
+		useCase.AssertExpectations(t)
+	})
+
+	t.Run("Resolve ReceiverHistory with a listReceivers cursor returns error", func(t *testing.T) {
+		// Arrange
+		receiversCursor := cursors.Encode(receiverCursor, entity.Cursor{ID: "63f8c8d6c6ce914b5b00b88f"}, entity.DefaultSort)
+		expectedError := `{"errors":[{"message":"Invalid after cursor: Cursor was issued for a different list","path":["receiverHistory"]}],"data":{"receiverHistory":null}}`
+
+		// Act
+		query := `
+			query {
+				receiverHistory(id: "63f8c8d6c6ce914b5b00b88e", after: "%s") {
+					edges {
+						cursor
+					}
+				}
+			}
+		`
+		query = fmt.Sprintf(query, receiversCursor)
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(expectedError), rr.Body.Bytes())
+		useCase.AssertExpectations(t)
+	})
+
+	t.Run("Resolve ReceiverHistory with first above the maximum returns error", func(t *testing.T) {
+		// Arrange
+		expectedError := `{"errors":[{"message":"first must not be greater than 100","path":["receiverHistory"]}],"data":{"receiverHistory":null}}`
+
+		// Act
+		query := `
+			query {
+				receiverHistory(id: "63f8c8d6c6ce914b5b00b88e", first: 1000) {
+					edges {
+						cursor
+					}
+				}
+			}
+		`
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(expectedError), rr.Body.Bytes())
+		useCase.AssertExpectations(t)
+	})
+
+	t.Run("Resolve ReceiverHistory with invalid cursor returns error", func(t *testing.T) {
+		// Arrange
+		expectedError := `{"errors":[{"message":"Invalid after cursor: Malformed cursor","path":["receiverHistory"]}],"data":{"receiverHistory":null}}`
+
+		// Act
+		query := `
+			query {
+				receiverHistory(id: "63f8c8d6c6ce914b5b00b88e", after: "!!") {
+					edges {
+						cursor
+					}
+				}
+			}
+		`
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(expectedError), rr.Body.Bytes())
+		useCase.AssertExpectations(t)
+	})
+}
+
+func Test_Resolvers_Banks_Success(t *testing.T) {
+	useCase := &mocks.ReceiverUseCases{}
+	h := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: &graph.Resolver{ReceiverUseCases: useCase}}))
+	router := gin.Default()
+	router.POST("/api/v1/receiver", func(c *gin.Context) {
+		h.ServeHTTP(c.Writer, c.Request)
+	})
+
+	t.Run("Resolve Banks successfully", func(t *testing.T) {
+		// Arrange
+		mockOutput := []bank.Institution{
+			{Code: "104", ISPB: "00360305", ShortName: "CAIXA ECONOMICA FEDERAL", Name: "Caixa Econômica Federal", PixParticipant: true},
+		}
+		useCase.On("ListBanks", mock.Anything, &usecase.ListBanksInput{Search: "caixa"}).Return(mockOutput, nil).Once()
+		expectedResult := `{"data":{"banks":[{"code":"104","ispb":"00360305","shortName":"CAIXA ECONOMICA FEDERAL","name":"Caixa Econômica Federal","pixParticipant":true}]}}`
+
+		// Act
+		query := `
+			query {
+				banks(search: "caixa") {
+					code
+					ispb
+					shortName
+					name
+					pixParticipant
+				}
+			}
+		`
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, rr.Body.String())
+		assert.Equal(t, http.StatusOK, rr.Code)
+		useCase.AssertExpectations(t)
+	})
+}
+
+func Test_Resolvers_ReceiverPixBRCode_Success(t *testing.T) {
+	useCase := &mocks.ReceiverUseCases{}
+	h := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: &graph.Resolver{ReceiverUseCases: useCase}}))
+	router := gin.Default()
+	router.POST("/api/v1/receiver", func(c *gin.Context) {
+		h.ServeHTTP(c.Writer, c.Request)
+	})
+
+	t.Run("Resolve ReceiverPixBRCode successfully", func(t *testing.T) {
+		// Arrange
+		id := "63f8c8d6c6ce914b5b00b88e"
+		mockInput := &usecase.GeneratePixBRCodeInput{
+			Id:          id,
+			Amount:      shared.GetPointerInt64(1050),
+			Txid:        "PEDIDO123",
+			Description: "Pagamento",
+		}
+		payload := "00020126460014br.gov.bcb.pix0111529982247250209Pagamento520400005303986540510.505802BR5913Joao da Silva6009SAO PAULO62130509PEDIDO1236304ABCD"
+		useCase.On("GeneratePixBRCode", mock.Anything, mockInput).Return(payload, nil).Once()
+		expectedResult := `{"data":{"receiverPixBRCode":"` + payload + `"}}`
+
+		// Act
+		query := `
+			query {
+				receiverPixBRCode(id: "%s", amount: 1050, txid: "PEDIDO123", description: "Pagamento")
+			}
+		`
+		query = fmt.Sprintf(query, id)
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, rr.Body.String())
+		assert.Equal(t, http.StatusOK, rr.Code)
+		useCase.AssertExpectations(t)
+	})
+}
+
+func Test_Resolvers_CreateReceiverFromBRCode_Success(t *testing.T) {
+	useCase := &mocks.ReceiverUseCases{}
+	h := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: &graph.Resolver{ReceiverUseCases: useCase}}))
+	router := gin.Default()
+	router.POST("/api/v1/receiver", func(c *gin.Context) {
+		h.ServeHTTP(c.Writer, c.Request)
+	})
+
+	t.Run("Resolve CreateReceiverFromBRCode successfully", func(t *testing.T) {
+		// Arrange
+		mockInput := &usecase.CreateReceiverFromBRCodeInput{
+			Payload:    "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D",
+			Identifier: "529.982.247-25",
+			Email:      "fulano@gmail.com",
+		}
+		mockOutput := &entity.Receiver{
+			ID:         "63fbbe585c3c3b8ab3a647aa",
+			Identifier: "52998224725",
+			Name:       "Fulano de Tal",
+			Email:      "fulano@gmail.com",
+			Status:     entity.Draft,
+			Pix: entity.Pix{
+				KeyType: entity.RandomKey,
+				Key:     "123e4567-e12b-12d1-a456-426655440000",
+			},
+			Version: 1,
+		}
+		expectedResult := `{"data":{"createReceiverFromBRCode":{"id":"63fbbe585c3c3b8ab3a647aa","name":"Fulano de Tal","status":"Draft","pix":{"keyType":"CHAVE_ALEATORIA","key":"123e4567-e12b-12d1-a456-426655440000"}}}}`
+
+		useCase.On("CreateFromBRCode", mock.Anything, mockInput).Return(mockOutput, nil).Once()
+
+		// Act
+		query := `
+			mutation {
+				createReceiverFromBRCode(payload: "%s", identifier: "%s", email: "%s") {
+					id
+					name
+					status
+					pix {
+						keyType
+						key
+					}
+				}
+			}
+		`
+		query = fmt.Sprintf(query, mockInput.Payload, mockInput.Identifier, mockInput.Email)
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, rr.Body.String())
+		assert.Equal(t, http.StatusOK, rr.Code)
+		useCase.AssertExpectations(t)
+	})
+
+	t.Run("Resolve CreateReceiverFromBRCode with warnings", func(t *testing.T) {
+		// Arrange
+		mockInput := &usecase.CreateReceiverFromBRCodeInput{
+			Payload:    "00020126330014br.gov.bcb.pix0111529982247255204000053039865802BR5909Company 16009SAO PAULO62070503***6304E2B0",
+			Identifier: "11.222.333/0001-81",
+			Email:      "company1@gmail.com",
+		}
+		mockOutput := &entity.Receiver{
+			ID:         "63fbbe585c3c3b8ab3a647ab",
+			Identifier: "11222333000181",
+			Name:       "Company 1",
+			Status:     entity.Draft,
+			Pix:        entity.Pix{KeyType: entity.CPF, Key: "52998224725"},
+			Warnings: []entity.Warning{
+				{
+					Code:    usecase.RULE_PIX_KEY_PERSON_TYPE_MISMATCH,
+					Field:   "pixKeyType",
+					Message: "CPF Pix Key belongs to a person, but the identifier is the CNPJ of a company",
+				},
+			},
+		}
+		expectedResult := `{"data":{"createReceiverFromBRCode":{"id":"63fbbe585c3c3b8ab3a647ab","warnings":[{"code":"PIX_KEY_PERSON_TYPE_MISMATCH","field":"pixKeyType","message":"CPF Pix Key belongs to a person, but the identifier is the CNPJ of a company"}]}}}`
+
+		useCase.On("CreateFromBRCode", mock.Anything, mockInput).Return(mockOutput, nil).Once()
+
+		// Act
+		query := `
+			mutation {
+				createReceiverFromBRCode(payload: "%s", identifier: "%s", email: "%s") {
+					id
+					warnings {
+						code
+						field
+						message
+					}
+				}
+			}
+		`
+		query = fmt.Sprintf(query, mockInput.Payload, mockInput.Identifier, mockInput.Email)
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, rr.Body.String())
+		assert.Equal(t, http.StatusOK, rr.Code)
+		useCase.AssertExpectations(t)
+	})
+}
+
+func Test_Resolvers_CreateTransfer_Success(t *testing.T) {
+	useCase := &mocks.TransferUseCases{}
+	h := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: &graph.Resolver{TransferUseCases: useCase}}))
+	router := gin.Default()
+	router.POST("/api/v1/receiver", func(c *gin.Context) {
+		h.ServeHTTP(c.Writer, c.Request)
+	})
+
+	t.Run("Resolve CreateTransfer successfully", func(t *testing.T) {
+		// Arrange
+		mockInput := &usecase.CreateTransferInput{
+			ReceiverId:  "63f8c8d6c6ce914b5b00b88e",
+			Amount:      1050,
+			Method:      entity.TransferPix,
+			Description: "Aluguel",
+		}
+		mockOutput := &entity.Transfer{
+			ID:          "63f8c8d6c6ce914b5b00b900",
+			ReceiverID:  mockInput.ReceiverId,
+			Amount:      1050,
+			Method:      entity.TransferPix,
+			Status:      entity.TransferCreated,
+			Description: "Aluguel",
+			PixKey:      "52998224725",
+			CreatedAt:   time.Date(2023, 2, 24, 12, 0, 0, 0, time.UTC),
+		}
+
+		expectedResult := graph.Transfer{
+			ID:          "63f8c8d6c6ce914b5b00b900",
+			ReceiverID:  mockInput.ReceiverId,
+			Amount:      1050,
+			Method:      graph.TransferMethodPix,
+			Status:      graph.TransferStatusCreated,
+			Description: shared.GetPointerStr("Aluguel"),
+			PixKey:      shared.GetPointerStr("52998224725"),
+			CreatedAt:   "2023-02-24T12:00:00Z",
+		}
+		var result struct {
+			Data struct {
+				CreateTransfer graph.Transfer `json:"createTransfer"`
+			} `json:"data"`
+		}
+		result.Data.CreateTransfer = expectedResult
+		expectedResultBytes, err := json.Marshal(result)
+
+		useCase.On("Create", mock.Anything, mockInput).Return(mockOutput, nil).Once()
+
+		// Act
+		query := `
+			mutation {
+				createTransfer(input: {
+					receiverId: "%s",
+					amount: %d,
+					method: PIX,
+					description: "%s"
+					}) {
+					id
+					receiverId
+					amount
+					method
+					status
+					description
+					pixKey
+					bankAccount {
+						bankCode
+					}
+					createdAt
+					updatedAt
+				}
+			}
+		`
+		query = fmt.Sprintf(query, mockInput.ReceiverId, mockInput.Amount, mockInput.Description)
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResultBytes, rr.Body.Bytes())
+		assert.Equal(t, http.StatusOK, rr.Code)
+		useCase.AssertExpectations(t)
+	})
+}
+
+func Test_Resolvers_CreateTransfer_Error(t *testing.T) {
+	useCase := &mocks.TransferUseCases{}
+	h := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: &graph.Resolver{TransferUseCases: useCase}}))
+	h.SetErrorPresenter(graph.ErrorPresenter)
+	router := gin.Default()
+	router.POST("/api/v1/receiver", func(c *gin.Context) {
+		h.ServeHTTP(c.Writer, c.Request)
+	})
+
+	t.Run("Resolve CreateTransfer to receiver not validated returns RECEIVER_NOT_VALIDATED code", func(t *testing.T) {
+		// Arrange
+		mockInput := &usecase.CreateTransferInput{
+			ReceiverId: "63f8c8d6c6ce914b5b00b88e",
+			Amount:     1050,
+			Method:     entity.TransferTED,
+		}
+		expectedError := `{"errors":[{"message":"Receiver 63f8c8d6c6ce914b5b00b88e with status Blocked cannot receive transfers","path":["createTransfer"],"extensions":{"code":"RECEIVER_NOT_VALIDATED","receiverId":"63f8c8d6c6ce914b5b00b88e"}}],"data":{"createTransfer":null}}`
+
+		useCase.On("Create", mock.Anything, mockInput).Return(nil, &usecase.ReceiverNotValidatedError{ID: mockInput.ReceiverId, Status: entity.Blocked}).Once()
+
+		// Act
+		query := `
+			mutation {
+				createTransfer(input: {
+					receiverId: "%s",
+					amount: %d,
+					method: TED
+					}) {
+					id
+				}
+			}
+		`
+		query = fmt.Sprintf(query, mockInput.ReceiverId, mockInput.Amount)
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedError, rr.Body.String())
+		useCase.AssertExpectations(t)
+	})
+
+	t.Run("Resolve CreateTransfer with a reused idempotency key returns CONFLICT code", func(t *testing.T) {
+		// Arrange
+		mockInput := &usecase.CreateTransferInput{
+			ReceiverId:     "63f8c8d6c6ce914b5b00b88e",
+			Amount:         2000,
+			Method:         entity.TransferPix,
+			IdempotencyKey: "order-1",
+		}
+		expectedError := `{"errors":[{"message":"Idempotency key order-1 was used for transfer 63f8c8d6c6ce914b5b00b900 with other data","path":["createTransfer"],"extensions":{"code":"CONFLICT","field":"idempotencyKey","transferId":"63f8c8d6c6ce914b5b00b900"}}],"data":{"createTransfer":null}}`
+
+		useCase.On("Create", mock.Anything, mockInput).Return(nil, &usecase.IdempotencyKeyReusedError{Key: "order-1", TransferID: "63f8c8d6c6ce914b5b00b900"}).Once()
+
+		// Act
+		query := `
+			mutation {
+				createTransfer(input: {
+					receiverId: "%s",
+					amount: %d,
+					method: PIX,
+					idempotencyKey: "%s"
+					}) {
+					id
+				}
+			}
+		`
+		query = fmt.Sprintf(query, mockInput.ReceiverId, mockInput.Amount, mockInput.IdempotencyKey)
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedError, rr.Body.String())
+		useCase.AssertExpectations(t)
+	})
+}
+
+func Test_Resolvers_ChangeTransferStatus(t *testing.T) {
+	useCase := &mocks.TransferUseCases{}
+	h := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: &graph.Resolver{TransferUseCases: useCase}}))
+	h.SetErrorPresenter(graph.ErrorPresenter)
+	router := gin.Default()
+	router.POST("/api/v1/receiver", func(c *gin.Context) {
+		h.ServeHTTP(c.Writer, c.Request)
+	})
+	query := `
+		mutation {
+			changeTransferStatus(input: {id: "%s", status: %s}) {
+				id
+				status
+			}
+		}
+	`
+
+	t.Run("Resolve ChangeTransferStatus successfully", func(t *testing.T) {
+		// Arrange
+		mockInput := &usecase.ChangeTransferStatusInput{Id: "63f8c8d6c6ce914b5b00b900", Status: entity.TransferProcessing}
+		mockOutput := &entity.Transfer{
+			ID:         mockInput.Id,
+			ReceiverID: "63f8c8d6c6ce914b5b00b88e",
+			Amount:     1050,
+			Method:     entity.TransferPix,
+			Status:     entity.TransferProcessing,
+			CreatedAt:  time.Date(2023, 2, 24, 12, 0, 0, 0, time.UTC),
+		}
+		expectedResult := `{"data":{"changeTransferStatus":{"id":"63f8c8d6c6ce914b5b00b900","status":"PROCESSING"}}}`
+
+		useCase.On("ChangeStatus", mock.Anything, mockInput).Return(mockOutput, nil).Once()
+
+		// Act
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: fmt.Sprintf(query, mockInput.Id, "PROCESSING")})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResult, rr.Body.String())
+		useCase.AssertExpectations(t)
+	})
+
+	t.Run("Resolve ChangeTransferStatus of a failed transfer returns INVALID_TRANSITION code", func(t *testing.T) {
+		// Arrange
+		mockInput := &usecase.ChangeTransferStatusInput{Id: "63f8c8d6c6ce914b5b00b900", Status: entity.TransferFinished}
+		expectedError := `{"errors":[{"message":"Cannot move transfer with status FAILED to FINISHED","path":["changeTransferStatus"],"extensions":{"code":"INVALID_TRANSITION"}}],"data":{"changeTransferStatus":null}}`
+
+		useCase.On("ChangeStatus", mock.Anything, mockInput).Return(nil, &entity.TransferStatusError{From: entity.TransferFailed, To: entity.TransferFinished}).Once()
+
+		// Act
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: fmt.Sprintf(query, mockInput.Id, "FINISHED")})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedError, rr.Body.String())
+		useCase.AssertExpectations(t)
+	})
+}
+
+func Test_Resolvers_Transfer_Success(t *testing.T) {
+	useCase := &mocks.TransferUseCases{}
+	h := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: &graph.Resolver{TransferUseCases: useCase}}))
+	router := gin.Default()
+	router.POST("/api/v1/receiver", func(c *gin.Context) {
+		h.ServeHTTP(c.Writer, c.Request)
+	})
+
+	t.Run("Resolve Transfer successfully", func(t *testing.T) {
+		// Arrange
+		mockInput := &usecase.ListTransferByIdInput{Id: "63f8c8d6c6ce914b5b00b900"}
+		mockOutput := &entity.Transfer{
+			ID:         mockInput.Id,
+			ReceiverID: "63f8c8d6c6ce914b5b00b88e",
+			Amount:     250000,
+			Method:     entity.TransferTED,
+			Status:     entity.TransferFinished,
+			BankAccount: &entity.BankAccount{
+				BankCode:     "001",
+				ISPB:         "00000000",
+				Agency:       "0814",
+				AgencyDigit:  "0",
+				Account:      "01002713",
+				AccountDigit: "9",
+				AccountType:  entity.Checking,
+				Verified:     true,
+			},
+			CreatedAt: time.Date(2023, 2, 24, 12, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2023, 2, 24, 12, 5, 0, 0, time.UTC),
+		}
+
+		expectedResult := graph.Transfer{
+			ID:         mockInput.Id,
+			ReceiverID: "63f8c8d6c6ce914b5b00b88e",
+			Amount:     250000,
+			Method:     graph.TransferMethodTed,
+			Status:     graph.TransferStatusFinished,
+			BankAccount: &graph.BankAccount{
+				BankCode:     "001",
+				Ispb:         shared.GetPointerStr("00000000"),
+				BankName:     shared.GetPointerStr("BCO DO BRASIL S.A."),
+				Agency:       "0814",
+				AgencyDigit:  shared.GetPointerStr("0"),
+				Account:      "01002713",
+				AccountDigit: "9",
+				AccountType:  graph.AccountTypeChecking,
+				Verified:     true,
+			},
+			CreatedAt: "2023-02-24T12:00:00Z",
+			UpdatedAt: shared.GetPointerStr("2023-02-24T12:05:00Z"),
+		}
+		var result struct {
+			Data struct {
+				Transfer graph.Transfer `json:"transfer"`
+			} `json:"data"`
+		}
+		result.Data.Transfer = expectedResult
+		expectedResultBytes, err := json.Marshal(result)
+
+		useCase.On("ListById", mock.Anything, mockInput).Return(mockOutput, nil).Once()
+
+		// Act
+		query := `
+			query {
+				transfer(id: "%s") {
+					id
+					receiverId
+					amount
+					method
+					status
+					description
+					pixKey
+					bankAccount {
+						bankCode
+						ispb
+						bankName
+						agency
+						agencyDigit
+						account
+						accountDigit
+						accountType
+						verified
+					}
+					createdAt
+					updatedAt
+				}
+			}
+		`
+		query = fmt.Sprintf(query, mockInput.Id)
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResultBytes, rr.Body.Bytes())
+		assert.Equal(t, http.StatusOK, rr.Code)
+		useCase.AssertExpectations(t)
+	})
+}
+
+func Test_Resolvers_ListTransfers_Success(t *testing.T) {
+	useCase := &mocks.TransferUseCases{}
+	cursors := cursor.NewCodec([]byte("secret"))
+	transferSort := entity.Sort{Field: entity.SortByID, Direction: entity.Descending}
+	h := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: &graph.Resolver{TransferUseCases: useCase, Cursors: cursors}}))
+	router := gin.Default()
+	router.POST("/api/v1/receiver", func(c *gin.Context) {
+		h.ServeHTTP(c.Writer, c.Request)
+	})
+
+	t.Run("Resolve ListTransfers of a receiver with a status successfully", func(t *testing.T) {
+		// Arrange
+		transferID := "63f8c8d6c6ce914b5b00b901"
+		after := cursors.Encode(transferCursor, entity.Cursor{ID: "63f8c8d6c6ce914b5b00b902"}, transferSort)
+		mockInput := &usecase.ListTransfersInput{
+			ReceiverId: "63f8c8d6c6ce914b5b00b88e",
+			Status:     entity.TransferCreated,
+			Limit:      1,
+			After:      shared.GetPointerStr("63f8c8d6c6ce914b5b00b902"),
+		}
+		mockOutput := &entity.TransferPage{
+			Transfers: []entity.Transfer{
+				{
+					ID:         transferID,
+					ReceiverID: mockInput.ReceiverId,
+					Amount:     1050,
+					Method:     entity.TransferPix,
+					Status:     entity.TransferCreated,
+					PixKey:     "52998224725",
+					CreatedAt:  time.Date(2023, 2, 24, 12, 0, 0, 0, time.UTC),
+				},
+			},
+			HasNextPage: true,
+		}
+
+		transferCursor := cursors.Encode(transferCursor, entity.Cursor{ID: transferID}, transferSort)
+		expectedResult := graph.Transfers{
+			Edges: []*graph.TransferEdge{
+				{
+					Cursor: transferCursor,
+					Node: &graph.Transfer{
+						ID:         transferID,
+						ReceiverID: mockInput.ReceiverId,
+						Amount:     1050,
+						Method:     graph.TransferMethodPix,
+						Status:     graph.TransferStatusCreated,
+						PixKey:     shared.GetPointerStr("52998224725"),
+						CreatedAt:  "2023-02-24T12:00:00Z",
+					},
+				},
+			},
+			PageInfo: &graph.PageInfo{
+				StartCursor:     transferCursor,
+				EndCursor:       transferCursor,
+				HasNextPage:     shared.GetPointerBool(true),
+				HasPreviousPage: shared.GetPointerBool(false),
+			},
+		}
+		var result struct {
+			Data struct {
+				ListTransfers graph.Transfers `json:"listTransfers"`
+			} `json:"data"`
+		}
+		result.Data.ListTransfers = expectedResult
+		expectedResultBytes, err := json.Marshal(result)
+
+		useCase.On("List", mock.Anything, mockInput).Return(mockOutput, nil).Once()
+
+		// Act
+		query := `
+			query {
+				listTransfers(receiverId: "%s", status: CREATED, first: 1, after: "%s") {
+					edges {
+						cursor
+						node {
+							id
+							receiverId
+							amount
+							method
+							status
+							description
+							pixKey
+							bankAccount {
+								bankCode
+							}
+							createdAt
+							updatedAt
+						}
+					}
+					pageInfo {
+						startCursor
+						endCursor
+						hasNextPage
+						hasPreviousPage
+					}
+				}
+			}
+		`
+		query = fmt.Sprintf(query, mockInput.ReceiverId, after)
+		gqlMarshalled, err := json.Marshal(graphQLRequest{Query: query})
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodPost, "/api/v1/receiver", strings.NewReader(string(gqlMarshalled)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, expectedResultBytes, rr.Body.Bytes())
+		assert.Equal(t, http.StatusOK, rr.Code)
+		useCase.AssertExpectations(t)
+	})
 }
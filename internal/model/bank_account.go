@@ -0,0 +1,95 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/pkg/bank"
+	"github.com/teste-transfeera/pkg/validation"
+)
+
+type BankAccount struct {
+	BankCode     string `bson:"bank_code"`
+	ISPB         string `bson:"ispb,omitempty"`
+	Agency       string `bson:"agency"`
+	AgencyDigit  string `bson:"agency_digit,omitempty"`
+	Account      string `bson:"account"`
+	AccountDigit string `bson:"account_digit"`
+	AccountType  string `bson:"account_type"`
+	Verified     bool   `bson:"verified"`
+}
+
+func NewBankAccount(account *entity.BankAccount) *BankAccount {
+	if account == nil {
+		return nil
+	}
+
+	return &BankAccount{
+		BankCode:     account.BankCode,
+		ISPB:         account.ISPB,
+		Agency:       account.Agency,
+		AgencyDigit:  account.AgencyDigit,
+		Account:      account.Account,
+		AccountDigit: account.AccountDigit,
+		AccountType:  string(account.AccountType),
+		Verified:     account.Verified,
+	}
+}
+
+func (m *BankAccount) ToEntity() *entity.BankAccount {
+	if m == nil {
+		return nil
+	}
+
+	return &entity.BankAccount{
+		BankCode:     m.BankCode,
+		ISPB:         m.ISPB,
+		Agency:       m.Agency,
+		AgencyDigit:  m.AgencyDigit,
+		Account:      m.Account,
+		AccountDigit: m.AccountDigit,
+		AccountType:  entity.AccountType(m.AccountType),
+		Verified:     m.Verified,
+	}
+}
+
+// LegacyBankAccount converts the free text bank, agency and account of
+// receivers stored before the structured bank account, such as "Bradesco",
+// "0814-0" and "01002713-9". The bank name is looked up in the bank
+// directory and BankCode is left empty when no institution matches. An
+// agency without a separator has no check digit, while the last digit of an
+// account without one is its check digit. The account is not verified, as
+// it is read on every query until the backfill stores it; the backfills call
+// Verify once. It returns nil when every field is empty.
+func LegacyBankAccount(bankName, agency, account string) *BankAccount {
+	bankName, agency, account = strings.TrimSpace(bankName), strings.TrimSpace(agency), strings.TrimSpace(account)
+	if bankName == "" && agency == "" && account == "" {
+		return nil
+	}
+
+	result := &BankAccount{AccountType: string(entity.Checking)}
+	if institution, ok := bank.ByName(bankName); ok {
+		result.BankCode = institution.Code
+		result.ISPB = institution.ISPB
+	}
+
+	result.Agency, result.AgencyDigit, _ = strings.Cut(agency, "-")
+	result.AgencyDigit = validation.NormalizeCheckDigit(result.AgencyDigit)
+
+	number, digit, found := strings.Cut(account, "-")
+	if !found && len(number) > 1 {
+		number, digit = number[:len(number)-1], number[len(number)-1:]
+	}
+	result.Account = number
+	result.AccountDigit = validation.NormalizeCheckDigit(digit)
+
+	return result
+}
+
+// Verify sets Verified from the check digits of the bank. Legacy text whose
+// agency or account does not match the format of the bank is stored as not
+// verified, like accounts of banks with no known algorithm.
+func (m *BankAccount) Verify() {
+	verified, err := validation.CheckBankAccount(m.BankCode, m.Agency, m.AgencyDigit, m.Account, m.AccountDigit)
+	m.Verified = verified && err == nil
+}
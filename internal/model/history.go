@@ -0,0 +1,66 @@
+package model
+
+import (
+	"time"
+
+	"github.com/teste-transfeera/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type HistoryEntry struct {
+	ID         primitive.ObjectID `bson:"_id"`
+	ReceiverID string             `bson:"receiver_id"`
+	Actor      string             `bson:"actor"`
+	Operation  string             `bson:"operation"`
+	Changes    []FieldChange      `bson:"changes"`
+	Reason     string             `bson:"reason,omitempty"`
+	CreatedAt  time.Time          `bson:"created_at"`
+}
+
+type FieldChange struct {
+	Field  string  `bson:"field" json:"field"`
+	Before *string `bson:"before" json:"before"`
+	After  *string `bson:"after" json:"after"`
+}
+
+func NewHistoryEntry(entry entity.HistoryEntry) HistoryEntry {
+	changes := make([]FieldChange, len(entry.Changes))
+	for i, change := range entry.Changes {
+		changes[i] = FieldChange{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		}
+	}
+
+	return HistoryEntry{
+		ID:         primitive.NewObjectID(),
+		ReceiverID: entry.ReceiverID,
+		Actor:      entry.Actor,
+		Operation:  string(entry.Operation),
+		Changes:    changes,
+		Reason:     entry.Reason,
+		CreatedAt:  entry.CreatedAt,
+	}
+}
+
+func (m *HistoryEntry) ToEntity() entity.HistoryEntry {
+	changes := make([]entity.FieldChange, len(m.Changes))
+	for i, change := range m.Changes {
+		changes[i] = entity.FieldChange{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		}
+	}
+
+	return entity.HistoryEntry{
+		ID:         m.ID.Hex(),
+		ReceiverID: m.ReceiverID,
+		Actor:      m.Actor,
+		Operation:  entity.HistoryOperation(m.Operation),
+		Changes:    changes,
+		Reason:     m.Reason,
+		CreatedAt:  m.CreatedAt,
+	}
+}
@@ -1,6 +1,7 @@
 package model
 
 type Pix struct {
-	KeyType string `bson:"key_type"`
-	Key     string `bson:"key"`
+	KeyType      string `bson:"key_type"`
+	Key          string `bson:"key"`
+	FormattedKey string `bson:"formatted_key,omitempty"`
 }
@@ -4,37 +4,81 @@ import (
 	"time"
 
 	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/pkg/search"
+	"github.com/teste-transfeera/pkg/shared"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type Receiver struct {
 	ID         primitive.ObjectID `bson:"_id"`
 	Identifier string             `bson:"identifier"`
-	Name       string             `bson:"name"`
-	Email      string             `bson:"email"`
-	Pix        Pix                `bson:"pix"`
-	Bank       *string            `bson:"bank,omitempty"`
-	Agency     *string            `bson:"agency,omitempty"`
-	Account    *string            `bson:"account,omitempty"`
-	Status     string             `bson:"status"`
-	CreatedAt  time.Time          `bson:"created_at"`
-	UpdatedAt  time.Time          `bson:"updated_at,omitempty"`
-	DeletedAt  time.Time          `bson:"deleted_at,omitempty"`
+	// FormattedIdentifier is missing on receivers created before canonical
+	// values were stored, ToEntity falls back to the identifier.
+	FormattedIdentifier string       `bson:"formatted_identifier,omitempty"`
+	Name                string       `bson:"name"`
+	Email               string       `bson:"email"`
+	Pix                 Pix          `bson:"pix"`
+	BankAccount         *BankAccount `bson:"bank_account,omitempty"`
+	// LegacyBank, LegacyAgency and LegacyAccount are the free text bank
+	// fields of receivers stored before bank_account. BackfillBankAccounts
+	// converts them, and ToEntity reads them while it has not run.
+	LegacyBank    *string   `bson:"bank,omitempty"`
+	LegacyAgency  *string   `bson:"agency,omitempty"`
+	LegacyAccount *string   `bson:"account,omitempty"`
+	Status        string    `bson:"status"`
+	CreatedAt     time.Time `bson:"created_at"`
+	UpdatedAt     time.Time `bson:"updated_at,omitempty"`
+	DeletedAt     time.Time `bson:"deleted_at,omitempty"`
+	Deleted       bool      `bson:"deleted"`
+	Search        []string  `bson:"search,omitempty"`
+	// SearchVersion is the search.TermsVersion Search was built with,
+	// missing on receivers stored before it. BackfillSearchTerms rebuilds
+	// the terms of older receivers.
+	SearchVersion int   `bson:"search_version,omitempty"`
+	Version       int64 `bson:"version"`
+}
+
+// RefreshSearch rebuilds the normalized terms used by the search filter from
+// the receiver's name, email, identifier and pix key.
+func (m *Receiver) RefreshSearch() {
+	m.Search = search.Terms(m.Name, m.Email, m.Identifier, m.Pix.Key)
+	m.SearchVersion = search.TermsVersion
 }
 
 func (m *Receiver) ToEntity() entity.Receiver {
+	formattedIdentifier := m.FormattedIdentifier
+	if formattedIdentifier == "" {
+		formattedIdentifier = m.Identifier
+	}
+	formattedKey := m.Pix.FormattedKey
+	if formattedKey == "" {
+		formattedKey = m.Pix.Key
+	}
+
 	return entity.Receiver{
-		ID:         m.ID.Hex(),
-		Identifier: m.Identifier,
-		Name:       m.Name,
-		Email:      m.Email,
+		ID:                  m.ID.Hex(),
+		Identifier:          m.Identifier,
+		FormattedIdentifier: formattedIdentifier,
+		Name:                m.Name,
+		Email:               m.Email,
 		Pix: entity.Pix{
-			KeyType: entity.PixKeyType(m.Pix.KeyType),
-			Key:     m.Pix.Key,
+			KeyType:      entity.PixKeyType(m.Pix.KeyType),
+			Key:          m.Pix.Key,
+			FormattedKey: formattedKey,
 		},
-		Bank:    m.Bank,
-		Agency:  m.Agency,
-		Account: m.Account,
-		Status:  (entity.Status)(m.Status),
+		BankAccount: m.bankAccount().ToEntity(),
+		Status:      (entity.Status)(m.Status),
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+		Version:     m.Version,
+	}
+}
+
+// bankAccount falls back to the legacy bank fields when the receiver has no
+// structured bank account.
+func (m *Receiver) bankAccount() *BankAccount {
+	if m.BankAccount != nil {
+		return m.BankAccount
 	}
+	return LegacyBankAccount(shared.GetValueStr(m.LegacyBank), shared.GetValueStr(m.LegacyAgency), shared.GetValueStr(m.LegacyAccount))
 }
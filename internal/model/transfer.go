@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"github.com/teste-transfeera/internal/entity"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Transfer struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	ReceiverID  string             `bson:"receiver_id"`
+	Amount      int64              `bson:"amount"`
+	Method      string             `bson:"method"`
+	Status      string             `bson:"status"`
+	Description string             `bson:"description,omitempty"`
+	PixKey      string             `bson:"pix_key,omitempty"`
+	BankAccount *BankAccount       `bson:"bank_account,omitempty"`
+	// IdempotencyKey is left out when empty, so the unique index only covers
+	// transfers created with a key.
+	IdempotencyKey string    `bson:"idempotency_key,omitempty"`
+	CreatedAt      time.Time `bson:"created_at"`
+	UpdatedAt      time.Time `bson:"updated_at,omitempty"`
+}
+
+func NewTransfer(transfer entity.Transfer) Transfer {
+	return Transfer{
+		ID:             primitive.NewObjectID(),
+		ReceiverID:     transfer.ReceiverID,
+		Amount:         transfer.Amount,
+		Method:         string(transfer.Method),
+		Status:         string(transfer.Status),
+		Description:    transfer.Description,
+		PixKey:         transfer.PixKey,
+		BankAccount:    NewBankAccount(transfer.BankAccount),
+		IdempotencyKey: transfer.IdempotencyKey,
+		CreatedAt:      transfer.CreatedAt,
+		UpdatedAt:      transfer.UpdatedAt,
+	}
+}
+
+func (m *Transfer) ToEntity() entity.Transfer {
+	return entity.Transfer{
+		ID:             m.ID.Hex(),
+		ReceiverID:     m.ReceiverID,
+		Amount:         m.Amount,
+		Method:         entity.TransferMethod(m.Method),
+		Status:         entity.TransferStatus(m.Status),
+		Description:    m.Description,
+		PixKey:         m.PixKey,
+		BankAccount:    m.BankAccount.ToEntity(),
+		IdempotencyKey: m.IdempotencyKey,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/teste-transfeera/internal/model"
+	"github.com/teste-transfeera/pkg/search"
+	"github.com/teste-transfeera/pkg/shared"
+	"github.com/teste-transfeera/pkg/validation"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// legacyBankFields matches receivers that still have the free text bank
+// fields and no structured bank account.
+var legacyBankFields = bson.M{
+	"bank_account": bson.M{"$exists": false},
+	"$or": bson.A{
+		bson.M{"bank": bson.M{"$exists": true}},
+		bson.M{"agency": bson.M{"$exists": true}},
+		bson.M{"account": bson.M{"$exists": true}},
+	},
+}
+
+// BackfillBankAccounts converts the free text bank, agency and account of
+// receivers stored before bank_account, mapping bank names to COMPE codes
+// with the bank directory. Receivers whose bank name matches no institution
+// keep their legacy fields, which are still read, and are counted in
+// skipped.
+func BackfillBankAccounts(ctx context.Context, collection *mongo.Collection) (skipped int, err error) {
+	cursor, err := collection.Find(ctx, legacyBankFields)
+	if err != nil {
+		return 0, translateError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var receiver model.Receiver
+		if err := cursor.Decode(&receiver); err != nil {
+			return skipped, translateError(err)
+		}
+
+		account := model.LegacyBankAccount(
+			shared.GetValueStr(receiver.LegacyBank), shared.GetValueStr(receiver.LegacyAgency), shared.GetValueStr(receiver.LegacyAccount),
+		)
+		if account == nil || account.BankCode == "" {
+			skipped++
+			continue
+		}
+		account.Verify()
+
+		_, err := collection.UpdateOne(ctx,
+			bson.M{"_id": receiver.ID, "bank_account": bson.M{"$exists": false}},
+			bson.M{
+				"$set":   bson.M{"bank_account": account},
+				"$unset": bson.M{"bank": "", "agency": "", "account": ""},
+			},
+		)
+		if err != nil {
+			return skipped, translateError(err)
+		}
+	}
+
+	return skipped, translateError(cursor.Err())
+}
+
+// legacyFormats matches receivers stored before canonical values, which
+// have no formatted identifier or formatted pix key.
+var legacyFormats = bson.M{
+	"$or": bson.A{
+		bson.M{"formatted_identifier": bson.M{"$exists": false}},
+		bson.M{"pix.formatted_key": bson.M{"$exists": false}},
+	},
+}
+
+// BackfillCanonicalValues rewrites the identifier, email and pix key of
+// receivers stored before canonical values into their canonical form, keeping
+// the stored text as the formatted identifier and key, so filters on the
+// normalized key and the unique pix.key index see them. A live receiver whose
+// canonical key another live receiver already holds keeps its key and is
+// counted in conflicts, to be resolved by hand before the unique index can be
+// built.
+func BackfillCanonicalValues(ctx context.Context, collection *mongo.Collection) (conflicts int, err error) {
+	cursor, err := collection.Find(ctx, legacyFormats)
+	if err != nil {
+		return 0, translateError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var receiver model.Receiver
+		if err := cursor.Decode(&receiver); err != nil {
+			return conflicts, translateError(err)
+		}
+
+		key := validation.NormalizePixKey(receiver.Pix.Key, receiver.Pix.KeyType)
+		if key != receiver.Pix.Key && !receiver.Deleted {
+			taken, err := collection.CountDocuments(ctx,
+				bson.M{"_id": bson.M{"$ne": receiver.ID}, "pix.key": key, "deleted": false},
+				options.Count().SetLimit(1),
+			)
+			if err != nil {
+				return conflicts, translateError(err)
+			}
+			if taken > 0 {
+				conflicts++
+				continue
+			}
+		}
+
+		receiver.FormattedIdentifier = validation.FormatIdentifier(receiver.Identifier)
+		receiver.Identifier = validation.NormalizeIdentifier(receiver.Identifier)
+		receiver.Email = validation.NormalizeEmail(receiver.Email)
+		receiver.Pix.FormattedKey = validation.FormatPixKey(receiver.Pix.Key, receiver.Pix.KeyType)
+		receiver.Pix.Key = key
+		receiver.RefreshSearch()
+
+		_, err = collection.UpdateOne(ctx,
+			bson.M{"_id": receiver.ID},
+			bson.M{"$set": bson.M{
+				"identifier":           receiver.Identifier,
+				"formatted_identifier": receiver.FormattedIdentifier,
+				"email":                receiver.Email,
+				"pix.key":              receiver.Pix.Key,
+				"pix.formatted_key":    receiver.Pix.FormattedKey,
+				"search":               receiver.Search,
+				"search_version":       receiver.SearchVersion,
+			}},
+		)
+		if mongo.IsDuplicateKeyError(err) {
+			conflicts++
+			continue
+		}
+		if err != nil {
+			return conflicts, translateError(err)
+		}
+	}
+
+	return conflicts, translateError(cursor.Err())
+}
+
+// BackfillSearchTerms rebuilds the search terms of receivers stored with an
+// older search.TermsVersion, which kept every suffix of every word. Their
+// terms still match, so this only reclaims the space they take.
+func BackfillSearchTerms(ctx context.Context, collection *mongo.Collection) error {
+	cursor, err := collection.Find(ctx, bson.M{"search_version": bson.M{"$ne": search.TermsVersion}})
+	if err != nil {
+		return translateError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var receiver model.Receiver
+		if err := cursor.Decode(&receiver); err != nil {
+			return translateError(err)
+		}
+
+		receiver.RefreshSearch()
+		_, err := collection.UpdateOne(ctx,
+			bson.M{"_id": receiver.ID},
+			bson.M{"$set": bson.M{"search": receiver.Search, "search_version": receiver.SearchVersion}},
+		)
+		if err != nil {
+			return translateError(err)
+		}
+	}
+
+	return translateError(cursor.Err())
+}
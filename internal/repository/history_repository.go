@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// HistoryRepository stores the change history of receivers. It only appends:
+// entries are never updated or removed.
+type HistoryRepository interface {
+	Append(ctx context.Context, entries ...entity.HistoryEntry) error
+	// List returns the entries of a receiver oldest first, starting after
+	// the entry with id after when it is given.
+	List(ctx context.Context, receiverID string, limit int, after *string) (*entity.HistoryPage, error)
+}
+
+type historyRepository struct {
+	collection *mongo.Collection
+	timeouts   Timeouts
+}
+
+// NewHistoryRepository stores history entries in their own collection.
+// Appending shares the Create timeout and listing the List timeout.
+func NewHistoryRepository(collection *mongo.Collection, timeouts Timeouts) HistoryRepository {
+	return &historyRepository{
+		collection: collection,
+		timeouts:   timeouts,
+	}
+}
+
+func (r *historyRepository) Append(ctx context.Context, entries ...entity.HistoryEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	ctx, cancel := withTimeout(ctx, r.timeouts.Create)
+	defer cancel()
+
+	documents := make([]interface{}, len(entries))
+	for i, entry := range entries {
+		entry.CreatedAt = now()
+		documents[i] = model.NewHistoryEntry(entry)
+	}
+
+	_, err := r.collection.InsertMany(ctx, documents)
+	return translateError(err)
+}
+
+func (r *historyRepository) List(ctx context.Context, receiverID string, limit int, after *string) (*entity.HistoryPage, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	bsonFilter := bson.M{"receiver_id": receiverID}
+	if after != nil {
+		afterID, err := primitive.ObjectIDFromHex(*after)
+		if err != nil {
+			return nil, err
+		}
+		bsonFilter["_id"] = bson.M{"$gt": afterID}
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit + 1))
+
+	cursor, err := r.collection.Find(ctx, bsonFilter, findOptions)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer cursor.Close(ctx)
+
+	entries := []entity.HistoryEntry{}
+	for cursor.Next(ctx) {
+		var entry model.HistoryEntry
+		if err := cursor.Decode(&entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry.ToEntity())
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, translateError(err)
+	}
+
+	return historyPage(entries, limit), nil
+}
+
+func historyPage(entries []entity.HistoryEntry, limit int) *entity.HistoryPage {
+	page := &entity.HistoryPage{Entries: entries}
+	if len(entries) > limit {
+		page.Entries = entries[:limit]
+		page.HasNextPage = true
+	}
+	return page
+}
@@ -0,0 +1,118 @@
+package repository_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/repository"
+	"github.com/teste-transfeera/pkg/shared"
+)
+
+func historyBackends(t *testing.T) map[string]func() repository.HistoryRepository {
+	return map[string]func() repository.HistoryRepository{
+		"memory": repository.NewMemoryHistoryRepository,
+		"sqlite": func() repository.HistoryRepository {
+			db, err := repository.OpenSQLite(filepath.Join(t.TempDir(), "transfeera.db"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { db.Close() })
+
+			if err := repository.MigrateSQLite(context.Background(), db); err != nil {
+				t.Fatal(err)
+			}
+			return repository.NewSQLiteHistoryRepository(db, repository.DefaultTimeouts())
+		},
+	}
+}
+
+func Test_HistoryRepository(t *testing.T) {
+	ctx := context.Background()
+
+	for backend, newRepository := range historyBackends(t) {
+		t.Run(backend, func(t *testing.T) {
+			t.Run("Append and list entries of a receiver in order", func(t *testing.T) {
+				repo := newRepository()
+				created := entity.HistoryEntry{
+					ReceiverID: "63f8c8d6c6ce914b5b00b88e",
+					Actor:      "maria@transfeera.com",
+					Operation:  entity.HistoryCreate,
+					Changes:    []entity.FieldChange{{Field: "name", After: shared.GetPointerStr("Receiver 1")}},
+				}
+				updated := entity.HistoryEntry{
+					ReceiverID: "63f8c8d6c6ce914b5b00b88e",
+					Actor:      "joao@transfeera.com",
+					Operation:  entity.HistoryUpdate,
+					Changes: []entity.FieldChange{
+						{Field: "name", Before: shared.GetPointerStr("Receiver 1"), After: shared.GetPointerStr("Receiver 2")},
+					},
+				}
+				other := entity.HistoryEntry{
+					ReceiverID: "63f8c8d6c6ce914b5b00b88f",
+					Actor:      "maria@transfeera.com",
+					Operation:  entity.HistoryDelete,
+					Changes:    []entity.FieldChange{},
+				}
+				assert.NoError(t, repo.Append(ctx, created))
+				assert.NoError(t, repo.Append(ctx, updated, other))
+
+				first, err := repo.List(ctx, created.ReceiverID, 1, nil)
+				assert.NoError(t, err)
+				assert.True(t, first.HasNextPage)
+				assert.Len(t, first.Entries, 1)
+				assert.Equal(t, "maria@transfeera.com", first.Entries[0].Actor)
+				assert.Equal(t, created.Changes, first.Entries[0].Changes)
+				assert.False(t, first.Entries[0].CreatedAt.IsZero())
+
+				second, err := repo.List(ctx, created.ReceiverID, 1, &first.Entries[0].ID)
+				assert.NoError(t, err)
+				assert.False(t, second.HasNextPage)
+				assert.Len(t, second.Entries, 1)
+				assert.Equal(t, entity.HistoryUpdate, second.Entries[0].Operation)
+				assert.Equal(t, updated.Changes, second.Entries[0].Changes)
+			})
+
+			t.Run("Append and list a status change with its reason", func(t *testing.T) {
+				repo := newRepository()
+				blocked := entity.HistoryEntry{
+					ReceiverID: "63f8c8d6c6ce914b5b00b88e",
+					Actor:      "maria@transfeera.com",
+					Operation:  entity.HistoryStatusChange,
+					Changes: []entity.FieldChange{
+						{Field: "status", Before: shared.GetPointerStr("Validated"), After: shared.GetPointerStr("Blocked")},
+					},
+					Reason: "Suspected fraud",
+				}
+				assert.NoError(t, repo.Append(ctx, blocked))
+
+				result, err := repo.List(ctx, blocked.ReceiverID, 10, nil)
+				assert.NoError(t, err)
+				assert.Len(t, result.Entries, 1)
+				assert.Equal(t, entity.HistoryStatusChange, result.Entries[0].Operation)
+				assert.Equal(t, "Suspected fraud", result.Entries[0].Reason)
+				assert.Equal(t, blocked.Changes, result.Entries[0].Changes)
+			})
+
+			t.Run("List entries of receiver without history returns empty page", func(t *testing.T) {
+				repo := newRepository()
+
+				result, err := repo.List(ctx, "63f8c8d6c6ce914b5b00b88e", 10, nil)
+
+				assert.NoError(t, err)
+				assert.Empty(t, result.Entries)
+				assert.False(t, result.HasNextPage)
+			})
+
+			t.Run("List entries after invalid cursor returns error", func(t *testing.T) {
+				repo := newRepository()
+
+				_, err := repo.List(ctx, "63f8c8d6c6ce914b5b00b88e", 10, shared.GetPointerStr("invalid"))
+
+				assert.Error(t, err)
+			})
+		})
+	}
+}
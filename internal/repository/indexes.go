@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// IndexSpec describes an index of the receiver collection.
+type IndexSpec struct {
+	Name          string
+	Keys          bson.D
+	Unique        bool
+	PartialFilter bson.D
+}
+
+// liveReceivers keeps soft-deleted receivers out of an index. Partial indexes
+// cannot filter on a missing field, which is why receivers carry the deleted
+// flag next to deleted_at.
+var liveReceivers = bson.D{{Key: "deleted", Value: false}}
+
+// ReceiverIndexes is the declared index set of the receiver collection.
+// ApplyIndexes makes a collection match its declared set.
+var ReceiverIndexes = []IndexSpec{
+	{
+		Name:          "status_1_created_at_1",
+		Keys:          bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}},
+		PartialFilter: liveReceivers,
+	},
+	{
+		Name:          "identifier_1",
+		Keys:          bson.D{{Key: "identifier", Value: 1}},
+		PartialFilter: liveReceivers,
+	},
+	{
+		Name:          "name_1",
+		Keys:          bson.D{{Key: "name", Value: 1}},
+		PartialFilter: liveReceivers,
+	},
+	{
+		Name:          "pix.key_type_1",
+		Keys:          bson.D{{Key: "pix.key_type", Value: 1}},
+		PartialFilter: liveReceivers,
+	},
+	{
+		Name:          "pix.key_1",
+		Keys:          bson.D{{Key: "pix.key", Value: 1}},
+		Unique:        true,
+		PartialFilter: liveReceivers,
+	},
+	{
+		Name:          "search_1",
+		Keys:          bson.D{{Key: "search", Value: 1}},
+		PartialFilter: liveReceivers,
+	},
+}
+
+// HistoryIndexes is the declared index set of the receiver_history
+// collection, which is always read by receiver in insertion order.
+var HistoryIndexes = []IndexSpec{
+	{
+		Name: "receiver_id_1__id_1",
+		Keys: bson.D{{Key: "receiver_id", Value: 1}, {Key: "_id", Value: 1}},
+	},
+}
+
+// TransferIndexes is the declared index set of the transfer collection,
+// which is listed newest first, by receiver or by status. The idempotency
+// key is unique among the transfers created with one.
+var TransferIndexes = []IndexSpec{
+	{
+		Name: "receiver_id_1__id_-1",
+		Keys: bson.D{{Key: "receiver_id", Value: 1}, {Key: "_id", Value: -1}},
+	},
+	{
+		Name: "status_1__id_-1",
+		Keys: bson.D{{Key: "status", Value: 1}, {Key: "_id", Value: -1}},
+	},
+	{
+		Name:          "idempotency_key_1",
+		Keys:          bson.D{{Key: "idempotency_key", Value: 1}},
+		Unique:        true,
+		PartialFilter: bson.D{{Key: "idempotency_key", Value: bson.D{{Key: "$exists", Value: true}}}},
+	},
+}
+
+// CollectionIndexes pairs a collection with its declared indexes.
+type CollectionIndexes struct {
+	Collection string
+	Indexes    []IndexSpec
+}
+
+// DatabaseIndexes is the declared index set of every collection of the
+// transfeera database.
+var DatabaseIndexes = []CollectionIndexes{
+	{Collection: "receiver", Indexes: ReceiverIndexes},
+	{Collection: "receiver_history", Indexes: HistoryIndexes},
+	{Collection: "transfer", Indexes: TransferIndexes},
+}
+
+// UniqueIndexes returns the unique indexes of a declared set. They enforce
+// rules such as one live receiver per pix key, so the API builds them at
+// startup even when the other indexes are left to the migrate command.
+func UniqueIndexes(declared []IndexSpec) []IndexSpec {
+	var unique []IndexSpec
+	for _, index := range declared {
+		if index.Unique {
+			unique = append(unique, index)
+		}
+	}
+	return unique
+}
+
+// IndexDrift lists the differences between the declared and the actual
+// indexes of a collection.
+type IndexDrift struct {
+	// Missing are declared indexes the collection does not have.
+	Missing []IndexSpec
+	// Changed are declared indexes whose existing namesake has other keys
+	// or options.
+	Changed []IndexSpec
+	// Extra are names of existing indexes that are not declared.
+	Extra []string
+}
+
+func (d IndexDrift) IsEmpty() bool {
+	return len(d.Missing) == 0 && len(d.Changed) == 0 && len(d.Extra) == 0
+}
+
+// Report describes the drift one index per line, for logs and the CLI.
+func (d IndexDrift) Report() []string {
+	var lines []string
+	for _, index := range d.Missing {
+		lines = append(lines, fmt.Sprintf("missing index %s", index.Name))
+	}
+	for _, index := range d.Changed {
+		lines = append(lines, fmt.Sprintf("index %s differs from its declaration", index.Name))
+	}
+	for _, name := range d.Extra {
+		lines = append(lines, fmt.Sprintf("index %s is not declared", name))
+	}
+	return lines
+}
+
+// CompareIndexes matches declared and existing indexes by name. The default
+// _id index is never reported.
+func CompareIndexes(declared, existing []IndexSpec) IndexDrift {
+	var drift IndexDrift
+
+	existingByName := map[string]IndexSpec{}
+	for _, index := range existing {
+		existingByName[index.Name] = index
+	}
+
+	declaredNames := map[string]bool{}
+	for _, index := range declared {
+		declaredNames[index.Name] = true
+
+		current, ok := existingByName[index.Name]
+		if !ok {
+			drift.Missing = append(drift.Missing, index)
+			continue
+		}
+		if !sameIndex(index, current) {
+			drift.Changed = append(drift.Changed, index)
+		}
+	}
+
+	for _, index := range existing {
+		if index.Name == "_id_" || declaredNames[index.Name] {
+			continue
+		}
+		drift.Extra = append(drift.Extra, index.Name)
+	}
+
+	return drift
+}
+
+func sameIndex(a, b IndexSpec) bool {
+	return a.Unique == b.Unique && sameDocument(a.Keys, b.Keys) && sameDocument(a.PartialFilter, b.PartialFilter)
+}
+
+// sameDocument compares documents by their encoding, so 1 declared as an int
+// equals the int32 the server reports.
+func sameDocument(a, b bson.D) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+
+	encodedA, errA := bson.Marshal(a)
+	encodedB, errB := bson.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(encodedA, encodedB)
+}
+
+// ListIndexes reads the indexes the collection currently has.
+func ListIndexes(ctx context.Context, collection *mongo.Collection) ([]IndexSpec, error) {
+	cursor, err := collection.Indexes().List(ctx)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	var documents []struct {
+		Name          string `bson:"name"`
+		Key           bson.D `bson:"key"`
+		Unique        bool   `bson:"unique"`
+		PartialFilter bson.D `bson:"partialFilterExpression"`
+	}
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, translateError(err)
+	}
+
+	indexes := make([]IndexSpec, 0, len(documents))
+	for _, document := range documents {
+		indexes = append(indexes, IndexSpec{
+			Name:          document.Name,
+			Keys:          document.Key,
+			Unique:        document.Unique,
+			PartialFilter: document.PartialFilter,
+		})
+	}
+
+	return indexes, nil
+}
+
+// CheckIndexes reports the drift of a collection from its declared indexes
+// without changing it.
+func CheckIndexes(ctx context.Context, collection *mongo.Collection, declared []IndexSpec) (IndexDrift, error) {
+	existing, err := ListIndexes(ctx, collection)
+	if err != nil {
+		return IndexDrift{}, err
+	}
+
+	return CompareIndexes(declared, existing), nil
+}
+
+// ApplyIndexes creates the missing indexes and rebuilds the changed ones.
+// Indexes that are not declared are only dropped when prune is set. It
+// returns the drift found before applying.
+func ApplyIndexes(ctx context.Context, collection *mongo.Collection, declared []IndexSpec, prune bool) (IndexDrift, error) {
+	drift, err := CheckIndexes(ctx, collection, declared)
+	if err != nil {
+		return drift, err
+	}
+
+	for _, index := range drift.Changed {
+		if _, err := collection.Indexes().DropOne(ctx, index.Name); err != nil {
+			return drift, translateError(err)
+		}
+	}
+	if prune {
+		for _, name := range drift.Extra {
+			if _, err := collection.Indexes().DropOne(ctx, name); err != nil {
+				return drift, translateError(err)
+			}
+		}
+	}
+
+	toCreate := append(append([]IndexSpec{}, drift.Missing...), drift.Changed...)
+	if len(toCreate) == 0 {
+		return drift, nil
+	}
+
+	models := make([]mongo.IndexModel, 0, len(toCreate))
+	for _, index := range toCreate {
+		indexOptions := options.Index().SetName(index.Name)
+		if index.Unique {
+			indexOptions.SetUnique(true)
+		}
+		if len(index.PartialFilter) > 0 {
+			indexOptions.SetPartialFilterExpression(index.PartialFilter)
+		}
+		models = append(models, mongo.IndexModel{Keys: index.Keys, Options: indexOptions})
+	}
+
+	if _, err := collection.Indexes().CreateMany(ctx, models); err != nil {
+		return drift, translateError(err)
+	}
+
+	return drift, nil
+}
+
+// BackfillDeletedFlag sets the deleted flag on receivers stored before it
+// existed, deriving it from deleted_at. Queries and partial indexes rely on
+// the flag, so this runs before the indexes are applied.
+func BackfillDeletedFlag(ctx context.Context, collection *mongo.Collection) error {
+	_, err := collection.UpdateMany(ctx,
+		bson.M{"deleted": bson.M{"$exists": false}, "deleted_at": bson.M{"$exists": true}},
+		bson.M{"$set": bson.M{"deleted": true}},
+	)
+	if err != nil {
+		return translateError(err)
+	}
+
+	_, err = collection.UpdateMany(ctx,
+		bson.M{"deleted": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"deleted": false}},
+	)
+	return translateError(err)
+}
@@ -0,0 +1,60 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teste-transfeera/internal/repository"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func Test_CompareIndexes(t *testing.T) {
+	live := bson.D{{Key: "deleted", Value: false}}
+	declared := []repository.IndexSpec{
+		{Name: "name_1", Keys: bson.D{{Key: "name", Value: 1}}, PartialFilter: live},
+		{Name: "pix.key_1", Keys: bson.D{{Key: "pix.key", Value: 1}}, Unique: true, PartialFilter: live},
+	}
+
+	t.Run("Collection matching the declaration has no drift", func(t *testing.T) {
+		existing := []repository.IndexSpec{
+			{Name: "_id_", Keys: bson.D{{Key: "_id", Value: int32(1)}}},
+			{Name: "name_1", Keys: bson.D{{Key: "name", Value: int32(1)}}, PartialFilter: live},
+			{Name: "pix.key_1", Keys: bson.D{{Key: "pix.key", Value: int32(1)}}, Unique: true, PartialFilter: live},
+		}
+
+		drift := repository.CompareIndexes(declared, existing)
+
+		assert.True(t, drift.IsEmpty())
+		assert.Empty(t, drift.Report())
+	})
+
+	t.Run("Missing, changed and undeclared indexes are reported", func(t *testing.T) {
+		existing := []repository.IndexSpec{
+			{Name: "_id_", Keys: bson.D{{Key: "_id", Value: int32(1)}}},
+			{Name: "pix.key_1", Keys: bson.D{{Key: "pix.key", Value: int32(1)}}},
+			{Name: "email_1", Keys: bson.D{{Key: "email", Value: int32(1)}}},
+		}
+
+		drift := repository.CompareIndexes(declared, existing)
+
+		assert.False(t, drift.IsEmpty())
+		assert.Equal(t, []string{
+			"missing index name_1",
+			"index pix.key_1 differs from its declaration",
+			"index email_1 is not declared",
+		}, drift.Report())
+	})
+
+	t.Run("Declared receiver indexes exclude deleted receivers", func(t *testing.T) {
+		for _, index := range repository.ReceiverIndexes {
+			assert.Equal(t, live, index.PartialFilter, index.Name)
+		}
+	})
+}
+
+func Test_UniqueIndexes(t *testing.T) {
+	unique := repository.UniqueIndexes(repository.ReceiverIndexes)
+
+	assert.Len(t, unique, 1)
+	assert.Equal(t, "pix.key_1", unique[0].Name)
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// memoryHistoryRepository keeps history entries in process memory, in the
+// order they were appended. It is safe for concurrent use.
+type memoryHistoryRepository struct {
+	mu      sync.RWMutex
+	entries []model.HistoryEntry
+}
+
+func NewMemoryHistoryRepository() HistoryRepository {
+	return &memoryHistoryRepository{}
+}
+
+func (r *memoryHistoryRepository) Append(ctx context.Context, entries ...entity.HistoryEntry) error {
+	if err := ctx.Err(); err != nil {
+		return translateError(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, entry := range entries {
+		entry.CreatedAt = now()
+		r.entries = append(r.entries, model.NewHistoryEntry(entry))
+	}
+
+	return nil
+}
+
+func (r *memoryHistoryRepository) List(ctx context.Context, receiverID string, limit int, after *string) (*entity.HistoryPage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, translateError(err)
+	}
+
+	var afterID primitive.ObjectID
+	if after != nil {
+		var err error
+		afterID, err = primitive.ObjectIDFromHex(*after)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := []entity.HistoryEntry{}
+	for _, entry := range r.entries {
+		if entry.ReceiverID != receiverID {
+			continue
+		}
+		if after != nil && entry.ID.Hex() <= afterID.Hex() {
+			continue
+		}
+		entries = append(entries, entry.ToEntity())
+		if len(entries) > limit {
+			break
+		}
+	}
+
+	return historyPage(entries, limit), nil
+}
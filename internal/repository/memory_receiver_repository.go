@@ -0,0 +1,462 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/model"
+	"github.com/teste-transfeera/pkg/search"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// memoryReceiverRepository keeps receivers in process memory, mirroring the
+// behaviour of the Mongo repository: soft delete through DeletedAt, the same
+// filters, errors and ordering. It is safe for concurrent use.
+type memoryReceiverRepository struct {
+	mu        sync.RWMutex
+	receivers map[primitive.ObjectID]model.Receiver
+}
+
+func NewMemoryReceiverRepository() ReceiverRepository {
+	return &memoryReceiverRepository{
+		receivers: make(map[primitive.ObjectID]model.Receiver),
+	}
+}
+
+func (r *memoryReceiverRepository) Create(ctx context.Context, receiver entity.Receiver) (*entity.Receiver, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, translateError(err)
+	}
+
+	model := model.Receiver{
+		ID:                  primitive.NewObjectID(),
+		Identifier:          receiver.Identifier,
+		FormattedIdentifier: receiver.FormattedIdentifier,
+		Name:                receiver.Name,
+		Email:               receiver.Email,
+		Pix: model.Pix{
+			KeyType:      string(receiver.Pix.KeyType),
+			Key:          receiver.Pix.Key,
+			FormattedKey: receiver.Pix.FormattedKey,
+		},
+		BankAccount: model.NewBankAccount(receiver.BankAccount),
+		Status:      string(receiver.Status),
+		CreatedAt:   now(),
+		Version:     1,
+	}
+	model.RefreshSearch()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.duplicatePixKey(model.ID, model.Pix.Key); err != nil {
+		return nil, err
+	}
+	r.receivers[model.ID] = model
+
+	entity := model.ToEntity()
+	return &entity, nil
+}
+
+func (r *memoryReceiverRepository) List(ctx context.Context, filter map[string]string, page entity.PageRequest) (*entity.ReceiverPage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, translateError(err)
+	}
+
+	after, err := memoryCursor(page.Sort, page.After)
+	if err != nil {
+		return nil, err
+	}
+	before, err := memoryCursor(page.Sort, page.Before)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	matching := r.matching(filter)
+	r.mu.RUnlock()
+
+	sort.Slice(matching, func(i, j int) bool {
+		return compareReceivers(page.Sort, &matching[i], &matching[j]) < 0
+	})
+
+	var inRange []model.Receiver
+	for _, receiver := range matching {
+		key := receiverSortKey(page.Sort, &receiver)
+		if after != nil && compareSortKeys(page.Sort, key, *after) <= 0 {
+			continue
+		}
+		if before != nil && compareSortKeys(page.Sort, key, *before) >= 0 {
+			continue
+		}
+		inRange = append(inRange, receiver)
+	}
+
+	if page.Backward {
+		for i, j := 0, len(inRange)-1; i < j; i, j = i+1, j-1 {
+			inRange[i], inRange[j] = inRange[j], inRange[i]
+		}
+	}
+
+	hasMore := len(inRange) > page.Limit
+	if hasMore {
+		inRange = inRange[:page.Limit]
+	}
+
+	receivers := []entity.Receiver{}
+	for _, receiver := range inRange {
+		receivers = append(receivers, receiver.ToEntity())
+	}
+
+	result := &entity.ReceiverPage{}
+	if page.Backward {
+		for i, j := 0, len(receivers)-1; i < j; i, j = i+1, j-1 {
+			receivers[i], receivers[j] = receivers[j], receivers[i]
+		}
+		result.HasPreviousPage = hasMore
+		result.HasNextPage = existsBeyondKey(page.Sort, matching, before, true)
+	} else {
+		result.HasNextPage = hasMore
+		result.HasPreviousPage = existsBeyondKey(page.Sort, matching, after, false)
+	}
+
+	result.Receivers = receivers
+	return result, nil
+}
+
+func (r *memoryReceiverRepository) Count(ctx context.Context, filter map[string]string) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, translateError(err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return int64(len(r.matching(filter))), nil
+}
+
+func (r *memoryReceiverRepository) FindById(ctx context.Context, id string) (*entity.Receiver, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, translateError(err)
+	}
+
+	docID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	receiver, ok := r.receivers[docID]
+	r.mu.RUnlock()
+
+	if !ok || !receiver.DeletedAt.IsZero() {
+		return nil, mongo.ErrNoDocuments
+	}
+
+	entity := receiver.ToEntity()
+	return &entity, nil
+}
+
+func (r *memoryReceiverRepository) FindByIds(ctx context.Context, ids []string) ([]entity.Receiver, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, translateError(err)
+	}
+
+	docIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		docID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, err
+		}
+		docIDs = append(docIDs, docID)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	receivers := []entity.Receiver{}
+	for _, docID := range docIDs {
+		receiver, ok := r.receivers[docID]
+		if ok && receiver.DeletedAt.IsZero() {
+			receivers = append(receivers, receiver.ToEntity())
+		}
+	}
+	return receivers, nil
+}
+
+func (r *memoryReceiverRepository) FindByIdentifier(ctx context.Context, identifier string) (*entity.Receiver, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, translateError(err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var oldest *model.Receiver
+	for _, receiver := range r.receivers {
+		if receiver.Deleted || receiver.Identifier != identifier {
+			continue
+		}
+		if oldest == nil || receiver.ID.Hex() < oldest.ID.Hex() {
+			found := receiver
+			oldest = &found
+		}
+	}
+
+	if oldest == nil {
+		return nil, mongo.ErrNoDocuments
+	}
+
+	entity := oldest.ToEntity()
+	return &entity, nil
+}
+
+func (r *memoryReceiverRepository) Update(ctx context.Context, id string, version int64, fields map[string]string) error {
+	if err := ctx.Err(); err != nil {
+		return translateError(err)
+	}
+
+	docID, _ := primitive.ObjectIDFromHex(id)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	receiver, ok := r.receivers[docID]
+	if !ok {
+		return ErrRecordNotFound
+	}
+	if receiver.Version != version {
+		return &VersionConflictError{ID: id, ExpectedVersion: version}
+	}
+	if err := r.duplicatePixKey(docID, fields["key"]); err != nil {
+		return err
+	}
+
+	applyUpdate(&receiver, fields)
+	receiver.UpdatedAt = now()
+	receiver.Version++
+	if changesSearchFields(fields) {
+		receiver.RefreshSearch()
+	}
+	r.receivers[docID] = receiver
+
+	return nil
+}
+
+// duplicatePixKey mirrors the unique pix.key index: it fails when a live
+// receiver other than id holds key. The caller must hold the lock.
+func (r *memoryReceiverRepository) duplicatePixKey(id primitive.ObjectID, key string) error {
+	if key == "" {
+		return nil
+	}
+
+	for _, receiver := range r.receivers {
+		if receiver.ID != id && !receiver.Deleted && receiver.Pix.Key == key {
+			return &DuplicatePixKeyError{Key: key, ReceiverID: receiver.ID.Hex()}
+		}
+	}
+
+	return nil
+}
+
+func (r *memoryReceiverRepository) Delete(ctx context.Context, ids []string) error {
+	if err := ctx.Err(); err != nil {
+		return translateError(err)
+	}
+
+	docIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		docID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return err
+		}
+		docIDs = append(docIDs, docID)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deletedAt := now()
+	matched := 0
+	for _, docID := range docIDs {
+		receiver, ok := r.receivers[docID]
+		if !ok {
+			continue
+		}
+		receiver.DeletedAt = deletedAt
+		receiver.Deleted = true
+		r.receivers[docID] = receiver
+		matched++
+	}
+
+	if matched == 0 {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// matching returns the live receivers accepted by the filter. The caller must
+// hold the lock.
+func (r *memoryReceiverRepository) matching(filter map[string]string) []model.Receiver {
+	words := search.Query(filter["search"])
+
+	var receivers []model.Receiver
+	for _, receiver := range r.receivers {
+		if !receiver.DeletedAt.IsZero() {
+			continue
+		}
+		if filter["status"] != "" && receiver.Status != filter["status"] {
+			continue
+		}
+		if filter["name"] != "" && receiver.Name != filter["name"] {
+			continue
+		}
+		if filter["key_type"] != "" && receiver.Pix.KeyType != filter["key_type"] {
+			continue
+		}
+		if filter["key"] != "" && receiver.Pix.Key != filter["key"] {
+			continue
+		}
+		if !matchesSearch(receiver.Search, words) {
+			continue
+		}
+		receivers = append(receivers, receiver)
+	}
+
+	return receivers
+}
+
+func matchesSearch(terms []string, words []string) bool {
+	for _, word := range words {
+		found := false
+		for _, term := range terms {
+			if strings.HasPrefix(term, word) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// sortKey is a receiver's position in an ordering: the value of the sort
+// field (nil when missing, string or time.Time otherwise) and its id.
+type sortKey struct {
+	value interface{}
+	id    primitive.ObjectID
+}
+
+func receiverSortKey(s entity.Sort, receiver *model.Receiver) sortKey {
+	key := sortKey{id: receiver.ID}
+
+	switch s.Field {
+	case entity.SortByName:
+		key.value = receiver.Name
+	case entity.SortByStatus:
+		key.value = receiver.Status
+	case entity.SortByCreatedAt:
+		key.value = receiver.CreatedAt
+	case entity.SortByUpdatedAt:
+		if !receiver.UpdatedAt.IsZero() {
+			key.value = receiver.UpdatedAt
+		}
+	}
+
+	return key
+}
+
+func memoryCursor(s entity.Sort, cursor *entity.Cursor) (*sortKey, error) {
+	if cursor == nil {
+		return nil, nil
+	}
+
+	id, err := primitive.ObjectIDFromHex(cursor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	key := sortKey{id: id}
+	if _, ok := sortFields[s.Field]; ok && cursor.Value != nil {
+		key.value, err = sortValue(s.Field, *cursor.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &key, nil
+}
+
+func compareReceivers(s entity.Sort, a *model.Receiver, b *model.Receiver) int {
+	return compareSortKeys(s, receiverSortKey(s, a), receiverSortKey(s, b))
+}
+
+// compareSortKeys orders two keys as Mongo would for the given sort: missing
+// values first, then by value, then by id, everything reversed for DESC.
+func compareSortKeys(s entity.Sort, a sortKey, b sortKey) int {
+	result := compareValues(a.value, b.value)
+	if result == 0 {
+		result = strings.Compare(a.id.Hex(), b.id.Hex())
+	}
+	if s.Direction == entity.Descending {
+		result = -result
+	}
+	return result
+}
+
+func compareValues(a interface{}, b interface{}) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	switch av := a.(type) {
+	case string:
+		return strings.Compare(av, b.(string))
+	case time.Time:
+		bv := b.(time.Time)
+		if av.Before(bv) {
+			return -1
+		}
+		if av.After(bv) {
+			return 1
+		}
+	}
+	return 0
+}
+
+// existsBeyondKey is the in-memory counterpart of existsBeyond: it reports
+// whether any receiver sits at or past the cursor in the given direction.
+func existsBeyondKey(s entity.Sort, receivers []model.Receiver, cursor *sortKey, forward bool) bool {
+	if cursor == nil {
+		return false
+	}
+
+	for _, receiver := range receivers {
+		if receiver.ID == cursor.id {
+			return true
+		}
+		result := compareSortKeys(s, receiverSortKey(s, &receiver), *cursor)
+		if (forward && result > 0) || (!forward && result < 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// now returns the current time with the millisecond precision Mongo stores.
+func now() time.Time {
+	return time.Now().Truncate(time.Millisecond)
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// memoryTransferRepository keeps transfers in process memory, in the order
+// they were created. It is safe for concurrent use.
+type memoryTransferRepository struct {
+	mu        sync.RWMutex
+	transfers []model.Transfer
+}
+
+func NewMemoryTransferRepository() TransferRepository {
+	return &memoryTransferRepository{}
+}
+
+func (r *memoryTransferRepository) Create(ctx context.Context, transfer entity.Transfer) (*entity.Transfer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, translateError(err)
+	}
+
+	transfer.CreatedAt = now()
+	document := model.NewTransfer(transfer)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if document.IdempotencyKey != "" {
+		for _, existing := range r.transfers {
+			if existing.IdempotencyKey == document.IdempotencyKey {
+				return nil, ErrDuplicateIdempotencyKey
+			}
+		}
+	}
+	r.transfers = append(r.transfers, document)
+
+	created := document.ToEntity()
+	return &created, nil
+}
+
+func (r *memoryTransferRepository) FindById(ctx context.Context, id string) (*entity.Transfer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, translateError(err)
+	}
+
+	docID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, transfer := range r.transfers {
+		if transfer.ID == docID {
+			found := transfer.ToEntity()
+			return &found, nil
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (r *memoryTransferRepository) FindByIdempotencyKey(ctx context.Context, key string) (*entity.Transfer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, translateError(err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, transfer := range r.transfers {
+		if transfer.IdempotencyKey == key {
+			found := transfer.ToEntity()
+			return &found, nil
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (r *memoryTransferRepository) List(ctx context.Context, filter TransferFilter, limit int, after *string) (*entity.TransferPage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, translateError(err)
+	}
+
+	var afterID primitive.ObjectID
+	if after != nil {
+		var err error
+		afterID, err = primitive.ObjectIDFromHex(*after)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	transfers := []entity.Transfer{}
+	for i := len(r.transfers) - 1; i >= 0 && len(transfers) <= limit; i-- {
+		transfer := r.transfers[i]
+		if filter.ReceiverID != "" && transfer.ReceiverID != filter.ReceiverID {
+			continue
+		}
+		if filter.Status != "" && transfer.Status != string(filter.Status) {
+			continue
+		}
+		if after != nil && transfer.ID.Hex() >= afterID.Hex() {
+			continue
+		}
+		transfers = append(transfers, transfer.ToEntity())
+	}
+
+	return transferPage(transfers, limit), nil
+}
+
+func (r *memoryTransferRepository) UpdateStatus(ctx context.Context, id string, from entity.TransferStatus, to entity.TransferStatus) error {
+	if err := ctx.Err(); err != nil {
+		return translateError(err)
+	}
+
+	docID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.transfers {
+		if r.transfers[i].ID != docID {
+			continue
+		}
+		if r.transfers[i].Status != string(from) {
+			return ErrTransferStatusChanged
+		}
+		r.transfers[i].Status = string(to)
+		r.transfers[i].UpdatedAt = now()
+		return nil
+	}
+	return ErrTransferStatusChanged
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/teste-transfeera/internal/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var sortFields = map[entity.SortField]string{
+	entity.SortByName:      "name",
+	entity.SortByCreatedAt: "created_at",
+	entity.SortByUpdatedAt: "updated_at",
+	entity.SortByStatus:    "status",
+}
+
+func buildSort(sort entity.Sort, backward bool) bson.D {
+	order := 1
+	if sort.Direction == entity.Descending {
+		order = -1
+	}
+	if backward {
+		order = -order
+	}
+
+	field, ok := sortFields[sort.Field]
+	if !ok {
+		return bson.D{{Key: "_id", Value: order}}
+	}
+
+	return bson.D{{Key: field, Value: order}, {Key: "_id", Value: order}}
+}
+
+// buildKeysetCondition matches the receivers strictly after (forward) or
+// strictly before the cursor in the requested ordering. Ties on the sort key
+// are broken by _id, and a missing sort key sorts before any value, as Mongo
+// itself does.
+func buildKeysetCondition(sort entity.Sort, cursor entity.Cursor, forward bool) (bson.M, error) {
+	cursorID, err := primitive.ObjectIDFromHex(cursor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	operator := "$lt"
+	if forward != (sort.Direction == entity.Descending) {
+		operator = "$gt"
+	}
+
+	field, ok := sortFields[sort.Field]
+	if !ok {
+		return bson.M{"_id": bson.M{operator: cursorID}}, nil
+	}
+
+	sameKeyNextID := bson.M{"_id": bson.M{operator: cursorID}}
+
+	if cursor.Value == nil {
+		sameKeyNextID[field] = nil
+		if operator == "$gt" {
+			return bson.M{"$or": bson.A{sameKeyNextID, bson.M{field: bson.M{"$ne": nil}}}}, nil
+		}
+		return sameKeyNextID, nil
+	}
+
+	value, err := sortValue(sort.Field, *cursor.Value)
+	if err != nil {
+		return nil, err
+	}
+	sameKeyNextID[field] = value
+
+	conditions := bson.A{bson.M{field: bson.M{operator: value}}, sameKeyNextID}
+	if operator == "$lt" {
+		conditions = append(conditions, bson.M{field: nil})
+	}
+
+	return bson.M{"$or": conditions}, nil
+}
+
+func sortValue(field entity.SortField, value string) (interface{}, error) {
+	switch field {
+	case entity.SortByCreatedAt, entity.SortByUpdatedAt:
+		return time.Parse(time.RFC3339Nano, value)
+	}
+	return value, nil
+}
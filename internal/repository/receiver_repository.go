@@ -3,71 +3,144 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/teste-transfeera/internal/entity"
 	"github.com/teste-transfeera/internal/model"
+	"github.com/teste-transfeera/pkg/search"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+var ErrRecordNotFound = errors.New("record does not exist")
+
+// VersionConflictError is returned by Update when the receiver is no longer
+// at the version the caller read, meaning someone else changed it meanwhile.
+type VersionConflictError struct {
+	ID              string
+	ExpectedVersion int64
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("Receiver %s was modified by another request, expected version %d", e.ID, e.ExpectedVersion)
+}
+
+// DuplicatePixKeyError is returned by Create and Update when another live
+// receiver already holds the pix key. ReceiverID is empty when that receiver
+// was deleted before it could be looked up.
+type DuplicatePixKeyError struct {
+	Key        string
+	ReceiverID string
+}
+
+func (e *DuplicatePixKeyError) Error() string {
+	if e.ReceiverID == "" {
+		return fmt.Sprintf("Pix Key %s is already used by another receiver", e.Key)
+	}
+	return fmt.Sprintf("Pix Key %s is already used by receiver %s", e.Key, e.ReceiverID)
+}
+
 type ReceiverRepository interface {
-	Create(receiver entity.Receiver) (*entity.Receiver, error)
-	List(filter map[string]string) ([]entity.Receiver, error)
-	FindById(id string) (*entity.Receiver, error)
-	Update(id string, fields map[string]string) error
-	Delete(ids []string) error
+	Create(ctx context.Context, receiver entity.Receiver) (*entity.Receiver, error)
+	List(ctx context.Context, filter map[string]string, page entity.PageRequest) (*entity.ReceiverPage, error)
+	Count(ctx context.Context, filter map[string]string) (int64, error)
+	FindById(ctx context.Context, id string) (*entity.Receiver, error)
+	// FindByIds returns the live receivers among ids in a single query, in
+	// no particular order. Unknown and deleted ids are left out.
+	FindByIds(ctx context.Context, ids []string) ([]entity.Receiver, error)
+	// FindByIdentifier returns the oldest live receiver with the canonical
+	// identifier, or mongo.ErrNoDocuments when there is none.
+	FindByIdentifier(ctx context.Context, identifier string) (*entity.Receiver, error)
+	Update(ctx context.Context, id string, version int64, fields map[string]string) error
+	Delete(ctx context.Context, ids []string) error
 }
 
 type receiverRepository struct {
 	collection *mongo.Collection
-	ctx        context.Context
+	timeouts   Timeouts
 }
 
-func NewReceiverRepository(collection *mongo.Collection, ctx context.Context) ReceiverRepository {
+func NewReceiverRepository(collection *mongo.Collection, timeouts Timeouts) ReceiverRepository {
 	return &receiverRepository{
 		collection: collection,
-		ctx:        ctx,
+		timeouts:   timeouts,
 	}
 }
 
-func (r *receiverRepository) Create(receiver entity.Receiver) (*entity.Receiver, error) {
+func (r *receiverRepository) Create(ctx context.Context, receiver entity.Receiver) (*entity.Receiver, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Create)
+	defer cancel()
+
 	model := model.Receiver{
-		ID:         primitive.NewObjectID(),
-		Identifier: receiver.Identifier,
-		Name:       receiver.Name,
-		Email:      receiver.Email,
+		ID:                  primitive.NewObjectID(),
+		Identifier:          receiver.Identifier,
+		FormattedIdentifier: receiver.FormattedIdentifier,
+		Name:                receiver.Name,
+		Email:               receiver.Email,
 		Pix: model.Pix{
-			KeyType: string(receiver.Pix.KeyType),
-			Key:     receiver.Pix.Key,
+			KeyType:      string(receiver.Pix.KeyType),
+			Key:          receiver.Pix.Key,
+			FormattedKey: receiver.Pix.FormattedKey,
 		},
-		Status:    string(receiver.Status),
-		CreatedAt: time.Now(),
+		BankAccount: model.NewBankAccount(receiver.BankAccount),
+		Status:      string(receiver.Status),
+		CreatedAt:   time.Now(),
+		Version:     1,
 	}
+	model.RefreshSearch()
 
-	_, err := r.collection.InsertOne(r.ctx, &model)
+	_, err := r.collection.InsertOne(ctx, &model)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, r.duplicatePixKey(ctx, model.Pix.Key)
+	}
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	entity := model.ToEntity()
 	return &entity, nil
 }
 
-func (r *receiverRepository) List(filter map[string]string) ([]entity.Receiver, error) {
+func (r *receiverRepository) List(ctx context.Context, filter map[string]string, page entity.PageRequest) (*entity.ReceiverPage, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
 	bsonFilter := buildFilter(filter)
-	bsonFilter["deleted_at"] = bson.M{"$exists": false}
-	findOptions := options.Find()
+	bsonFilter["deleted"] = false
 
-	cursor, err := r.collection.Find(r.ctx, bsonFilter, findOptions)
+	var cursorConditions []interface{}
+	if page.After != nil {
+		condition, err := buildKeysetCondition(page.Sort, *page.After, true)
+		if err != nil {
+			return nil, err
+		}
+		cursorConditions = append(cursorConditions, condition)
+	}
+	if page.Before != nil {
+		condition, err := buildKeysetCondition(page.Sort, *page.Before, false)
+		if err != nil {
+			return nil, err
+		}
+		cursorConditions = append(cursorConditions, condition)
+	}
+	appendConditions(bsonFilter, cursorConditions...)
+
+	findOptions := options.Find().
+		SetSort(buildSort(page.Sort, page.Backward)).
+		SetLimit(int64(page.Limit + 1))
+
+	cursor, err := r.collection.Find(ctx, bsonFilter, findOptions)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
+	defer cursor.Close(ctx)
 
-	var receivers []entity.Receiver
-	for cursor.Next(r.ctx) {
+	receivers := []entity.Receiver{}
+	for cursor.Next(ctx) {
 		var receiver model.Receiver
 		err := cursor.Decode(&receiver)
 		if err != nil {
@@ -79,28 +152,94 @@ func (r *receiverRepository) List(filter map[string]string) ([]entity.Receiver,
 	}
 
 	if err := cursor.Err(); err != nil {
+		return nil, translateError(err)
+	}
+
+	hasMore := len(receivers) > page.Limit
+	if hasMore {
+		receivers = receivers[:page.Limit]
+	}
+
+	result := &entity.ReceiverPage{}
+	if page.Backward {
+		for i, j := 0, len(receivers)-1; i < j; i, j = i+1, j-1 {
+			receivers[i], receivers[j] = receivers[j], receivers[i]
+		}
+		result.HasPreviousPage = hasMore
+		result.HasNextPage, err = r.existsBeyond(ctx, filter, page.Sort, page.Before, true)
+	} else {
+		result.HasNextPage = hasMore
+		result.HasPreviousPage, err = r.existsBeyond(ctx, filter, page.Sort, page.After, false)
+	}
+	if err != nil {
 		return nil, err
 	}
 
-	cursor.Close(r.ctx)
+	result.Receivers = receivers
+	return result, nil
+}
 
-	return receivers, nil
+func (r *receiverRepository) Count(ctx context.Context, filter map[string]string) (int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Count)
+	defer cancel()
+
+	bsonFilter := buildFilter(filter)
+	bsonFilter["deleted"] = false
+
+	count, err := r.collection.CountDocuments(ctx, bsonFilter)
+	if err != nil {
+		return 0, translateError(err)
+	}
+
+	return count, nil
+}
+
+// existsBeyond reports whether any live receiver matching the filter sits at
+// or past the cursor in the given direction, which is how the page flag
+// opposite to the paging direction is answered.
+func (r *receiverRepository) existsBeyond(ctx context.Context, filter map[string]string, sort entity.Sort, cursor *entity.Cursor, forward bool) (bool, error) {
+	if cursor == nil {
+		return false, nil
+	}
+
+	condition, err := buildKeysetCondition(sort, *cursor, forward)
+	if err != nil {
+		return false, err
+	}
+	cursorID, err := primitive.ObjectIDFromHex(cursor.ID)
+	if err != nil {
+		return false, err
+	}
+
+	bsonFilter := buildFilter(filter)
+	bsonFilter["deleted"] = false
+	bsonFilter["$or"] = bson.A{condition, bson.M{"_id": cursorID}}
+
+	count, err := r.collection.CountDocuments(ctx, bsonFilter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, translateError(err)
+	}
+
+	return count > 0, nil
 }
 
-func (r *receiverRepository) FindById(id string) (*entity.Receiver, error) {
+func (r *receiverRepository) FindById(ctx context.Context, id string) (*entity.Receiver, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.FindById)
+	defer cancel()
+
 	docID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return nil, err
 	}
 
-	bsonFilter := bson.M{"_id": docID, "deleted_at": bson.M{"$exists": false}}
+	bsonFilter := bson.M{"_id": docID, "deleted": false}
 
-	result := r.collection.FindOne(r.ctx, bsonFilter)
+	result := r.collection.FindOne(ctx, bsonFilter)
 
 	var receiver model.Receiver
 	err = result.Decode(&receiver)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	if err := result.Err(); err != nil {
@@ -111,26 +250,142 @@ func (r *receiverRepository) FindById(id string) (*entity.Receiver, error) {
 	return &entity, nil
 }
 
-func (r *receiverRepository) Update(id string, fields map[string]string) error {
+func (r *receiverRepository) FindByIds(ctx context.Context, ids []string) ([]entity.Receiver, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.FindById)
+	defer cancel()
+
+	docIDs := bson.A{}
+	for _, id := range ids {
+		docID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, err
+		}
+		docIDs = append(docIDs, docID)
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": docIDs}, "deleted": false})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer cursor.Close(ctx)
+
+	receivers := []entity.Receiver{}
+	for cursor.Next(ctx) {
+		var receiver model.Receiver
+		if err := cursor.Decode(&receiver); err != nil {
+			return nil, err
+		}
+		receivers = append(receivers, receiver.ToEntity())
+	}
+
+	return receivers, translateError(cursor.Err())
+}
+
+func (r *receiverRepository) FindByIdentifier(ctx context.Context, identifier string) (*entity.Receiver, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.FindById)
+	defer cancel()
+
+	bsonFilter := bson.M{"identifier": identifier, "deleted": false}
+	opts := options.FindOne().SetSort(bson.D{{Key: "_id", Value: 1}})
+
+	var receiver model.Receiver
+	err := r.collection.FindOne(ctx, bsonFilter, opts).Decode(&receiver)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	entity := receiver.ToEntity()
+	return &entity, nil
+}
+
+func (r *receiverRepository) Update(ctx context.Context, id string, version int64, fields map[string]string) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Update)
+	defer cancel()
+
 	docID, err := primitive.ObjectIDFromHex(id)
-	bsonFilter := bson.M{"_id": docID}
+	bsonFilter := bson.M{"_id": docID, "version": versionFilter(version)}
+	bsonUpdate := buildUpdate(fields)
+
+	if changesSearchFields(fields) {
+		var receiver model.Receiver
+		err := r.collection.FindOne(ctx, bsonFilter).Decode(&receiver)
+		if err == mongo.ErrNoDocuments {
+			return r.missingOrConflict(ctx, docID, version)
+		}
+		if err != nil {
+			return translateError(err)
+		}
+
+		applyUpdate(&receiver, fields)
+		receiver.RefreshSearch()
+		bsonUpdate = append(bsonUpdate,
+			primitive.E{Key: "search", Value: receiver.Search},
+			primitive.E{Key: "search_version", Value: receiver.SearchVersion},
+		)
+	}
+
 	updater := bson.D{
-		{"$set", buildUpdate(fields)},
+		{Key: "$set", Value: bsonUpdate},
+		{Key: "$inc", Value: bson.D{{Key: "version", Value: 1}}},
 	}
 
-	result, err := r.collection.UpdateOne(r.ctx, bsonFilter, updater)
+	result, err := r.collection.UpdateOne(ctx, bsonFilter, updater)
+	if mongo.IsDuplicateKeyError(err) {
+		return r.duplicatePixKey(ctx, fields["key"])
+	}
 	if err != nil {
-		return err
+		return translateError(err)
 	}
 
 	if result.MatchedCount == 0 {
-		return errors.New("record does not exist")
+		return r.missingOrConflict(ctx, docID, version)
 	}
 
 	return nil
 }
 
-func (r *receiverRepository) Delete(ids []string) error {
+// missingOrConflict tells apart the two reasons an update matched nothing.
+func (r *receiverRepository) missingOrConflict(ctx context.Context, docID primitive.ObjectID, version int64) error {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"_id": docID}, options.Count().SetLimit(1))
+	if err != nil {
+		return translateError(err)
+	}
+	if count == 0 {
+		return ErrRecordNotFound
+	}
+
+	return &VersionConflictError{ID: docID.Hex(), ExpectedVersion: version}
+}
+
+// duplicatePixKey looks up the live receiver holding key after a write was
+// rejected by the unique pix.key index. The holder may have been deleted in
+// between, and the write is still reported as a duplicate.
+func (r *receiverRepository) duplicatePixKey(ctx context.Context, key string) error {
+	var receiver model.Receiver
+	err := r.collection.FindOne(ctx, bson.M{"pix.key": key, "deleted": false}).Decode(&receiver)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return &DuplicatePixKeyError{Key: key}
+	}
+	if err != nil {
+		return translateError(err)
+	}
+
+	return &DuplicatePixKeyError{Key: key, ReceiverID: receiver.ID.Hex()}
+}
+
+// versionFilter matches receivers at the given version. Receivers stored
+// before versioning have no version field and count as version 0.
+func versionFilter(version int64) interface{} {
+	if version == 0 {
+		return bson.M{"$in": bson.A{0, nil}}
+	}
+	return version
+}
+
+func (r *receiverRepository) Delete(ctx context.Context, ids []string) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Delete)
+	defer cancel()
+
 	bsonList := bson.A{}
 	for _, id := range ids {
 		docID, err := primitive.ObjectIDFromHex(id)
@@ -150,17 +405,21 @@ func (r *receiverRepository) Delete(ids []string) error {
 					Key:   "deleted_at",
 					Value: time.Now(),
 				},
+				primitive.E{
+					Key:   "deleted",
+					Value: true,
+				},
 			},
 		},
 	}
 
-	result, err := r.collection.UpdateMany(r.ctx, bsonFilter, updater)
+	result, err := r.collection.UpdateMany(ctx, bsonFilter, updater)
 	if err != nil {
-		return err
+		return translateError(err)
 	}
 
 	if result.MatchedCount == 0 {
-		return errors.New("record does not exist")
+		return ErrRecordNotFound
 	}
 
 	return nil
@@ -181,30 +440,113 @@ func buildFilter(filter map[string]string) bson.M {
 	if filter["key"] != "" {
 		bsonFilter["pix.key"] = filter["key"]
 	}
+	if filter["search"] != "" {
+		var searchConditions []interface{}
+		for _, word := range search.Query(filter["search"]) {
+			pattern := primitive.Regex{Pattern: "^" + regexp.QuoteMeta(word)}
+			searchConditions = append(searchConditions, bson.M{"search": pattern})
+		}
+		appendConditions(bsonFilter, searchConditions...)
+	}
 
 	return bsonFilter
 }
 
+func appendConditions(bsonFilter bson.M, conditions ...interface{}) {
+	if len(conditions) == 0 {
+		return
+	}
+
+	existing, _ := bsonFilter["$and"].(bson.A)
+	bsonFilter["$and"] = append(existing, conditions...)
+}
+
 func buildUpdate(fields map[string]string) bson.D {
 	var bsonUpdate bson.D
 
 	if fields["identifier"] != "" {
-		bsonUpdate = append(bsonUpdate, primitive.E{"identifier", fields["identifier"]})
+		bsonUpdate = append(bsonUpdate, primitive.E{Key: "identifier", Value: fields["identifier"]})
+	}
+	if fields["formatted_identifier"] != "" {
+		bsonUpdate = append(bsonUpdate, primitive.E{Key: "formatted_identifier", Value: fields["formatted_identifier"]})
 	}
 	if fields["name"] != "" {
-		bsonUpdate = append(bsonUpdate, primitive.E{"name", fields["name"]})
+		bsonUpdate = append(bsonUpdate, primitive.E{Key: "name", Value: fields["name"]})
 	}
 	if fields["email"] != "" {
-		bsonUpdate = append(bsonUpdate, primitive.E{"email", fields["email"]})
+		bsonUpdate = append(bsonUpdate, primitive.E{Key: "email", Value: fields["email"]})
 	}
 	if fields["key_type"] != "" {
-		bsonUpdate = append(bsonUpdate, primitive.E{"pix.key_type", fields["key_type"]})
+		bsonUpdate = append(bsonUpdate, primitive.E{Key: "pix.key_type", Value: fields["key_type"]})
 	}
 	if fields["key"] != "" {
-		bsonUpdate = append(bsonUpdate, primitive.E{"pix.key", fields["key"]})
+		bsonUpdate = append(bsonUpdate, primitive.E{Key: "pix.key", Value: fields["key"]})
+	}
+	if fields["formatted_key"] != "" {
+		bsonUpdate = append(bsonUpdate, primitive.E{Key: "pix.formatted_key", Value: fields["formatted_key"]})
+	}
+	if fields["status"] != "" {
+		bsonUpdate = append(bsonUpdate, primitive.E{Key: "status", Value: fields["status"]})
+	}
+	if account := bankAccountUpdate(fields); account != nil {
+		bsonUpdate = append(bsonUpdate, primitive.E{Key: "bank_account", Value: account})
 	}
 
-	bsonUpdate = append(bsonUpdate, primitive.E{"updated_at", time.Now()})
+	bsonUpdate = append(bsonUpdate, primitive.E{Key: "updated_at", Value: time.Now()})
 
 	return bsonUpdate
 }
+
+func changesSearchFields(fields map[string]string) bool {
+	return fields["identifier"] != "" || fields["name"] != "" || fields["email"] != "" || fields["key"] != ""
+}
+
+func applyUpdate(receiver *model.Receiver, fields map[string]string) {
+	if fields["identifier"] != "" {
+		receiver.Identifier = fields["identifier"]
+	}
+	if fields["formatted_identifier"] != "" {
+		receiver.FormattedIdentifier = fields["formatted_identifier"]
+	}
+	if fields["name"] != "" {
+		receiver.Name = fields["name"]
+	}
+	if fields["email"] != "" {
+		receiver.Email = fields["email"]
+	}
+	if fields["key_type"] != "" {
+		receiver.Pix.KeyType = fields["key_type"]
+	}
+	if fields["key"] != "" {
+		receiver.Pix.Key = fields["key"]
+	}
+	if fields["formatted_key"] != "" {
+		receiver.Pix.FormattedKey = fields["formatted_key"]
+	}
+	if fields["status"] != "" {
+		receiver.Status = fields["status"]
+	}
+	if account := bankAccountUpdate(fields); account != nil {
+		receiver.BankAccount = account
+	}
+}
+
+// bankAccountUpdate reads the bank account from the update fields. The
+// account is always replaced as a whole, so it is only present with its
+// bank_code.
+func bankAccountUpdate(fields map[string]string) *model.BankAccount {
+	if fields["bank_code"] == "" {
+		return nil
+	}
+
+	return &model.BankAccount{
+		BankCode:     fields["bank_code"],
+		ISPB:         fields["ispb"],
+		Agency:       fields["agency"],
+		AgencyDigit:  fields["agency_digit"],
+		Account:      fields["account"],
+		AccountDigit: fields["account_digit"],
+		AccountType:  fields["account_type"],
+		Verified:     fields["verified"] == "true",
+	}
+}
@@ -0,0 +1,424 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/repository"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// pixKeys numbers the pix keys of created receivers, which must be unique
+// among live receivers.
+var pixKeys int64
+
+func createReceivers(t *testing.T, repo repository.ReceiverRepository, names ...string) []*entity.Receiver {
+	var created []*entity.Receiver
+	for i, name := range names {
+		receiver, err := repo.Create(context.Background(), entity.Receiver{
+			Identifier: fmt.Sprintf("111.111.111-%02d", i),
+			Name:       name,
+			Email:      fmt.Sprintf("RECEIVER%d@GMAIL.COM", i),
+			Pix: entity.Pix{
+				KeyType: entity.Email,
+				Key:     fmt.Sprintf("receiver%d@gmail.com", atomic.AddInt64(&pixKeys, 1)),
+			},
+			Status: entity.Draft,
+		})
+		assert.NoError(t, err)
+		created = append(created, receiver)
+	}
+	return created
+}
+
+func names(receivers []entity.Receiver) []string {
+	var result []string
+	for _, receiver := range receivers {
+		result = append(result, receiver.Name)
+	}
+	return result
+}
+
+// backends builds a fresh, empty repository of each implementation, so the
+// same behaviour is checked on all of them.
+func backends(t *testing.T) map[string]func() repository.ReceiverRepository {
+	return map[string]func() repository.ReceiverRepository{
+		"memory": repository.NewMemoryReceiverRepository,
+		"sqlite": func() repository.ReceiverRepository {
+			db, err := repository.OpenSQLite(filepath.Join(t.TempDir(), "transfeera.db"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { db.Close() })
+
+			if err := repository.MigrateSQLite(context.Background(), db); err != nil {
+				t.Fatal(err)
+			}
+			return repository.NewSQLiteReceiverRepository(db, repository.DefaultTimeouts())
+		},
+	}
+}
+
+func Test_ReceiverRepository_Success(t *testing.T) {
+	for backend, newRepository := range backends(t) {
+		t.Run(backend, func(t *testing.T) {
+			testReceiverRepositorySuccess(t, newRepository)
+		})
+	}
+}
+
+func Test_ReceiverRepository_Error(t *testing.T) {
+	for backend, newRepository := range backends(t) {
+		t.Run(backend, func(t *testing.T) {
+			testReceiverRepositoryError(t, newRepository())
+		})
+	}
+}
+
+func testReceiverRepositorySuccess(t *testing.T, newRepository func() repository.ReceiverRepository) {
+	ctx := context.Background()
+
+	t.Run("Create and find receiver successfully", func(t *testing.T) {
+		repo := newRepository()
+		created := createReceivers(t, repo, "Receiver 1")[0]
+
+		result, err := repo.FindById(ctx, created.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, created, result)
+		assert.False(t, result.CreatedAt.IsZero())
+		assert.Nil(t, result.BankAccount)
+	})
+
+	t.Run("Find the oldest live receiver by identifier", func(t *testing.T) {
+		repo := newRepository()
+		first := createReceivers(t, repo, "First")[0]
+		second := createReceivers(t, repo, "Second")[0]
+
+		result, err := repo.FindByIdentifier(ctx, first.Identifier)
+		assert.NoError(t, err)
+		assert.Equal(t, first, result)
+
+		err = repo.Delete(ctx, []string{first.ID})
+		assert.NoError(t, err)
+		result, err = repo.FindByIdentifier(ctx, first.Identifier)
+		assert.NoError(t, err)
+		assert.Equal(t, second, result)
+
+		_, err = repo.FindByIdentifier(ctx, "52998224725")
+		assert.Equal(t, mongo.ErrNoDocuments, err)
+	})
+
+	t.Run("Store canonical and formatted identifier and pix key", func(t *testing.T) {
+		repo := newRepository()
+		created, err := repo.Create(ctx, entity.Receiver{
+			Identifier:          "52998224725",
+			FormattedIdentifier: "529.982.247-25",
+			Name:                "Receiver",
+			Email:               "receiver@gmail.com",
+			Pix: entity.Pix{
+				KeyType:      entity.Phone,
+				Key:          "+5548991000001",
+				FormattedKey: "+55 (48) 99100-0001",
+			},
+			Status: entity.Draft,
+		})
+		assert.NoError(t, err)
+
+		err = repo.Update(ctx, created.ID, created.Version, map[string]string{
+			"identifier":           "11222333000181",
+			"formatted_identifier": "11.222.333/0001-81",
+		})
+		assert.NoError(t, err)
+
+		result, err := repo.FindById(ctx, created.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "11222333000181", result.Identifier)
+		assert.Equal(t, "11.222.333/0001-81", result.FormattedIdentifier)
+		assert.Equal(t, entity.Pix{KeyType: entity.Phone, Key: "+5548991000001", FormattedKey: "+55 (48) 99100-0001"}, result.Pix)
+
+		count, err := repo.Count(ctx, map[string]string{"key": "+5548991000001"})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("List receivers with filters and search", func(t *testing.T) {
+		repo := newRepository()
+		created := createReceivers(t, repo, "João da Silva LTDA", "Maria", "Joana")
+		err := repo.Update(ctx, created[2].ID, created[2].Version, map[string]string{"name": "Ana"})
+		assert.NoError(t, err)
+
+		page := entity.PageRequest{Limit: 10, Sort: entity.DefaultSort}
+		result, err := repo.List(ctx, map[string]string{"search": "joao silv"}, page)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"João da Silva LTDA"}, names(result.Receivers))
+
+		result, err = repo.List(ctx, map[string]string{"search": "jo"}, page)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"João da Silva LTDA"}, names(result.Receivers))
+
+		result, err = repo.List(ctx, map[string]string{"name": "Maria", "status": "Draft"}, page)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Maria"}, names(result.Receivers))
+
+		count, err := repo.Count(ctx, map[string]string{"key_type": "EMAIL"})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+	})
+
+	t.Run("Deleted receivers are hidden from queries", func(t *testing.T) {
+		repo := newRepository()
+		created := createReceivers(t, repo, "Receiver 1", "Receiver 2")
+
+		err := repo.Delete(ctx, []string{created[0].ID})
+		assert.NoError(t, err)
+
+		_, err = repo.FindById(ctx, created[0].ID)
+		assert.Equal(t, mongo.ErrNoDocuments, err)
+
+		count, err := repo.Count(ctx, map[string]string{})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		found, err := repo.FindByIds(ctx, []string{created[0].ID, created[1].ID, "63f8c8d6c6ce914b5b00b88e"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Receiver 2"}, names(found))
+	})
+
+	t.Run("Paginate forward and backward ordered by name", func(t *testing.T) {
+		repo := newRepository()
+		createReceivers(t, repo, "C", "A", "E", "B", "D")
+		sort := entity.Sort{Field: entity.SortByName, Direction: entity.Ascending}
+
+		first, err := repo.List(ctx, map[string]string{}, entity.PageRequest{Limit: 2, Sort: sort})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"A", "B"}, names(first.Receivers))
+		assert.True(t, first.HasNextPage)
+		assert.False(t, first.HasPreviousPage)
+
+		after := first.Receivers[1].CursorFor(sort)
+		second, err := repo.List(ctx, map[string]string{}, entity.PageRequest{Limit: 2, After: &after, Sort: sort})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"C", "D"}, names(second.Receivers))
+		assert.True(t, second.HasNextPage)
+		assert.True(t, second.HasPreviousPage)
+
+		before := second.Receivers[0].CursorFor(sort)
+		previous, err := repo.List(ctx, map[string]string{}, entity.PageRequest{Limit: 1, Before: &before, Backward: true, Sort: sort})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"B"}, names(previous.Receivers))
+		assert.True(t, previous.HasNextPage)
+		assert.True(t, previous.HasPreviousPage)
+	})
+
+	t.Run("Order by updated at puts receivers never updated first", func(t *testing.T) {
+		repo := newRepository()
+		created := createReceivers(t, repo, "A", "B", "C")
+		err := repo.Update(ctx, created[0].ID, created[0].Version, map[string]string{"email": "NEW@GMAIL.COM"})
+		assert.NoError(t, err)
+		sort := entity.Sort{Field: entity.SortByUpdatedAt, Direction: entity.Descending}
+
+		result, err := repo.List(ctx, map[string]string{}, entity.PageRequest{Limit: 2, Sort: sort})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"A", "C"}, names(result.Receivers))
+
+		after := result.Receivers[1].CursorFor(sort)
+		result, err = repo.List(ctx, map[string]string{}, entity.PageRequest{Limit: 2, After: &after, Sort: sort})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"B"}, names(result.Receivers))
+		assert.False(t, result.HasNextPage)
+	})
+
+	t.Run("Concurrent writes and reads are safe", func(t *testing.T) {
+		repo := newRepository()
+		created := createReceivers(t, repo, "Receiver")
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(3)
+			go func() {
+				defer wg.Done()
+				createReceivers(t, repo, "Concurrent")
+			}()
+			go func(i int) {
+				defer wg.Done()
+				err := repo.Update(ctx, created[0].ID, created[0].Version, map[string]string{"name": fmt.Sprint(i)})
+				if _, conflict := err.(*repository.VersionConflictError); !conflict {
+					assert.NoError(t, err)
+				}
+			}(i)
+			go func() {
+				defer wg.Done()
+				_, err := repo.List(ctx, map[string]string{}, entity.PageRequest{Limit: 5, Sort: entity.DefaultSort})
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		count, err := repo.Count(ctx, map[string]string{"name": "Concurrent"})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(50), count)
+
+		// All updates were made at the same version, so only one applies.
+		updated, err := repo.FindById(ctx, created[0].ID)
+		assert.NoError(t, err)
+		assert.Equal(t, created[0].Version+1, updated.Version)
+	})
+
+	t.Run("Update receiver status", func(t *testing.T) {
+		repo := newRepository()
+		created := createReceivers(t, repo, "Receiver")[0]
+
+		err := repo.Update(ctx, created.ID, created.Version, map[string]string{"status": string(entity.Validated)})
+		assert.NoError(t, err)
+
+		result, err := repo.FindById(ctx, created.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.Validated, result.Status)
+		assert.Equal(t, created.Version+1, result.Version)
+		count, err := repo.Count(ctx, map[string]string{"status": string(entity.Validated)})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Store and replace the bank account", func(t *testing.T) {
+		repo := newRepository()
+		created, err := repo.Create(ctx, entity.Receiver{
+			Name: "Receiver",
+			BankAccount: &entity.BankAccount{
+				BankCode:     "001",
+				ISPB:         "00000000",
+				Agency:       "0814",
+				AgencyDigit:  "X",
+				Account:      "12345",
+				AccountDigit: "6",
+				AccountType:  entity.Checking,
+				Verified:     true,
+			},
+			Status: entity.Draft,
+		})
+		assert.NoError(t, err)
+
+		result, err := repo.FindById(ctx, created.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, created.BankAccount, result.BankAccount)
+
+		err = repo.Update(ctx, created.ID, created.Version, map[string]string{
+			"bank_code":     "341",
+			"ispb":          "60701190",
+			"agency":        "1234",
+			"account":       "98765",
+			"account_digit": "4",
+			"account_type":  string(entity.Savings),
+		})
+		assert.NoError(t, err)
+
+		result, err = repo.FindById(ctx, created.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.BankAccount{
+			BankCode:     "341",
+			ISPB:         "60701190",
+			Agency:       "1234",
+			Account:      "98765",
+			AccountDigit: "4",
+			AccountType:  entity.Savings,
+		}, result.BankAccount)
+	})
+
+	t.Run("Reuse the pix key of a deleted receiver", func(t *testing.T) {
+		repo := newRepository()
+		deleted := createReceivers(t, repo, "Deleted")[0]
+		err := repo.Delete(ctx, []string{deleted.ID})
+		assert.NoError(t, err)
+
+		created, err := repo.Create(ctx, entity.Receiver{Name: "Receiver", Pix: deleted.Pix, Status: entity.Draft})
+		assert.NoError(t, err)
+
+		other := createReceivers(t, repo, "Other")[0]
+		err = repo.Update(ctx, other.ID, other.Version, map[string]string{"key": "reused@gmail.com"})
+		assert.NoError(t, err)
+		err = repo.Delete(ctx, []string{other.ID})
+		assert.NoError(t, err)
+		err = repo.Update(ctx, created.ID, created.Version, map[string]string{"key": "reused@gmail.com"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Update at an outdated version returns conflict error", func(t *testing.T) {
+		repo := newRepository()
+		created := createReceivers(t, repo, "Receiver")[0]
+		err := repo.Update(ctx, created.ID, created.Version, map[string]string{"name": "First"})
+		assert.NoError(t, err)
+
+		err = repo.Update(ctx, created.ID, created.Version, map[string]string{"name": "Second"})
+
+		assert.Equal(t, &repository.VersionConflictError{ID: created.ID, ExpectedVersion: created.Version}, err)
+		result, err := repo.FindById(ctx, created.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "First", result.Name)
+		assert.Equal(t, created.Version+1, result.Version)
+	})
+}
+
+func testReceiverRepositoryError(t *testing.T, repo repository.ReceiverRepository) {
+	ctx := context.Background()
+
+	t.Run("Update receiver that does not exist returns error", func(t *testing.T) {
+		err := repo.Update(ctx, "63f8c8d6c6ce914b5b00b88e", 1, map[string]string{"name": "Receiver"})
+
+		assert.Equal(t, repository.ErrRecordNotFound, err)
+	})
+
+	t.Run("Delete receivers that do not exist returns error", func(t *testing.T) {
+		err := repo.Delete(ctx, []string{"63f8c8d6c6ce914b5b00b88e"})
+
+		assert.Equal(t, repository.ErrRecordNotFound, err)
+	})
+
+	t.Run("Find receiver with invalid id returns error", func(t *testing.T) {
+		_, err := repo.FindById(ctx, "invalid")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Create receiver with a pix key in use returns duplicate error", func(t *testing.T) {
+		existing := createReceivers(t, repo, "Existing")[0]
+
+		_, err := repo.Create(ctx, entity.Receiver{Name: "Receiver", Pix: existing.Pix, Status: entity.Draft})
+
+		assert.Equal(t, &repository.DuplicatePixKeyError{Key: existing.Pix.Key, ReceiverID: existing.ID}, err)
+	})
+
+	t.Run("Update receiver to a pix key in use returns duplicate error", func(t *testing.T) {
+		created := createReceivers(t, repo, "Existing", "Receiver")
+
+		err := repo.Update(ctx, created[1].ID, created[1].Version, map[string]string{"key": created[0].Pix.Key})
+
+		assert.Equal(t, &repository.DuplicatePixKeyError{Key: created[0].Pix.Key, ReceiverID: created[0].ID}, err)
+		result, err := repo.FindById(ctx, created[1].ID)
+		assert.NoError(t, err)
+		assert.Equal(t, created[1].Pix.Key, result.Pix.Key)
+	})
+
+	t.Run("Update receiver keeping its own pix key succeeds", func(t *testing.T) {
+		created := createReceivers(t, repo, "Receiver")[0]
+
+		err := repo.Update(ctx, created.ID, created.Version, map[string]string{"key": created.Pix.Key})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Operations with expired context return timeout error", func(t *testing.T) {
+		expired, cancel := context.WithTimeout(ctx, 0)
+		defer cancel()
+
+		_, err := repo.Count(expired, map[string]string{})
+
+		assert.Equal(t, repository.ErrTimeout, err)
+	})
+}
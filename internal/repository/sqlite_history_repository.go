@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type sqliteHistoryRepository struct {
+	db       *sql.DB
+	timeouts Timeouts
+}
+
+// NewSQLiteHistoryRepository stores history entries in the receiver_history
+// table, with the field changes encoded as JSON.
+func NewSQLiteHistoryRepository(db *sql.DB, timeouts Timeouts) HistoryRepository {
+	return &sqliteHistoryRepository{
+		db:       db,
+		timeouts: timeouts,
+	}
+}
+
+func (r *sqliteHistoryRepository) Append(ctx context.Context, entries ...entity.HistoryEntry) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Create)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return translateContextError(ctx, err)
+	}
+	defer tx.Rollback()
+
+	for _, entry := range entries {
+		entry.CreatedAt = now()
+		document := model.NewHistoryEntry(entry)
+
+		changes, err := json.Marshal(document.Changes)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO receiver_history (id, receiver_id, actor, operation, changes, reason, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			document.ID.Hex(), document.ReceiverID, document.Actor, document.Operation, string(changes), document.Reason, document.CreatedAt.UnixMilli(),
+		)
+		if err != nil {
+			return translateContextError(ctx, err)
+		}
+	}
+
+	return translateContextError(ctx, tx.Commit())
+}
+
+func (r *sqliteHistoryRepository) List(ctx context.Context, receiverID string, limit int, after *string) (*entity.HistoryPage, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	query := `SELECT id, receiver_id, actor, operation, changes, reason, created_at FROM receiver_history WHERE receiver_id = ?`
+	args := []interface{}{receiverID}
+	if after != nil {
+		afterID, err := primitive.ObjectIDFromHex(*after)
+		if err != nil {
+			return nil, err
+		}
+		query += ` AND id > ?`
+		args = append(args, afterID.Hex())
+	}
+	query += ` ORDER BY id LIMIT ?`
+	args = append(args, limit+1)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, translateContextError(ctx, err)
+	}
+	defer rows.Close()
+
+	entries := []entity.HistoryEntry{}
+	for rows.Next() {
+		var (
+			entry     model.HistoryEntry
+			id        string
+			changes   string
+			createdAt int64
+		)
+		if err := rows.Scan(&id, &entry.ReceiverID, &entry.Actor, &entry.Operation, &changes, &entry.Reason, &createdAt); err != nil {
+			return nil, err
+		}
+		if entry.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(changes), &entry.Changes); err != nil {
+			return nil, err
+		}
+		entry.CreatedAt = time.UnixMilli(createdAt)
+		entries = append(entries, entry.ToEntity())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, translateContextError(ctx, err)
+	}
+
+	return historyPage(entries, limit), nil
+}
@@ -0,0 +1,395 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/teste-transfeera/internal/model"
+	"github.com/teste-transfeera/pkg/validation"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	_ "modernc.org/sqlite"
+)
+
+type sqliteMigration struct {
+	version    int
+	statements []string
+	// backfill converts existing rows after the statements ran, for
+	// changes SQL alone cannot express.
+	backfill func(ctx context.Context, tx *sql.Tx) error
+}
+
+// sqliteMigrations is the versioned schema of the SQLite backend. Migrations
+// are applied in order and never edited once released: schema changes go in
+// a new entry.
+var sqliteMigrations = []sqliteMigration{
+	{
+		version: 1,
+		statements: []string{
+			`CREATE TABLE receivers (
+				id           TEXT PRIMARY KEY,
+				identifier   TEXT NOT NULL,
+				name         TEXT NOT NULL,
+				email        TEXT NOT NULL,
+				pix_key_type TEXT NOT NULL,
+				pix_key      TEXT NOT NULL,
+				bank         TEXT,
+				agency       TEXT,
+				account      TEXT,
+				status       TEXT NOT NULL,
+				created_at   INTEGER NOT NULL,
+				updated_at   INTEGER,
+				deleted_at   INTEGER
+			)`,
+			`CREATE INDEX receivers_status ON receivers (status) WHERE deleted_at IS NULL`,
+			`CREATE INDEX receivers_name ON receivers (name) WHERE deleted_at IS NULL`,
+			`CREATE INDEX receivers_pix_key ON receivers (pix_key) WHERE deleted_at IS NULL`,
+			`CREATE TABLE receiver_search_terms (
+				receiver_id TEXT NOT NULL REFERENCES receivers (id),
+				term        TEXT NOT NULL,
+				PRIMARY KEY (receiver_id, term)
+			)`,
+			`CREATE INDEX receiver_search_terms_term ON receiver_search_terms (term)`,
+		},
+	},
+	{
+		version: 2,
+		statements: []string{
+			`ALTER TABLE receivers ADD COLUMN version INTEGER NOT NULL DEFAULT 0`,
+		},
+	},
+	{
+		version: 3,
+		statements: []string{
+			`CREATE TABLE receiver_history (
+				id          TEXT PRIMARY KEY,
+				receiver_id TEXT NOT NULL,
+				actor       TEXT NOT NULL,
+				operation   TEXT NOT NULL,
+				changes     TEXT NOT NULL,
+				created_at  INTEGER NOT NULL
+			)`,
+			`CREATE INDEX receiver_history_receiver_id ON receiver_history (receiver_id, id)`,
+		},
+	},
+	{
+		version: 4,
+		statements: []string{
+			`ALTER TABLE receivers ADD COLUMN formatted_identifier TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE receivers ADD COLUMN pix_formatted_key TEXT NOT NULL DEFAULT ''`,
+		},
+	},
+	{
+		version: 5,
+		statements: []string{
+			`DROP INDEX receivers_pix_key`,
+			`CREATE UNIQUE INDEX receivers_pix_key ON receivers (pix_key) WHERE deleted_at IS NULL`,
+		},
+	},
+	{
+		version: 6,
+		statements: []string{
+			`CREATE INDEX receivers_identifier ON receivers (identifier) WHERE deleted_at IS NULL`,
+		},
+	},
+	{
+		version: 7,
+		statements: []string{
+			`ALTER TABLE receiver_history ADD COLUMN reason TEXT NOT NULL DEFAULT ''`,
+		},
+	},
+	{
+		version: 8,
+		statements: []string{
+			`ALTER TABLE receivers ADD COLUMN agency_digit TEXT`,
+			`ALTER TABLE receivers ADD COLUMN account_digit TEXT`,
+			`ALTER TABLE receivers ADD COLUMN account_type TEXT`,
+		},
+	},
+	{
+		version: 9,
+		statements: []string{
+			`ALTER TABLE receivers ADD COLUMN ispb TEXT`,
+		},
+	},
+	{
+		version: 10,
+		statements: []string{
+			`ALTER TABLE receivers ADD COLUMN verified INTEGER`,
+		},
+	},
+	{
+		version: 11,
+		statements: []string{
+			`CREATE TABLE transfers (
+				id           TEXT PRIMARY KEY,
+				receiver_id  TEXT NOT NULL,
+				amount       INTEGER NOT NULL,
+				method       TEXT NOT NULL,
+				status       TEXT NOT NULL,
+				description  TEXT NOT NULL,
+				pix_key      TEXT NOT NULL,
+				bank_account TEXT,
+				created_at   INTEGER NOT NULL,
+				updated_at   INTEGER
+			)`,
+			`CREATE INDEX transfers_receiver_id ON transfers (receiver_id, id)`,
+			`CREATE INDEX transfers_status ON transfers (status, id)`,
+		},
+	},
+	{
+		version:  12,
+		backfill: backfillSQLiteBankAccounts,
+	},
+	{
+		version:  13,
+		backfill: backfillSQLiteCanonicalValues,
+	},
+	{
+		version: 14,
+		statements: []string{
+			`ALTER TABLE transfers ADD COLUMN idempotency_key TEXT`,
+			`CREATE UNIQUE INDEX transfers_idempotency_key ON transfers (idempotency_key) WHERE idempotency_key IS NOT NULL`,
+		},
+	},
+	{
+		version:  15,
+		backfill: backfillSQLiteSearchTerms,
+	},
+}
+
+// OpenSQLite opens the database file at path. SQLite allows a single writer,
+// so the pool is kept to one connection.
+func OpenSQLite(path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+
+	return db, nil
+}
+
+// MigrateSQLite applies the migrations the database has not seen yet,
+// recording each version in schema_migrations.
+func MigrateSQLite(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		applied_at INTEGER NOT NULL
+	)`)
+	if err != nil {
+		return err
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT version FROM schema_migrations`)
+	if err != nil {
+		return err
+	}
+	applied := map[int]bool{}
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			rows.Close()
+			return err
+		}
+		applied[version] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, migration := range sqliteMigrations {
+		if applied[migration.version] {
+			continue
+		}
+		if err := applySQLiteMigration(ctx, db, migration); err != nil {
+			return fmt.Errorf("migration %d: %w", migration.version, err)
+		}
+	}
+
+	return nil
+}
+
+func applySQLiteMigration(ctx context.Context, db *sql.DB, migration sqliteMigration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, statement := range migration.statements {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	if migration.backfill != nil {
+		if err := migration.backfill(ctx, tx); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`, migration.version, time.Now().UnixMilli())
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// backfillSQLiteBankAccounts converts the free text bank, agency and account
+// of rows stored before the structured columns, which have no account type.
+// Rows whose bank name matches no institution are left as they are and keep
+// being read as free text.
+func backfillSQLiteBankAccounts(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx,
+		`SELECT id, COALESCE(bank, ''), COALESCE(agency, ''), COALESCE(account, '') FROM receivers WHERE account_type IS NULL`)
+	if err != nil {
+		return err
+	}
+
+	type legacyRow struct{ id, bank, agency, account string }
+	var legacy []legacyRow
+	for rows.Next() {
+		var row legacyRow
+		if err := rows.Scan(&row.id, &row.bank, &row.agency, &row.account); err != nil {
+			rows.Close()
+			return err
+		}
+		legacy = append(legacy, row)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, row := range legacy {
+		account := model.LegacyBankAccount(row.bank, row.agency, row.account)
+		if account == nil || account.BankCode == "" {
+			continue
+		}
+		account.Verify()
+
+		_, err := tx.ExecContext(ctx,
+			`UPDATE receivers SET bank = ?, ispb = ?, agency = ?, agency_digit = ?, account = ?, account_digit = ?, account_type = ?, verified = ? WHERE id = ?`,
+			account.BankCode, account.ISPB, account.Agency, account.AgencyDigit, account.Account, account.AccountDigit, account.AccountType, account.Verified, row.id,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// backfillSQLiteCanonicalValues rewrites the identifier, email and pix key of
+// rows stored before canonical values, which have no formatted identifier or
+// key, as BackfillCanonicalValues does for MongoDB. A live row whose
+// canonical key another live row already holds keeps its values.
+func backfillSQLiteCanonicalValues(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx,
+		`SELECT id, identifier, name, email, pix_key_type, pix_key, deleted_at IS NOT NULL FROM receivers WHERE formatted_identifier = '' OR pix_formatted_key = ''`)
+	if err != nil {
+		return err
+	}
+
+	type legacyRow struct {
+		receiver model.Receiver
+		id       string
+		deleted  bool
+	}
+	var legacy []legacyRow
+	for rows.Next() {
+		var row legacyRow
+		err := rows.Scan(&row.id, &row.receiver.Identifier, &row.receiver.Name, &row.receiver.Email,
+			&row.receiver.Pix.KeyType, &row.receiver.Pix.Key, &row.deleted)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		legacy = append(legacy, row)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, row := range legacy {
+		receiver := row.receiver
+		if receiver.ID, err = primitive.ObjectIDFromHex(row.id); err != nil {
+			return err
+		}
+
+		key := validation.NormalizePixKey(receiver.Pix.Key, receiver.Pix.KeyType)
+		if !row.deleted {
+			var duplicate *DuplicatePixKeyError
+			err := sqliteDuplicatePixKey(ctx, tx, row.id, key)
+			if errors.As(err, &duplicate) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		receiver.FormattedIdentifier = validation.FormatIdentifier(receiver.Identifier)
+		receiver.Identifier = validation.NormalizeIdentifier(receiver.Identifier)
+		receiver.Email = validation.NormalizeEmail(receiver.Email)
+		receiver.Pix.FormattedKey = validation.FormatPixKey(receiver.Pix.Key, receiver.Pix.KeyType)
+		receiver.Pix.Key = key
+		receiver.RefreshSearch()
+
+		_, err := tx.ExecContext(ctx,
+			`UPDATE receivers SET identifier = ?, formatted_identifier = ?, email = ?, pix_key = ?, pix_formatted_key = ? WHERE id = ?`,
+			receiver.Identifier, receiver.FormattedIdentifier, receiver.Email, receiver.Pix.Key, receiver.Pix.FormattedKey, row.id,
+		)
+		if err != nil {
+			return err
+		}
+		if err := replaceSearchTerms(ctx, tx, &receiver); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// backfillSQLiteSearchTerms rebuilds the search terms of every row, which
+// earlier versions stored as every suffix of every word, as
+// BackfillSearchTerms does for MongoDB.
+func backfillSQLiteSearchTerms(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, `SELECT id, identifier, name, email, pix_key FROM receivers`)
+	if err != nil {
+		return err
+	}
+
+	var receivers []model.Receiver
+	for rows.Next() {
+		var id string
+		var receiver model.Receiver
+		if err := rows.Scan(&id, &receiver.Identifier, &receiver.Name, &receiver.Email, &receiver.Pix.Key); err != nil {
+			rows.Close()
+			return err
+		}
+		if receiver.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+			rows.Close()
+			return err
+		}
+		receivers = append(receivers, receiver)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for i := range receivers {
+		receivers[i].RefreshSearch()
+		if err := replaceSearchTerms(ctx, tx, &receivers[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package repository_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/repository"
+)
+
+func Test_SQLiteMigrations_LegacyBankAccount(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := repository.OpenSQLite(filepath.Join(t.TempDir(), "transfeera.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := repository.MigrateSQLite(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+
+	insertLegacy := func(id, bank, agency, account string) {
+		_, err := db.ExecContext(ctx,
+			`INSERT INTO receivers (id, identifier, name, email, pix_key_type, pix_key, bank, agency, account, status, created_at, version)
+			VALUES (?, '52998224725', 'Receiver 1', 'receiver1@gmail.com', 'EMAIL', ?, ?, ?, ?, 'Draft', 0, 1)`,
+			id, id+"@gmail.com", bank, agency, account,
+		)
+		assert.NoError(t, err)
+	}
+	insertLegacy("63f8c8d6c6ce914b5b00b88e", "Bradesco", "0814-1", "01002713-6")
+	insertLegacy("63f8c8d6c6ce914b5b00b88f", "Banco Imaginario", "1234", "5678")
+	insertLegacy("63f8c8d6c6ce914b5b00b890", "Bradesco", "12345-1", "1234567-0")
+
+	bradesco := &entity.BankAccount{
+		BankCode:     "237",
+		ISPB:         "60746948",
+		Agency:       "0814",
+		AgencyDigit:  "1",
+		Account:      "01002713",
+		AccountDigit: "6",
+		AccountType:  entity.Checking,
+		Verified:     true,
+	}
+	unknown := &entity.BankAccount{
+		Agency:       "1234",
+		Account:      "567",
+		AccountDigit: "8",
+		AccountType:  entity.Checking,
+	}
+	repo := repository.NewSQLiteReceiverRepository(db, repository.DefaultTimeouts())
+
+	t.Run("Read legacy bank fields before the backfill without verifying them", func(t *testing.T) {
+		found, err := repo.FindById(ctx, "63f8c8d6c6ce914b5b00b88e")
+
+		unverified := *bradesco
+		unverified.Verified = false
+		assert.NoError(t, err)
+		assert.Equal(t, &unverified, found.BankAccount)
+	})
+
+	t.Run("Backfill converts legacy bank fields and keeps unknown banks readable", func(t *testing.T) {
+		_, err := db.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = 12`)
+		assert.NoError(t, err)
+		assert.NoError(t, repository.MigrateSQLite(ctx, db))
+
+		var bank, accountType string
+		err = db.QueryRowContext(ctx, `SELECT bank, account_type FROM receivers WHERE id = ?`, "63f8c8d6c6ce914b5b00b88e").Scan(&bank, &accountType)
+		assert.NoError(t, err)
+		assert.Equal(t, "237", bank)
+		assert.Equal(t, "CHECKING", accountType)
+
+		found, err := repo.FindById(ctx, "63f8c8d6c6ce914b5b00b88e")
+		assert.NoError(t, err)
+		assert.Equal(t, bradesco, found.BankAccount)
+
+		found, err = repo.FindById(ctx, "63f8c8d6c6ce914b5b00b88f")
+		assert.NoError(t, err)
+		assert.Equal(t, unknown, found.BankAccount)
+	})
+
+	t.Run("Backfill stores malformed legacy accounts as not verified", func(t *testing.T) {
+		found, err := repo.FindById(ctx, "63f8c8d6c6ce914b5b00b890")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "237", found.BankAccount.BankCode)
+		assert.Equal(t, "12345", found.BankAccount.Agency)
+		assert.False(t, found.BankAccount.Verified)
+	})
+}
+
+func Test_SQLiteMigrations_CanonicalValues(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := repository.OpenSQLite(filepath.Join(t.TempDir(), "transfeera.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := repository.MigrateSQLite(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+
+	insertLegacy := func(id, identifier, keyType, key string) {
+		_, err := db.ExecContext(ctx,
+			`INSERT INTO receivers (id, identifier, name, email, pix_key_type, pix_key, status, created_at, version)
+			VALUES (?, ?, 'Receiver 1', 'Receiver1@Gmail.com', ?, ?, 'Draft', 0, 1)`,
+			id, identifier, keyType, key,
+		)
+		assert.NoError(t, err)
+	}
+	insertLegacy("63f8c8d6c6ce914b5b00b88e", "529.982.247-25", "TELEFONE", "48991000001")
+	insertLegacy("63f8c8d6c6ce914b5b00b88f", "29055159026", "TELEFONE", "+5548991000001")
+
+	_, err = db.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = 13`)
+	assert.NoError(t, err)
+	assert.NoError(t, repository.MigrateSQLite(ctx, db))
+
+	repo := repository.NewSQLiteReceiverRepository(db, repository.DefaultTimeouts())
+
+	t.Run("Rewrites legacy values in canonical form", func(t *testing.T) {
+		found, err := repo.FindById(ctx, "63f8c8d6c6ce914b5b00b88f")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "29055159026", found.Identifier)
+		assert.Equal(t, "290.551.590-26", found.FormattedIdentifier)
+		assert.Equal(t, "receiver1@gmail.com", found.Email)
+		assert.Equal(t, "+5548991000001", found.Pix.Key)
+		assert.Equal(t, "+55 (48) 99100-0001", found.Pix.FormattedKey)
+	})
+
+	t.Run("Keeps legacy values when another receiver holds the canonical pix key", func(t *testing.T) {
+		found, err := repo.FindById(ctx, "63f8c8d6c6ce914b5b00b88e")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "529.982.247-25", found.Identifier)
+		assert.Equal(t, "48991000001", found.Pix.Key)
+	})
+}
+
+func Test_SQLiteMigrations_SearchTerms(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := repository.OpenSQLite(filepath.Join(t.TempDir(), "transfeera.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := repository.MigrateSQLite(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = db.ExecContext(ctx,
+		`INSERT INTO receivers (id, identifier, name, email, pix_key_type, pix_key, status, created_at, version)
+		VALUES ('63f8c8d6c6ce914b5b00b88e', '29055159026', 'Ana', 'ana@gmail.com', 'EMAIL', 'ana@gmail.com', 'Draft', 0, 1)`,
+	)
+	assert.NoError(t, err)
+	for _, term := range []string{"ana", "na", "a", "gmail", "mail", "com", "om", "m"} {
+		_, err := db.ExecContext(ctx, `INSERT INTO receiver_search_terms (receiver_id, term) VALUES ('63f8c8d6c6ce914b5b00b88e', ?)`, term)
+		assert.NoError(t, err)
+	}
+
+	_, err = db.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = 15`)
+	assert.NoError(t, err)
+	assert.NoError(t, repository.MigrateSQLite(ctx, db))
+
+	t.Run("Rebuilds legacy suffix terms as words", func(t *testing.T) {
+		rows, err := db.QueryContext(ctx, `SELECT term FROM receiver_search_terms WHERE receiver_id = '63f8c8d6c6ce914b5b00b88e'`)
+		assert.NoError(t, err)
+		defer rows.Close()
+
+		var terms []string
+		for rows.Next() {
+			var term string
+			assert.NoError(t, rows.Scan(&term))
+			terms = append(terms, term)
+		}
+
+		assert.ElementsMatch(t, []string{"ana", "gmail", "com", "anagmailcom", "29055159026"}, terms)
+	})
+}
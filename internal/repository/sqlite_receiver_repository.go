@@ -0,0 +1,570 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/model"
+	"github.com/teste-transfeera/pkg/search"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const sqliteReceiverColumns = `id, identifier, name, email, pix_key_type, pix_key, bank, agency, account, status, created_at, updated_at, deleted_at, version, formatted_identifier, pix_formatted_key, agency_digit, account_digit, account_type, ispb, verified`
+
+var sqliteSortColumns = map[entity.SortField]string{
+	entity.SortByName:      "name",
+	entity.SortByCreatedAt: "created_at",
+	entity.SortByUpdatedAt: "updated_at",
+	entity.SortByStatus:    "status",
+}
+
+type sqliteReceiverRepository struct {
+	db       *sql.DB
+	timeouts Timeouts
+}
+
+// NewSQLiteReceiverRepository stores receivers in an SQLite database already
+// brought up to date with MigrateSQLite. Ids are ObjectID hex strings, so they
+// sort by creation like the Mongo ones.
+func NewSQLiteReceiverRepository(db *sql.DB, timeouts Timeouts) ReceiverRepository {
+	return &sqliteReceiverRepository{
+		db:       db,
+		timeouts: timeouts,
+	}
+}
+
+func (r *sqliteReceiverRepository) Create(ctx context.Context, receiver entity.Receiver) (*entity.Receiver, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Create)
+	defer cancel()
+
+	model := model.Receiver{
+		ID:                  primitive.NewObjectID(),
+		Identifier:          receiver.Identifier,
+		FormattedIdentifier: receiver.FormattedIdentifier,
+		Name:                receiver.Name,
+		Email:               receiver.Email,
+		Pix: model.Pix{
+			KeyType:      string(receiver.Pix.KeyType),
+			Key:          receiver.Pix.Key,
+			FormattedKey: receiver.Pix.FormattedKey,
+		},
+		BankAccount: model.NewBankAccount(receiver.BankAccount),
+		Status:      string(receiver.Status),
+		CreatedAt:   now(),
+		Version:     1,
+	}
+	model.RefreshSearch()
+
+	bank := newSQLiteBankAccount(model.BankAccount)
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		if err := sqliteDuplicatePixKey(ctx, tx, model.ID.Hex(), model.Pix.Key); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO receivers (`+sqliteReceiverColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			model.ID.Hex(), model.Identifier, model.Name, model.Email, model.Pix.KeyType, model.Pix.Key,
+			bank.bankCode, bank.agency, bank.account, model.Status,
+			model.CreatedAt.UnixMilli(), nil, nil, model.Version, model.FormattedIdentifier, model.Pix.FormattedKey,
+			bank.agencyDigit, bank.accountDigit, bank.accountType, bank.ispb, bank.verified,
+		)
+		if err != nil {
+			return err
+		}
+		return replaceSearchTerms(ctx, tx, &model)
+	})
+	if err != nil {
+		return nil, translateContextError(ctx, err)
+	}
+
+	entity := model.ToEntity()
+	return &entity, nil
+}
+
+func (r *sqliteReceiverRepository) List(ctx context.Context, filter map[string]string, page entity.PageRequest) (*entity.ReceiverPage, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	where, args := buildSQLiteFilter(filter)
+
+	if page.After != nil {
+		condition, conditionArgs, err := buildSQLiteKeysetCondition(page.Sort, *page.After, true)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, condition)
+		args = append(args, conditionArgs...)
+	}
+	if page.Before != nil {
+		condition, conditionArgs, err := buildSQLiteKeysetCondition(page.Sort, *page.Before, false)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, condition)
+		args = append(args, conditionArgs...)
+	}
+
+	query := `SELECT ` + sqliteReceiverColumns + ` FROM receivers r WHERE ` + strings.Join(where, " AND ") +
+		` ORDER BY ` + buildSQLiteSort(page.Sort, page.Backward) + ` LIMIT ?`
+	args = append(args, page.Limit+1)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, translateContextError(ctx, err)
+	}
+	defer rows.Close()
+
+	receivers := []entity.Receiver{}
+	for rows.Next() {
+		receiver, err := scanSQLiteReceiver(rows)
+		if err != nil {
+			return nil, err
+		}
+		receivers = append(receivers, receiver.ToEntity())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, translateContextError(ctx, err)
+	}
+
+	hasMore := len(receivers) > page.Limit
+	if hasMore {
+		receivers = receivers[:page.Limit]
+	}
+
+	result := &entity.ReceiverPage{}
+	if page.Backward {
+		for i, j := 0, len(receivers)-1; i < j; i, j = i+1, j-1 {
+			receivers[i], receivers[j] = receivers[j], receivers[i]
+		}
+		result.HasPreviousPage = hasMore
+		result.HasNextPage, err = r.existsBeyond(ctx, filter, page.Sort, page.Before, true)
+	} else {
+		result.HasNextPage = hasMore
+		result.HasPreviousPage, err = r.existsBeyond(ctx, filter, page.Sort, page.After, false)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.Receivers = receivers
+	return result, nil
+}
+
+func (r *sqliteReceiverRepository) Count(ctx context.Context, filter map[string]string) (int64, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Count)
+	defer cancel()
+
+	where, args := buildSQLiteFilter(filter)
+
+	var count int64
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM receivers r WHERE `+strings.Join(where, " AND "), args...).Scan(&count)
+	if err != nil {
+		return 0, translateContextError(ctx, err)
+	}
+
+	return count, nil
+}
+
+func (r *sqliteReceiverRepository) existsBeyond(ctx context.Context, filter map[string]string, sort entity.Sort, cursor *entity.Cursor, forward bool) (bool, error) {
+	if cursor == nil {
+		return false, nil
+	}
+
+	condition, conditionArgs, err := buildSQLiteKeysetCondition(sort, *cursor, forward)
+	if err != nil {
+		return false, err
+	}
+
+	where, args := buildSQLiteFilter(filter)
+	where = append(where, "("+condition+" OR r.id = ?)")
+	args = append(args, conditionArgs...)
+	args = append(args, cursor.ID)
+
+	var exists bool
+	err = r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM receivers r WHERE `+strings.Join(where, " AND ")+`)`, args...).Scan(&exists)
+	if err != nil {
+		return false, translateContextError(ctx, err)
+	}
+
+	return exists, nil
+}
+
+func (r *sqliteReceiverRepository) FindById(ctx context.Context, id string) (*entity.Receiver, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.FindById)
+	defer cancel()
+
+	docID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	row := r.db.QueryRowContext(ctx, `SELECT `+sqliteReceiverColumns+` FROM receivers WHERE id = ? AND deleted_at IS NULL`, docID.Hex())
+
+	receiver, err := scanSQLiteReceiver(row)
+	if err == sql.ErrNoRows {
+		return nil, mongo.ErrNoDocuments
+	}
+	if err != nil {
+		return nil, translateContextError(ctx, err)
+	}
+
+	entity := receiver.ToEntity()
+	return &entity, nil
+}
+
+func (r *sqliteReceiverRepository) FindByIds(ctx context.Context, ids []string) ([]entity.Receiver, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.FindById)
+	defer cancel()
+
+	placeholders := make([]string, 0, len(ids))
+	args := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		docID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, err
+		}
+		placeholders = append(placeholders, "?")
+		args = append(args, docID.Hex())
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+sqliteReceiverColumns+` FROM receivers WHERE id IN (`+strings.Join(placeholders, ", ")+`) AND deleted_at IS NULL`, args...)
+	if err != nil {
+		return nil, translateContextError(ctx, err)
+	}
+	defer rows.Close()
+
+	receivers := []entity.Receiver{}
+	for rows.Next() {
+		receiver, err := scanSQLiteReceiver(rows)
+		if err != nil {
+			return nil, err
+		}
+		receivers = append(receivers, receiver.ToEntity())
+	}
+
+	return receivers, translateContextError(ctx, rows.Err())
+}
+
+func (r *sqliteReceiverRepository) FindByIdentifier(ctx context.Context, identifier string) (*entity.Receiver, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.FindById)
+	defer cancel()
+
+	row := r.db.QueryRowContext(ctx, `SELECT `+sqliteReceiverColumns+` FROM receivers WHERE identifier = ? AND deleted_at IS NULL ORDER BY id LIMIT 1`, identifier)
+
+	receiver, err := scanSQLiteReceiver(row)
+	if err == sql.ErrNoRows {
+		return nil, mongo.ErrNoDocuments
+	}
+	if err != nil {
+		return nil, translateContextError(ctx, err)
+	}
+
+	entity := receiver.ToEntity()
+	return &entity, nil
+}
+
+func (r *sqliteReceiverRepository) Update(ctx context.Context, id string, version int64, fields map[string]string) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Update)
+	defer cancel()
+
+	docID, _ := primitive.ObjectIDFromHex(id)
+
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		row := tx.QueryRowContext(ctx, `SELECT `+sqliteReceiverColumns+` FROM receivers WHERE id = ?`, docID.Hex())
+		receiver, err := scanSQLiteReceiver(row)
+		if err == sql.ErrNoRows {
+			return ErrRecordNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if receiver.Version != version {
+			return &VersionConflictError{ID: id, ExpectedVersion: version}
+		}
+
+		if err := sqliteDuplicatePixKey(ctx, tx, docID.Hex(), fields["key"]); err != nil {
+			return err
+		}
+
+		applyUpdate(receiver, fields)
+		bank := newSQLiteBankAccount(receiver.BankAccount)
+		_, err = tx.ExecContext(ctx,
+			`UPDATE receivers SET identifier = ?, formatted_identifier = ?, name = ?, email = ?, pix_key_type = ?, pix_key = ?, pix_formatted_key = ?, `+
+				`bank = ?, ispb = ?, agency = ?, agency_digit = ?, account = ?, account_digit = ?, account_type = ?, verified = ?, status = ?, updated_at = ?, version = version + 1 WHERE id = ?`,
+			receiver.Identifier, receiver.FormattedIdentifier, receiver.Name, receiver.Email, receiver.Pix.KeyType, receiver.Pix.Key, receiver.Pix.FormattedKey,
+			bank.bankCode, bank.ispb, bank.agency, bank.agencyDigit, bank.account, bank.accountDigit, bank.accountType, bank.verified,
+			receiver.Status, now().UnixMilli(), docID.Hex(),
+		)
+		if err != nil {
+			return err
+		}
+
+		if !changesSearchFields(fields) {
+			return nil
+		}
+		receiver.RefreshSearch()
+		return replaceSearchTerms(ctx, tx, receiver)
+	})
+	if err != nil {
+		return translateContextError(ctx, err)
+	}
+
+	return nil
+}
+
+func (r *sqliteReceiverRepository) Delete(ctx context.Context, ids []string) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Delete)
+	defer cancel()
+
+	placeholders := make([]string, 0, len(ids))
+	args := []interface{}{now().UnixMilli()}
+	for _, id := range ids {
+		docID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return err
+		}
+		placeholders = append(placeholders, "?")
+		args = append(args, docID.Hex())
+	}
+
+	result, err := r.db.ExecContext(ctx, `UPDATE receivers SET deleted_at = ? WHERE id IN (`+strings.Join(placeholders, ", ")+`)`, args...)
+	if err != nil {
+		return translateContextError(ctx, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *sqliteReceiverRepository) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// sqliteDuplicatePixKey fails when a live receiver other than id holds key.
+// The unique receivers_pix_key index backs the check.
+func sqliteDuplicatePixKey(ctx context.Context, tx *sql.Tx, id string, key string) error {
+	if key == "" {
+		return nil
+	}
+
+	var receiverID string
+	err := tx.QueryRowContext(ctx, `SELECT id FROM receivers WHERE pix_key = ? AND deleted_at IS NULL AND id <> ?`, key, id).Scan(&receiverID)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return &DuplicatePixKeyError{Key: key, ReceiverID: receiverID}
+}
+
+func replaceSearchTerms(ctx context.Context, tx *sql.Tx, receiver *model.Receiver) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM receiver_search_terms WHERE receiver_id = ?`, receiver.ID.Hex())
+	if err != nil {
+		return err
+	}
+
+	for _, term := range receiver.Search {
+		_, err := tx.ExecContext(ctx, `INSERT INTO receiver_search_terms (receiver_id, term) VALUES (?, ?)`, receiver.ID.Hex(), term)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type sqliteScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSQLiteReceiver(row sqliteScanner) (*model.Receiver, error) {
+	var (
+		receiver             model.Receiver
+		id                   string
+		bank                 sqliteBankAccount
+		createdAt            int64
+		updatedAt, deletedAt sql.NullInt64
+	)
+
+	err := row.Scan(
+		&id, &receiver.Identifier, &receiver.Name, &receiver.Email, &receiver.Pix.KeyType, &receiver.Pix.Key,
+		&bank.bankCode, &bank.agency, &bank.account, &receiver.Status, &createdAt, &updatedAt, &deletedAt, &receiver.Version,
+		&receiver.FormattedIdentifier, &receiver.Pix.FormattedKey, &bank.agencyDigit, &bank.accountDigit, &bank.accountType, &bank.ispb, &bank.verified,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	receiver.ID, err = primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	receiver.BankAccount = bank.toModel()
+	receiver.CreatedAt = time.UnixMilli(createdAt)
+	if updatedAt.Valid {
+		receiver.UpdatedAt = time.UnixMilli(updatedAt.Int64)
+	}
+	if deletedAt.Valid {
+		receiver.DeletedAt = time.UnixMilli(deletedAt.Int64)
+		receiver.Deleted = true
+	}
+
+	return &receiver, nil
+}
+
+// sqliteBankAccount is the bank account as stored in the receivers columns,
+// all NULL when the receiver has no bank account.
+type sqliteBankAccount struct {
+	bankCode, ispb, agency, agencyDigit, account, accountDigit, accountType sql.NullString
+	verified                                                                sql.NullBool
+}
+
+func newSQLiteBankAccount(account *model.BankAccount) sqliteBankAccount {
+	if account == nil {
+		return sqliteBankAccount{}
+	}
+
+	return sqliteBankAccount{
+		bankCode:     sql.NullString{String: account.BankCode, Valid: true},
+		ispb:         sql.NullString{String: account.ISPB, Valid: true},
+		agency:       sql.NullString{String: account.Agency, Valid: true},
+		agencyDigit:  sql.NullString{String: account.AgencyDigit, Valid: true},
+		account:      sql.NullString{String: account.Account, Valid: true},
+		accountDigit: sql.NullString{String: account.AccountDigit, Valid: true},
+		accountType:  sql.NullString{String: account.AccountType, Valid: true},
+		verified:     sql.NullBool{Bool: account.Verified, Valid: true},
+	}
+}
+
+// toModel reads rows stored before the structured columns, which have no
+// account type, as free text bank fields.
+func (b sqliteBankAccount) toModel() *model.BankAccount {
+	if !b.accountType.Valid {
+		return model.LegacyBankAccount(b.bankCode.String, b.agency.String, b.account.String)
+	}
+
+	return &model.BankAccount{
+		BankCode:     b.bankCode.String,
+		ISPB:         b.ispb.String,
+		Agency:       b.agency.String,
+		AgencyDigit:  b.agencyDigit.String,
+		Account:      b.account.String,
+		AccountDigit: b.accountDigit.String,
+		AccountType:  b.accountType.String,
+		Verified:     b.verified.Bool,
+	}
+}
+
+func buildSQLiteFilter(filter map[string]string) ([]string, []interface{}) {
+	where := []string{"r.deleted_at IS NULL"}
+	var args []interface{}
+
+	if filter["status"] != "" {
+		where = append(where, "r.status = ?")
+		args = append(args, filter["status"])
+	}
+	if filter["name"] != "" {
+		where = append(where, "r.name = ?")
+		args = append(args, filter["name"])
+	}
+	if filter["key_type"] != "" {
+		where = append(where, "r.pix_key_type = ?")
+		args = append(args, filter["key_type"])
+	}
+	if filter["key"] != "" {
+		where = append(where, "r.pix_key = ?")
+		args = append(args, filter["key"])
+	}
+	if filter["search"] != "" {
+		// Search words only hold letters and digits, so they are safe to
+		// use as GLOB prefixes, which SQLite answers from the term index.
+		for _, word := range search.Query(filter["search"]) {
+			where = append(where, "EXISTS (SELECT 1 FROM receiver_search_terms t WHERE t.receiver_id = r.id AND t.term GLOB ?)")
+			args = append(args, word+"*")
+		}
+	}
+
+	return where, args
+}
+
+func buildSQLiteSort(sort entity.Sort, backward bool) string {
+	order := "ASC"
+	if (sort.Direction == entity.Descending) != backward {
+		order = "DESC"
+	}
+
+	column, ok := sqliteSortColumns[sort.Field]
+	if !ok {
+		return "r.id " + order
+	}
+
+	return "r." + column + " " + order + ", r.id " + order
+}
+
+// buildSQLiteKeysetCondition is the SQL counterpart of buildKeysetCondition.
+// SQLite also sorts NULL before any value, so the same rules apply.
+func buildSQLiteKeysetCondition(sort entity.Sort, cursor entity.Cursor, forward bool) (string, []interface{}, error) {
+	cursorID, err := primitive.ObjectIDFromHex(cursor.ID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	operator := "<"
+	if forward != (sort.Direction == entity.Descending) {
+		operator = ">"
+	}
+
+	column, ok := sqliteSortColumns[sort.Field]
+	if !ok {
+		return "r.id " + operator + " ?", []interface{}{cursorID.Hex()}, nil
+	}
+	column = "r." + column
+
+	if cursor.Value == nil {
+		if operator == ">" {
+			return "((" + column + " IS NULL AND r.id > ?) OR " + column + " IS NOT NULL)", []interface{}{cursorID.Hex()}, nil
+		}
+		return "(" + column + " IS NULL AND r.id < ?)", []interface{}{cursorID.Hex()}, nil
+	}
+
+	value, err := sortValue(sort.Field, *cursor.Value)
+	if err != nil {
+		return "", nil, err
+	}
+	if t, ok := value.(time.Time); ok {
+		value = t.UnixMilli()
+	}
+
+	condition := "(" + column + " " + operator + " ? OR (" + column + " = ? AND r.id " + operator + " ?)"
+	if operator == "<" {
+		condition += " OR " + column + " IS NULL"
+	}
+	condition += ")"
+
+	return condition, []interface{}{value, value, cursorID.Hex()}, nil
+}
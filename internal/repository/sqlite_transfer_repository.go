@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const sqliteTransferColumns = `id, receiver_id, amount, method, status, description, pix_key, bank_account, idempotency_key, created_at, updated_at`
+
+type sqliteTransferRepository struct {
+	db       *sql.DB
+	timeouts Timeouts
+}
+
+// NewSQLiteTransferRepository stores transfers in the transfers table, with
+// the bank account of TED transfers encoded as JSON.
+func NewSQLiteTransferRepository(db *sql.DB, timeouts Timeouts) TransferRepository {
+	return &sqliteTransferRepository{
+		db:       db,
+		timeouts: timeouts,
+	}
+}
+
+func (r *sqliteTransferRepository) Create(ctx context.Context, transfer entity.Transfer) (*entity.Transfer, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Create)
+	defer cancel()
+
+	transfer.CreatedAt = now()
+	document := model.NewTransfer(transfer)
+
+	var bankAccount interface{}
+	if document.BankAccount != nil {
+		encoded, err := json.Marshal(document.BankAccount)
+		if err != nil {
+			return nil, err
+		}
+		bankAccount = string(encoded)
+	}
+
+	var idempotencyKey interface{}
+	if document.IdempotencyKey != "" {
+		idempotencyKey = document.IdempotencyKey
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO transfers (`+sqliteTransferColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		document.ID.Hex(), document.ReceiverID, document.Amount, document.Method, document.Status, document.Description,
+		document.PixKey, bankAccount, idempotencyKey, document.CreatedAt.UnixMilli(), nil,
+	)
+	if err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed: transfers.idempotency_key") {
+		return nil, ErrDuplicateIdempotencyKey
+	}
+	if err != nil {
+		return nil, translateContextError(ctx, err)
+	}
+
+	created := document.ToEntity()
+	return &created, nil
+}
+
+func (r *sqliteTransferRepository) FindById(ctx context.Context, id string) (*entity.Transfer, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.FindById)
+	defer cancel()
+
+	docID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	row := r.db.QueryRowContext(ctx, `SELECT `+sqliteTransferColumns+` FROM transfers WHERE id = ?`, docID.Hex())
+
+	transfer, err := scanSQLiteTransfer(row)
+	if err == sql.ErrNoRows {
+		return nil, mongo.ErrNoDocuments
+	}
+	if err != nil {
+		return nil, translateContextError(ctx, err)
+	}
+
+	found := transfer.ToEntity()
+	return &found, nil
+}
+
+func (r *sqliteTransferRepository) FindByIdempotencyKey(ctx context.Context, key string) (*entity.Transfer, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.FindById)
+	defer cancel()
+
+	row := r.db.QueryRowContext(ctx, `SELECT `+sqliteTransferColumns+` FROM transfers WHERE idempotency_key = ?`, key)
+
+	transfer, err := scanSQLiteTransfer(row)
+	if err == sql.ErrNoRows {
+		return nil, mongo.ErrNoDocuments
+	}
+	if err != nil {
+		return nil, translateContextError(ctx, err)
+	}
+
+	found := transfer.ToEntity()
+	return &found, nil
+}
+
+func (r *sqliteTransferRepository) List(ctx context.Context, filter TransferFilter, limit int, after *string) (*entity.TransferPage, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	query := `SELECT ` + sqliteTransferColumns + ` FROM transfers WHERE 1 = 1`
+	args := []interface{}{}
+	if filter.ReceiverID != "" {
+		query += ` AND receiver_id = ?`
+		args = append(args, filter.ReceiverID)
+	}
+	if filter.Status != "" {
+		query += ` AND status = ?`
+		args = append(args, string(filter.Status))
+	}
+	if after != nil {
+		afterID, err := primitive.ObjectIDFromHex(*after)
+		if err != nil {
+			return nil, err
+		}
+		query += ` AND id < ?`
+		args = append(args, afterID.Hex())
+	}
+	query += ` ORDER BY id DESC LIMIT ?`
+	args = append(args, limit+1)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, translateContextError(ctx, err)
+	}
+	defer rows.Close()
+
+	transfers := []entity.Transfer{}
+	for rows.Next() {
+		transfer, err := scanSQLiteTransfer(rows)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, transfer.ToEntity())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, translateContextError(ctx, err)
+	}
+
+	return transferPage(transfers, limit), nil
+}
+
+func (r *sqliteTransferRepository) UpdateStatus(ctx context.Context, id string, from entity.TransferStatus, to entity.TransferStatus) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Update)
+	defer cancel()
+
+	docID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE transfers SET status = ?, updated_at = ? WHERE id = ? AND status = ?`,
+		string(to), now().UnixMilli(), docID.Hex(), string(from),
+	)
+	if err != nil {
+		return translateContextError(ctx, err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return ErrTransferStatusChanged
+	}
+
+	return nil
+}
+
+func scanSQLiteTransfer(row sqliteScanner) (*model.Transfer, error) {
+	var (
+		transfer       model.Transfer
+		id             string
+		bankAccount    sql.NullString
+		idempotencyKey sql.NullString
+		createdAt      int64
+		updatedAt      sql.NullInt64
+	)
+	err := row.Scan(&id, &transfer.ReceiverID, &transfer.Amount, &transfer.Method, &transfer.Status, &transfer.Description,
+		&transfer.PixKey, &bankAccount, &idempotencyKey, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+		return nil, err
+	}
+	if bankAccount.Valid {
+		if err := json.Unmarshal([]byte(bankAccount.String), &transfer.BankAccount); err != nil {
+			return nil, err
+		}
+	}
+	transfer.IdempotencyKey = idempotencyKey.String
+	transfer.CreatedAt = time.UnixMilli(createdAt)
+	if updatedAt.Valid {
+		transfer.UpdatedAt = time.UnixMilli(updatedAt.Int64)
+	}
+
+	return &transfer, nil
+}
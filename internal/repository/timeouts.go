@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var ErrTimeout = errors.New("Database operation timed out")
+
+// Timeouts holds the deadline applied to each repository operation on top of
+// the caller's context. A zero duration leaves the caller's deadline alone.
+type Timeouts struct {
+	Create   time.Duration
+	List     time.Duration
+	Count    time.Duration
+	FindById time.Duration
+	Update   time.Duration
+	Delete   time.Duration
+}
+
+func DefaultTimeouts() Timeouts {
+	return Timeouts{
+		Create:   5 * time.Second,
+		List:     10 * time.Second,
+		Count:    10 * time.Second,
+		FindById: 5 * time.Second,
+		Update:   5 * time.Second,
+		Delete:   5 * time.Second,
+	}
+}
+
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+func translateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if mongo.IsTimeout(err) || errors.Is(err, context.DeadlineExceeded) {
+		return ErrTimeout
+	}
+	return err
+}
+
+// translateContextError reports ErrTimeout when the operation's own deadline
+// expired, whatever error the driver surfaced for it.
+func translateContextError(ctx context.Context, err error) error {
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return ErrTimeout
+	}
+	return translateError(err)
+}
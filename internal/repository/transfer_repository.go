@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrTransferStatusChanged is returned by UpdateStatus when the transfer is
+// no longer in the status it was read with.
+var ErrTransferStatusChanged = errors.New("Transfer status was changed by another request")
+
+// ErrDuplicateIdempotencyKey is returned by Create when another transfer was
+// already created with the idempotency key.
+var ErrDuplicateIdempotencyKey = errors.New("Idempotency key was already used by another transfer")
+
+// TransferFilter narrows List down. Empty fields do not filter.
+type TransferFilter struct {
+	ReceiverID string
+	Status     entity.TransferStatus
+}
+
+// TransferRepository stores transfers. Transfers are never removed, only
+// moved between statuses.
+type TransferRepository interface {
+	// Create stores the transfer, or returns ErrDuplicateIdempotencyKey when
+	// its idempotency key is already taken.
+	Create(ctx context.Context, transfer entity.Transfer) (*entity.Transfer, error)
+	FindById(ctx context.Context, id string) (*entity.Transfer, error)
+	FindByIdempotencyKey(ctx context.Context, key string) (*entity.Transfer, error)
+	// List returns the transfers newest first, starting after the transfer
+	// with id after when it is given.
+	List(ctx context.Context, filter TransferFilter, limit int, after *string) (*entity.TransferPage, error)
+	// UpdateStatus moves the transfer from status from to status to, or
+	// returns ErrTransferStatusChanged when it is no longer in from.
+	UpdateStatus(ctx context.Context, id string, from entity.TransferStatus, to entity.TransferStatus) error
+}
+
+type transferRepository struct {
+	collection *mongo.Collection
+	timeouts   Timeouts
+}
+
+func NewTransferRepository(collection *mongo.Collection, timeouts Timeouts) TransferRepository {
+	return &transferRepository{
+		collection: collection,
+		timeouts:   timeouts,
+	}
+}
+
+func (r *transferRepository) Create(ctx context.Context, transfer entity.Transfer) (*entity.Transfer, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Create)
+	defer cancel()
+
+	transfer.CreatedAt = now()
+	document := model.NewTransfer(transfer)
+
+	_, err := r.collection.InsertOne(ctx, &document)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, ErrDuplicateIdempotencyKey
+	}
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	created := document.ToEntity()
+	return &created, nil
+}
+
+func (r *transferRepository) FindById(ctx context.Context, id string) (*entity.Transfer, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.FindById)
+	defer cancel()
+
+	docID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var transfer model.Transfer
+	err = r.collection.FindOne(ctx, bson.M{"_id": docID}).Decode(&transfer)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	found := transfer.ToEntity()
+	return &found, nil
+}
+
+func (r *transferRepository) FindByIdempotencyKey(ctx context.Context, key string) (*entity.Transfer, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.FindById)
+	defer cancel()
+
+	var transfer model.Transfer
+	err := r.collection.FindOne(ctx, bson.M{"idempotency_key": key}).Decode(&transfer)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	found := transfer.ToEntity()
+	return &found, nil
+}
+
+func (r *transferRepository) List(ctx context.Context, filter TransferFilter, limit int, after *string) (*entity.TransferPage, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.List)
+	defer cancel()
+
+	bsonFilter := bson.M{}
+	if filter.ReceiverID != "" {
+		bsonFilter["receiver_id"] = filter.ReceiverID
+	}
+	if filter.Status != "" {
+		bsonFilter["status"] = string(filter.Status)
+	}
+	if after != nil {
+		afterID, err := primitive.ObjectIDFromHex(*after)
+		if err != nil {
+			return nil, err
+		}
+		bsonFilter["_id"] = bson.M{"$lt": afterID}
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: -1}}).
+		SetLimit(int64(limit + 1))
+
+	cursor, err := r.collection.Find(ctx, bsonFilter, findOptions)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	defer cursor.Close(ctx)
+
+	transfers := []entity.Transfer{}
+	for cursor.Next(ctx) {
+		var transfer model.Transfer
+		if err := cursor.Decode(&transfer); err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, transfer.ToEntity())
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, translateError(err)
+	}
+
+	return transferPage(transfers, limit), nil
+}
+
+func (r *transferRepository) UpdateStatus(ctx context.Context, id string, from entity.TransferStatus, to entity.TransferStatus) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Update)
+	defer cancel()
+
+	docID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": docID, "status": string(from)},
+		bson.M{"$set": bson.M{"status": string(to), "updated_at": now()}},
+	)
+	if err != nil {
+		return translateError(err)
+	}
+	if result.MatchedCount == 0 {
+		return ErrTransferStatusChanged
+	}
+
+	return nil
+}
+
+func transferPage(transfers []entity.Transfer, limit int) *entity.TransferPage {
+	page := &entity.TransferPage{Transfers: transfers}
+	if len(transfers) > limit {
+		page.Transfers = transfers[:limit]
+		page.HasNextPage = true
+	}
+	return page
+}
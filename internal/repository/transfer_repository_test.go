@@ -0,0 +1,174 @@
+package repository_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/repository"
+	"github.com/teste-transfeera/pkg/shared"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func transferBackends(t *testing.T) map[string]func() repository.TransferRepository {
+	return map[string]func() repository.TransferRepository{
+		"memory": repository.NewMemoryTransferRepository,
+		"sqlite": func() repository.TransferRepository {
+			db, err := repository.OpenSQLite(filepath.Join(t.TempDir(), "transfeera.db"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { db.Close() })
+
+			if err := repository.MigrateSQLite(context.Background(), db); err != nil {
+				t.Fatal(err)
+			}
+			return repository.NewSQLiteTransferRepository(db, repository.DefaultTimeouts())
+		},
+	}
+}
+
+func Test_TransferRepository(t *testing.T) {
+	ctx := context.Background()
+
+	pix := entity.Transfer{
+		ReceiverID:  "63f8c8d6c6ce914b5b00b88e",
+		Amount:      1050,
+		Method:      entity.TransferPix,
+		Status:      entity.TransferCreated,
+		Description: "Aluguel",
+		PixKey:      "52998224725",
+	}
+	ted := entity.Transfer{
+		ReceiverID: "63f8c8d6c6ce914b5b00b88f",
+		Amount:     250000,
+		Method:     entity.TransferTED,
+		Status:     entity.TransferCreated,
+		BankAccount: &entity.BankAccount{
+			BankCode:     "001",
+			ISPB:         "00000000",
+			Agency:       "0814",
+			AgencyDigit:  "0",
+			Account:      "01002713",
+			AccountDigit: "9",
+			AccountType:  entity.Checking,
+			Verified:     true,
+		},
+	}
+
+	for backend, newRepository := range transferBackends(t) {
+		t.Run(backend, func(t *testing.T) {
+			t.Run("Create and find transfers with their destination", func(t *testing.T) {
+				repo := newRepository()
+
+				createdPix, err := repo.Create(ctx, pix)
+				assert.NoError(t, err)
+				createdTED, err := repo.Create(ctx, ted)
+				assert.NoError(t, err)
+
+				found, err := repo.FindById(ctx, createdPix.ID)
+				assert.NoError(t, err)
+				assert.Equal(t, createdPix, found)
+				assert.Equal(t, pix.PixKey, found.PixKey)
+				assert.Nil(t, found.BankAccount)
+				assert.False(t, found.CreatedAt.IsZero())
+				assert.True(t, found.UpdatedAt.IsZero())
+
+				found, err = repo.FindById(ctx, createdTED.ID)
+				assert.NoError(t, err)
+				assert.Equal(t, ted.BankAccount, found.BankAccount)
+				assert.Empty(t, found.PixKey)
+				assert.Equal(t, int64(250000), found.Amount)
+			})
+
+			t.Run("Find unknown transfer returns no documents", func(t *testing.T) {
+				repo := newRepository()
+
+				_, err := repo.FindById(ctx, "63f8c8d6c6ce914b5b00b88e")
+
+				assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+			})
+
+			t.Run("Idempotency key is unique among transfers created with one", func(t *testing.T) {
+				repo := newRepository()
+				keyed := pix
+				keyed.IdempotencyKey = "order-1"
+
+				created, err := repo.Create(ctx, keyed)
+				assert.NoError(t, err)
+				_, err = repo.Create(ctx, keyed)
+				assert.ErrorIs(t, err, repository.ErrDuplicateIdempotencyKey)
+				_, err = repo.Create(ctx, pix)
+				assert.NoError(t, err)
+				_, err = repo.Create(ctx, pix)
+				assert.NoError(t, err)
+
+				found, err := repo.FindByIdempotencyKey(ctx, "order-1")
+				assert.NoError(t, err)
+				assert.Equal(t, created, found)
+
+				_, err = repo.FindByIdempotencyKey(ctx, "order-2")
+				assert.ErrorIs(t, err, mongo.ErrNoDocuments)
+			})
+
+			t.Run("List transfers newest first, filtered and paged", func(t *testing.T) {
+				repo := newRepository()
+				first, _ := repo.Create(ctx, pix)
+				second, _ := repo.Create(ctx, ted)
+				third, _ := repo.Create(ctx, pix)
+
+				page, err := repo.List(ctx, repository.TransferFilter{}, 2, nil)
+				assert.NoError(t, err)
+				assert.True(t, page.HasNextPage)
+				assert.Equal(t, []string{third.ID, second.ID}, transferIDs(page))
+
+				page, err = repo.List(ctx, repository.TransferFilter{}, 2, &second.ID)
+				assert.NoError(t, err)
+				assert.False(t, page.HasNextPage)
+				assert.Equal(t, []string{first.ID}, transferIDs(page))
+
+				page, err = repo.List(ctx, repository.TransferFilter{ReceiverID: pix.ReceiverID}, 10, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, []string{third.ID, first.ID}, transferIDs(page))
+
+				assert.NoError(t, repo.UpdateStatus(ctx, second.ID, entity.TransferCreated, entity.TransferProcessing))
+				page, err = repo.List(ctx, repository.TransferFilter{Status: entity.TransferProcessing}, 10, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, []string{second.ID}, transferIDs(page))
+			})
+
+			t.Run("List transfers after invalid cursor returns error", func(t *testing.T) {
+				repo := newRepository()
+
+				_, err := repo.List(ctx, repository.TransferFilter{}, 10, shared.GetPointerStr("invalid"))
+
+				assert.Error(t, err)
+			})
+
+			t.Run("Update status only from the status it was read with", func(t *testing.T) {
+				repo := newRepository()
+				created, _ := repo.Create(ctx, pix)
+
+				err := repo.UpdateStatus(ctx, created.ID, entity.TransferCreated, entity.TransferProcessing)
+				assert.NoError(t, err)
+
+				err = repo.UpdateStatus(ctx, created.ID, entity.TransferCreated, entity.TransferProcessing)
+				assert.ErrorIs(t, err, repository.ErrTransferStatusChanged)
+
+				found, _ := repo.FindById(ctx, created.ID)
+				assert.Equal(t, entity.TransferProcessing, found.Status)
+				assert.False(t, found.UpdatedAt.IsZero())
+			})
+		})
+	}
+}
+
+func transferIDs(page *entity.TransferPage) []string {
+	ids := []string{}
+	for _, transfer := range page.Transfers {
+		ids = append(ids, transfer.ID)
+	}
+	return ids
+}
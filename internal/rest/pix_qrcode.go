@@ -0,0 +1,86 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/teste-transfeera/internal/repository"
+	"github.com/teste-transfeera/internal/usecase"
+	"github.com/teste-transfeera/pkg/pix"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// QRCodeSize is the width and height of the QR code images in pixels.
+const QRCodeSize = 256
+
+type errorResponse struct {
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+	Field   string `json:"field,omitempty"`
+}
+
+// PixQRCode serves the BR Code of the receiver in the id parameter as a PNG
+// QR code. The amount (in cents), txid and description query parameters
+// are the same as in the receiverPixBRCode query.
+func PixQRCode(useCases usecase.ReceiverUseCases) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		input := &usecase.GeneratePixBRCodeInput{
+			Id:          c.Param("id"),
+			Txid:        c.Query("txid"),
+			Description: c.Query("description"),
+		}
+		if amount := c.Query("amount"); amount != "" {
+			cents, err := strconv.ParseInt(amount, 10, 64)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"errors": []errorResponse{
+					{Message: "Amount must be an integer number of cents", Code: usecase.ERROR_CODE_INVALID_FORMAT, Field: "amount"},
+				}})
+				return
+			}
+			input.Amount = &cents
+		}
+
+		payload, err := useCases.GeneratePixBRCode(c.Request.Context(), input)
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+
+		image, err := pix.QRCodePNG(payload, QRCodeSize)
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+
+		c.Data(http.StatusOK, "image/png", image)
+	}
+}
+
+// writeError answers with the status matching the error and its message,
+// one item per field for validation errors.
+func writeError(c *gin.Context, err error) {
+	var validationErr *usecase.ValidationError
+	if errors.As(err, &validationErr) {
+		errs := make([]errorResponse, len(validationErr.Errors))
+		for i, fieldErr := range validationErr.Errors {
+			errs[i] = errorResponse{Message: fieldErr.Message, Code: fieldErr.Code, Field: fieldErr.Field}
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"errors": errs})
+		return
+	}
+
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		status = http.StatusNotFound
+	case errors.Is(err, primitive.ErrInvalidHex):
+		status = http.StatusBadRequest
+	case errors.Is(err, repository.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		status = http.StatusGatewayTimeout
+	}
+	c.JSON(status, gin.H{"errors": []errorResponse{{Message: err.Error()}}})
+}
@@ -0,0 +1,91 @@
+package rest_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/teste-transfeera/internal/rest"
+	"github.com/teste-transfeera/internal/usecase"
+	"github.com/teste-transfeera/mocks"
+	"github.com/teste-transfeera/pkg/shared"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func newRouter(useCase usecase.ReceiverUseCases) *gin.Engine {
+	router := gin.Default()
+	router.GET("/api/v1/receiver/:id/pix-qrcode.png", rest.PixQRCode(useCase))
+	return router
+}
+
+func Test_Rest_PixQRCode_Success(t *testing.T) {
+	useCase := &mocks.ReceiverUseCases{}
+	router := newRouter(useCase)
+
+	t.Run("Serve the BR Code as a PNG QR code", func(t *testing.T) {
+		// Arrange
+		id := "63f8c8d6c6ce914b5b00b88e"
+		mockInput := &usecase.GeneratePixBRCodeInput{Id: id, Amount: shared.GetPointerInt64(1050), Txid: "PEDIDO123"}
+		useCase.On("GeneratePixBRCode", mock.Anything, mockInput).Return("00020126330014br.gov.bcb.pix", nil).Once()
+
+		// Act
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/receiver/"+id+"/pix-qrcode.png?amount=1050&txid=PEDIDO123", nil)
+		router.ServeHTTP(rr, req)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "image/png", rr.Header().Get("Content-Type"))
+		assert.True(t, bytes.HasPrefix(rr.Body.Bytes(), []byte("\x89PNG")))
+		useCase.AssertExpectations(t)
+	})
+}
+
+func Test_Rest_PixQRCode_Error(t *testing.T) {
+	useCase := &mocks.ReceiverUseCases{}
+	router := newRouter(useCase)
+	id := "63f8c8d6c6ce914b5b00b88e"
+
+	t.Run("Answer bad request for an amount that is not in cents", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/receiver/"+id+"/pix-qrcode.png?amount=10.50", nil)
+		router.ServeHTTP(rr, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"errors":[{"message":"Amount must be an integer number of cents","code":"INVALID_FORMAT","field":"amount"}]}`, rr.Body.String())
+	})
+
+	t.Run("Answer bad request with the validation errors", func(t *testing.T) {
+		validationErr := &usecase.ValidationError{Errors: []*usecase.FieldError{
+			{Code: usecase.ERROR_CODE_INVALID_FORMAT, Field: "txid", Message: "Txid must contain only letters and digits"},
+		}}
+		useCase.On("GeneratePixBRCode", mock.Anything, mock.Anything).Return("", validationErr).Once()
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/receiver/"+id+"/pix-qrcode.png?txid=a-b", nil)
+		router.ServeHTTP(rr, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"errors":[{"message":"Txid must contain only letters and digits","code":"INVALID_FORMAT","field":"txid"}]}`, rr.Body.String())
+		useCase.AssertExpectations(t)
+	})
+
+	t.Run("Answer not found for an unknown receiver", func(t *testing.T) {
+		useCase.On("GeneratePixBRCode", mock.Anything, mock.Anything).Return("", mongo.ErrNoDocuments).Once()
+
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest(http.MethodGet, "/api/v1/receiver/"+id+"/pix-qrcode.png", nil)
+		router.ServeHTTP(rr, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		useCase.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/pkg/bank"
+	"github.com/teste-transfeera/pkg/validation"
+)
+
+// BankAccountInput is the bank account of CreateReceiverInput and
+// UpdateReceiverInput. It always replaces the receiver's account as a whole.
+type BankAccountInput struct {
+	BankCode     string `validate:"required,len=3,validateDigits,validateBankCode"`
+	Agency       string `validate:"required,max=4,validateDigits"`
+	AgencyDigit  string `validate:"omitempty,validateCheckDigit"`
+	Account      string `validate:"required,max=20,validateDigits"`
+	AccountDigit string `validate:"required,validateCheckDigit"`
+	AccountType  string `validate:"required,oneof=CHECKING SAVINGS PAYMENT"`
+
+	verified bool
+}
+
+// checkDigits verifies the check digits with the algorithm of the bank,
+// once the format of every field is valid, and keeps whether they were
+// verified for toEntity.
+func (i *BankAccountInput) checkDigits() *FieldError {
+	verified, err := validation.CheckBankAccount(i.BankCode, i.Agency, i.AgencyDigit, i.Account, i.AccountDigit)
+	i.verified = verified
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, validation.ErrInvalidAgencyFormat), errors.Is(err, validation.ErrInvalidAgencyCheckDigit):
+		return documentFieldError("bankAccount.agencyDigit", err)
+	case errors.Is(err, validation.ErrInvalidAccountFormat):
+		return documentFieldError("bankAccount.account", err)
+	default:
+		return documentFieldError("bankAccount.accountDigit", err)
+	}
+}
+
+// toEntity converts an input checked by checkDigits, taking the ISPB from
+// the bank directory.
+func (i *BankAccountInput) toEntity() *entity.BankAccount {
+	if i == nil {
+		return nil
+	}
+
+	institution, _ := bank.ByCode(i.BankCode)
+	return &entity.BankAccount{
+		BankCode:     i.BankCode,
+		ISPB:         institution.ISPB,
+		Agency:       i.Agency,
+		AgencyDigit:  validation.NormalizeCheckDigit(i.AgencyDigit),
+		Account:      i.Account,
+		AccountDigit: validation.NormalizeCheckDigit(i.AccountDigit),
+		AccountType:  entity.AccountType(i.AccountType),
+		Verified:     i.verified,
+	}
+}
+
+// addBankAccountFields sets the repository update fields of the account.
+func addBankAccountFields(fieldsToUpdate map[string]string, account *entity.BankAccount) {
+	fieldsToUpdate["bank_code"] = account.BankCode
+	fieldsToUpdate["ispb"] = account.ISPB
+	fieldsToUpdate["agency"] = account.Agency
+	fieldsToUpdate["agency_digit"] = account.AgencyDigit
+	fieldsToUpdate["account"] = account.Account
+	fieldsToUpdate["account_digit"] = account.AccountDigit
+	fieldsToUpdate["account_type"] = string(account.AccountType)
+	fieldsToUpdate["verified"] = strconv.FormatBool(account.Verified)
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/repository"
+	"github.com/teste-transfeera/pkg/validation"
+)
+
+type ChangeReceiverStatusInput struct {
+	Id         string            `validate:"required"`
+	Transition entity.Transition `validate:"required"`
+	Reason     string            `validate:"required,max=500"`
+	// ExpectedVersion is the version the client last read, as in
+	// UpdateReceiverInput.
+	ExpectedVersion *int64
+}
+
+// ChangeStatus moves the receiver through the status state machine and
+// records the transition with its reason in the history.
+func (u *receiverUseCase) ChangeStatus(ctx context.Context, input *ChangeReceiverStatusInput) (*entity.Receiver, error) {
+	if err := validateInput(input).orNil(); err != nil {
+		return nil, err
+	}
+
+	receiver, err := u.receiverRepository.FindById(ctx, input.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.ExpectedVersion != nil && *input.ExpectedVersion != receiver.Version {
+		return nil, &repository.VersionConflictError{ID: input.Id, ExpectedVersion: *input.ExpectedVersion}
+	}
+
+	status, err := receiver.Next(input.Transition)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Transition == entity.TransitionValidate {
+		if err := checkDocuments(receiver); err != nil {
+			return nil, err
+		}
+		if err := u.checkConsistency(receiver); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.Transition == entity.TransitionValidate && receiver.Pix.Key != "" {
+		fieldErr, err := u.checkPixOwner(ctx, receiver.Identifier, receiver.Pix.Key)
+		if err != nil {
+			return nil, err
+		}
+		if fieldErr != nil {
+			return nil, &ValidationError{Errors: []*FieldError{fieldErr}}
+		}
+	}
+
+	fieldsToUpdate := map[string]string{"status": string(status)}
+	err = u.receiverRepository.Update(ctx, input.Id, receiver.Version, fieldsToUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := newHistoryEntry(ctx, input.Id, entity.HistoryStatusChange, diffReceivers(receiver, applyFields(*receiver, fieldsToUpdate)))
+	entry.Reason = input.Reason
+	if err := u.recordHistory(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	updated, err := u.receiverRepository.FindById(ctx, input.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.withWarnings(updated), nil
+}
+
+// checkDocuments verifies the identifier and pix key of a receiver being
+// validated with the same rules as the create input, so receivers stored
+// before those rules cannot be validated with invalid documents.
+func checkDocuments(receiver *entity.Receiver) error {
+	reason := ""
+	if err := validation.CheckIdentifier(receiver.Identifier); err != nil {
+		reason = fmt.Sprintf("identifier is invalid: %v", err)
+	} else if err := validation.CheckPixKey(receiver.Pix.Key, string(receiver.Pix.KeyType)); err != nil {
+		reason = fmt.Sprintf("pix key is invalid: %v", err)
+	}
+	if reason != "" {
+		return &entity.TransitionError{From: receiver.Status, Transition: entity.TransitionValidate, Reason: reason}
+	}
+
+	return nil
+}
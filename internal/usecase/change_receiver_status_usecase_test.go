@@ -0,0 +1,192 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/teste-transfeera/internal/entity"
+	repositoryPkg "github.com/teste-transfeera/internal/repository"
+	"github.com/teste-transfeera/internal/usecase"
+	"github.com/teste-transfeera/mocks"
+	"github.com/teste-transfeera/pkg/actor"
+	"github.com/teste-transfeera/pkg/shared"
+	"github.com/teste-transfeera/pkg/validation"
+)
+
+func statusReceiver(status entity.Status) *entity.Receiver {
+	return &entity.Receiver{
+		ID:         "63f8c8d6c6ce914b5b00b88e",
+		Identifier: "52998224725",
+		Name:       "Receiver 1",
+		Email:      "receiver1@gmail.com",
+		Pix: entity.Pix{
+			KeyType: entity.CPF,
+			Key:     "52998224725",
+		},
+		Status:  status,
+		Version: 2,
+	}
+}
+
+func Test_ReceiverUseCase_ChangeStatus_Success(t *testing.T) {
+	repository := &mocks.ReceiverRepository{}
+	history := &mocks.HistoryRepository{}
+	useCase := usecase.NewReceiverUseCases(repository, history)
+	ctx := context.Background()
+
+	t.Run("Validate Draft receiver successfully", func(t *testing.T) {
+		input := usecase.ChangeReceiverStatusInput{
+			Id:         "63f8c8d6c6ce914b5b00b88e",
+			Transition: entity.TransitionValidate,
+			Reason:     "Documents checked",
+		}
+		mockOutput := statusReceiver(entity.Draft)
+		updated := statusReceiver(entity.Validated)
+		updated.Version = 3
+		expectedEntry := entity.HistoryEntry{
+			ReceiverID: input.Id,
+			Actor:      actor.Anonymous,
+			Operation:  entity.HistoryStatusChange,
+			Changes: []entity.FieldChange{
+				{Field: "status", Before: shared.GetPointerStr("Draft"), After: shared.GetPointerStr("Validated")},
+			},
+			Reason: "Documents checked",
+		}
+		repository.On("FindById", ctx, input.Id).Return(mockOutput, nil).Once()
+		repository.On("Update", ctx, input.Id, int64(2), map[string]string{"status": "Validated"}).Return(nil).Once()
+		history.On("Append", ctx, expectedEntry).Return(nil).Once()
+		repository.On("FindById", ctx, input.Id).Return(updated, nil).Once()
+
+		result, err := useCase.ChangeStatus(ctx, &input)
+
+		assert.Equal(t, updated, result)
+		assert.Equal(t, nil, err)
+		repository.AssertExpectations(t)
+		history.AssertExpectations(t)
+	})
+
+	t.Run("Block Validated receiver at the expected version successfully", func(t *testing.T) {
+		input := usecase.ChangeReceiverStatusInput{
+			Id:              "63f8c8d6c6ce914b5b00b88e",
+			Transition:      entity.TransitionBlock,
+			Reason:          "Suspected fraud",
+			ExpectedVersion: shared.GetPointerInt64(2),
+		}
+		updated := statusReceiver(entity.Blocked)
+		repository.On("FindById", ctx, input.Id).Return(statusReceiver(entity.Validated), nil).Once()
+		repository.On("Update", ctx, input.Id, int64(2), map[string]string{"status": "Blocked"}).Return(nil).Once()
+		history.On("Append", ctx, entity.HistoryEntry{
+			ReceiverID: input.Id,
+			Actor:      actor.Anonymous,
+			Operation:  entity.HistoryStatusChange,
+			Changes: []entity.FieldChange{
+				{Field: "status", Before: shared.GetPointerStr("Validated"), After: shared.GetPointerStr("Blocked")},
+			},
+			Reason: "Suspected fraud",
+		}).Return(nil).Once()
+		repository.On("FindById", ctx, input.Id).Return(updated, nil).Once()
+
+		result, err := useCase.ChangeStatus(ctx, &input)
+
+		assert.Equal(t, updated, result)
+		assert.Equal(t, nil, err)
+		repository.AssertExpectations(t)
+		history.AssertExpectations(t)
+	})
+}
+
+func Test_ReceiverUseCase_ChangeStatus_Error(t *testing.T) {
+	repository := &mocks.ReceiverRepository{}
+	history := &mocks.HistoryRepository{}
+	useCase := usecase.NewReceiverUseCases(repository, history)
+	ctx := context.Background()
+
+	t.Run("Change status without reason returns validation error", func(t *testing.T) {
+		input := usecase.ChangeReceiverStatusInput{
+			Id:         "63f8c8d6c6ce914b5b00b88e",
+			Transition: entity.TransitionBlock,
+		}
+		expectedErrors := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_REQUIRED, Field: "reason", Message: "Reason is required"},
+		}
+
+		result, err := useCase.ChangeStatus(ctx, &input)
+
+		assert.Equal(t, (*entity.Receiver)(nil), result)
+		assert.Equal(t, expectedErrors, fieldErrors(t, err))
+	})
+
+	t.Run("Unblock Validated receiver returns transition error", func(t *testing.T) {
+		input := usecase.ChangeReceiverStatusInput{
+			Id:         "63f8c8d6c6ce914b5b00b88e",
+			Transition: entity.TransitionUnblock,
+			Reason:     "Fraud ruled out",
+		}
+		expectedError := &entity.TransitionError{From: entity.Validated, Transition: entity.TransitionUnblock, Reason: "not allowed from this status"}
+		repository.On("FindById", ctx, input.Id).Return(statusReceiver(entity.Validated), nil).Once()
+
+		result, err := useCase.ChangeStatus(ctx, &input)
+
+		assert.Equal(t, (*entity.Receiver)(nil), result)
+		assert.Equal(t, expectedError, err)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Validate Draft receiver with an invalid stored identifier returns transition error", func(t *testing.T) {
+		input := usecase.ChangeReceiverStatusInput{
+			Id:         "63f8c8d6c6ce914b5b00b88e",
+			Transition: entity.TransitionValidate,
+			Reason:     "Documents checked",
+		}
+		receiver := statusReceiver(entity.Draft)
+		receiver.Identifier = "11111111111"
+		expectedError := &entity.TransitionError{
+			From:       entity.Draft,
+			Transition: entity.TransitionValidate,
+			Reason:     "identifier is invalid: " + validation.ErrCPFRepeatedDigits.Error(),
+		}
+		repository.On("FindById", ctx, input.Id).Return(receiver, nil).Once()
+
+		result, err := useCase.ChangeStatus(ctx, &input)
+
+		assert.Equal(t, (*entity.Receiver)(nil), result)
+		assert.Equal(t, expectedError, err)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Change status with outdated expected version returns conflict error", func(t *testing.T) {
+		input := usecase.ChangeReceiverStatusInput{
+			Id:              "63f8c8d6c6ce914b5b00b88e",
+			Transition:      entity.TransitionValidate,
+			Reason:          "Documents checked",
+			ExpectedVersion: shared.GetPointerInt64(1),
+		}
+		expectedError := &repositoryPkg.VersionConflictError{ID: input.Id, ExpectedVersion: 1}
+		repository.On("FindById", ctx, input.Id).Return(statusReceiver(entity.Draft), nil).Once()
+
+		result, err := useCase.ChangeStatus(ctx, &input)
+
+		assert.Equal(t, (*entity.Receiver)(nil), result)
+		assert.Equal(t, expectedError, err)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Change status returns error from repository on Update", func(t *testing.T) {
+		input := usecase.ChangeReceiverStatusInput{
+			Id:         "63f8c8d6c6ce914b5b00b88e",
+			Transition: entity.TransitionValidate,
+			Reason:     "Documents checked",
+		}
+		expectedError := errors.New("error")
+		repository.On("FindById", ctx, input.Id).Return(statusReceiver(entity.Draft), nil).Once()
+		repository.On("Update", ctx, input.Id, int64(2), map[string]string{"status": "Validated"}).Return(errors.New("error")).Once()
+
+		result, err := useCase.ChangeStatus(ctx, &input)
+
+		assert.Equal(t, (*entity.Receiver)(nil), result)
+		assert.Equal(t, expectedError, err)
+		repository.AssertExpectations(t)
+	})
+}
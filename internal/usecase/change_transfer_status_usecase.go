@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/teste-transfeera/internal/entity"
+)
+
+type ChangeTransferStatusInput struct {
+	Id     string                `validate:"required"`
+	Status entity.TransferStatus `validate:"required,oneof=CREATED PROCESSING FINISHED FAILED RETURNED"`
+}
+
+// ChangeStatus moves the transfer along its status flow. The update only
+// applies if no other request moved the transfer since it was read.
+func (u *transferUseCase) ChangeStatus(ctx context.Context, input *ChangeTransferStatusInput) (*entity.Transfer, error) {
+	if err := validateInput(input).orNil(); err != nil {
+		return nil, err
+	}
+
+	transfer, err := u.transferRepository.FindById(ctx, input.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	from := transfer.Status
+	if err := transfer.MoveTo(input.Status); err != nil {
+		return nil, err
+	}
+
+	if err := u.transferRepository.UpdateStatus(ctx, input.Id, from, input.Status); err != nil {
+		return nil, err
+	}
+
+	return u.transferRepository.FindById(ctx, input.Id)
+}
@@ -0,0 +1,90 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/teste-transfeera/internal/entity"
+	repositoryPkg "github.com/teste-transfeera/internal/repository"
+	"github.com/teste-transfeera/internal/usecase"
+	"github.com/teste-transfeera/mocks"
+)
+
+func statusTransfer(status entity.TransferStatus) *entity.Transfer {
+	return &entity.Transfer{
+		ID:         "63f8c8d6c6ce914b5b00b900",
+		ReceiverID: "63f8c8d6c6ce914b5b00b88e",
+		Amount:     1050,
+		Method:     entity.TransferPix,
+		Status:     status,
+		PixKey:     "52998224725",
+	}
+}
+
+func Test_TransferUseCase_ChangeStatus_Success(t *testing.T) {
+	transfers := &mocks.TransferRepository{}
+	receivers := &mocks.ReceiverRepository{}
+	useCase := usecase.NewTransferUseCases(transfers, receivers)
+	ctx := context.Background()
+
+	t.Run("Move Processing transfer to Finished successfully", func(t *testing.T) {
+		input := usecase.ChangeTransferStatusInput{Id: "63f8c8d6c6ce914b5b00b900", Status: entity.TransferFinished}
+		updated := statusTransfer(entity.TransferFinished)
+		transfers.On("FindById", ctx, input.Id).Return(statusTransfer(entity.TransferProcessing), nil).Once()
+		transfers.On("UpdateStatus", ctx, input.Id, entity.TransferProcessing, entity.TransferFinished).Return(nil).Once()
+		transfers.On("FindById", ctx, input.Id).Return(updated, nil).Once()
+
+		result, err := useCase.ChangeStatus(ctx, &input)
+
+		assert.Equal(t, updated, result)
+		assert.Equal(t, nil, err)
+		transfers.AssertExpectations(t)
+	})
+}
+
+func Test_TransferUseCase_ChangeStatus_Error(t *testing.T) {
+	transfers := &mocks.TransferRepository{}
+	receivers := &mocks.ReceiverRepository{}
+	useCase := usecase.NewTransferUseCases(transfers, receivers)
+	ctx := context.Background()
+
+	t.Run("Move Created transfer to Finished returns error", func(t *testing.T) {
+		input := usecase.ChangeTransferStatusInput{Id: "63f8c8d6c6ce914b5b00b900", Status: entity.TransferFinished}
+		expectedError := &entity.TransferStatusError{From: entity.TransferCreated, To: entity.TransferFinished}
+		transfers.On("FindById", ctx, input.Id).Return(statusTransfer(entity.TransferCreated), nil).Once()
+
+		result, err := useCase.ChangeStatus(ctx, &input)
+
+		assert.Equal(t, (*entity.Transfer)(nil), result)
+		assert.Equal(t, expectedError, err)
+		transfers.AssertExpectations(t)
+		transfers.AssertNotCalled(t, "UpdateStatus")
+	})
+
+	t.Run("Move transfer moved by another request returns error", func(t *testing.T) {
+		input := usecase.ChangeTransferStatusInput{Id: "63f8c8d6c6ce914b5b00b900", Status: entity.TransferProcessing}
+		transfers.On("FindById", ctx, input.Id).Return(statusTransfer(entity.TransferCreated), nil).Once()
+		transfers.On("UpdateStatus", ctx, input.Id, entity.TransferCreated, entity.TransferProcessing).
+			Return(repositoryPkg.ErrTransferStatusChanged).Once()
+
+		result, err := useCase.ChangeStatus(ctx, &input)
+
+		assert.Equal(t, (*entity.Transfer)(nil), result)
+		assert.Equal(t, repositoryPkg.ErrTransferStatusChanged, err)
+		transfers.AssertExpectations(t)
+	})
+
+	t.Run("Move transfer returns validation errors", func(t *testing.T) {
+		input := usecase.ChangeTransferStatusInput{Status: "PAID"}
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_REQUIRED, Field: "id", Message: "Id is required"},
+			{Code: usecase.ERROR_CODE_INVALID_VALUE, Field: "status", Message: "Status must be one of CREATED, PROCESSING, FINISHED, FAILED, RETURNED"},
+		}
+
+		result, err := useCase.ChangeStatus(ctx, &input)
+
+		assert.Equal(t, (*entity.Transfer)(nil), result)
+		assert.Equal(t, expectedError, fieldErrors(t, err))
+	})
+}
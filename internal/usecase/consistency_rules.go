@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/pkg/validation"
+)
+
+// RuleSeverity is what breaking a consistency rule does to the receiver.
+type RuleSeverity string
+
+const (
+	// RuleBlocking refuses the receiver with a validation error.
+	RuleBlocking RuleSeverity = "BLOCKING"
+	// RuleWarning accepts the receiver and reports the rule in its warnings.
+	RuleWarning RuleSeverity = "WARNING"
+	// RuleOff does not evaluate the rule.
+	RuleOff RuleSeverity = "OFF"
+)
+
+const (
+	RULE_PIX_KEY_IDENTIFIER_MISMATCH  string = "PIX_KEY_IDENTIFIER_MISMATCH"
+	RULE_PIX_KEY_PERSON_TYPE_MISMATCH string = "PIX_KEY_PERSON_TYPE_MISMATCH"
+)
+
+// ConsistencyRule checks fields of a receiver against each other. Check
+// returns the field and the message of the violation, or an empty field when
+// the receiver follows the rule. Receivers are checked in canonical form.
+type ConsistencyRule struct {
+	Code     string
+	Severity RuleSeverity
+	Check    func(receiver *entity.Receiver) (field string, message string)
+}
+
+// DefaultConsistencyRules refuses CPF and CNPJ keys of another document than
+// the identifier, and warns about keys of a person for a company or of a
+// company for a person.
+func DefaultConsistencyRules() []ConsistencyRule {
+	return []ConsistencyRule{
+		{Code: RULE_PIX_KEY_IDENTIFIER_MISMATCH, Severity: RuleBlocking, Check: checkPixKeyIdentifier},
+		{Code: RULE_PIX_KEY_PERSON_TYPE_MISMATCH, Severity: RuleWarning, Check: checkPixKeyPersonType},
+	}
+}
+
+// ConfigureRules changes the severity of rules from a comma separated list
+// of CODE=SEVERITY, such as "PIX_KEY_PERSON_TYPE_MISMATCH=BLOCKING". Rules
+// that are not in config keep their severity.
+func ConfigureRules(rules []ConsistencyRule, config string) ([]ConsistencyRule, error) {
+	configured := make([]ConsistencyRule, len(rules))
+	copy(configured, rules)
+
+	for _, setting := range strings.Split(config, ",") {
+		setting = strings.TrimSpace(setting)
+		if setting == "" {
+			continue
+		}
+
+		code, severity, ok := strings.Cut(setting, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rule setting %q, expected CODE=SEVERITY", setting)
+		}
+		switch RuleSeverity(severity) {
+		case RuleBlocking, RuleWarning, RuleOff:
+		default:
+			return nil, fmt.Errorf("invalid severity %q of rule %s, expected BLOCKING, WARNING or OFF", severity, code)
+		}
+
+		found := false
+		for i := range configured {
+			if configured[i].Code == code {
+				configured[i].Severity = RuleSeverity(severity)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown rule %s", code)
+		}
+	}
+
+	return configured, nil
+}
+
+// checkConsistency returns a validation error with the blocking rules the
+// receiver breaks.
+func (u *receiverUseCase) checkConsistency(receiver *entity.Receiver) error {
+	validationErr := &ValidationError{}
+	for _, rule := range u.rules {
+		if rule.Severity != RuleBlocking {
+			continue
+		}
+		if field, message := rule.Check(receiver); field != "" {
+			validationErr.add(&FieldError{Code: rule.Code, Field: field, Message: message})
+		}
+	}
+	return validationErr.orNil()
+}
+
+// withWarnings sets the warnings of the receiver. Blocking rules are reported
+// too, as receivers written before a rule was blocking may break it.
+func (u *receiverUseCase) withWarnings(receiver *entity.Receiver) *entity.Receiver {
+	receiver.Warnings = []entity.Warning{}
+	for _, rule := range u.rules {
+		if rule.Severity == RuleOff {
+			continue
+		}
+		if field, message := rule.Check(receiver); field != "" {
+			receiver.Warnings = append(receiver.Warnings, entity.Warning{Code: rule.Code, Field: field, Message: message})
+		}
+	}
+	return receiver
+}
+
+// isCompany tells a company CNPJ identifier from a person CPF one by its
+// length.
+func isCompany(identifier string) bool {
+	return len(identifier) == 14
+}
+
+func checkPixKeyIdentifier(receiver *entity.Receiver) (string, string) {
+	keyType := receiver.Pix.KeyType
+	if keyType != entity.CPF && keyType != entity.CNPJ {
+		return "", ""
+	}
+	if isCompany(receiver.Identifier) != (keyType == entity.CNPJ) {
+		return "", ""
+	}
+
+	if receiver.Pix.Key != receiver.Identifier {
+		return "pixKey", fmt.Sprintf("%s Pix Key must be the identifier %s", keyType, validation.FormatIdentifier(receiver.Identifier))
+	}
+	return "", ""
+}
+
+func checkPixKeyPersonType(receiver *entity.Receiver) (string, string) {
+	switch {
+	case receiver.Pix.KeyType == entity.CPF && isCompany(receiver.Identifier):
+		return "pixKeyType", "CPF Pix Key belongs to a person, but the identifier is the CNPJ of a company"
+	case receiver.Pix.KeyType == entity.CNPJ && !isCompany(receiver.Identifier):
+		return "pixKeyType", "CNPJ Pix Key belongs to a company, but the identifier is the CPF of a person"
+	}
+	return "", ""
+}
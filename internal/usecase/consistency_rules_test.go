@@ -0,0 +1,228 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/usecase"
+	"github.com/teste-transfeera/mocks"
+)
+
+func Test_ReceiverUseCase_ConsistencyRules_Success(t *testing.T) {
+	repository := &mocks.ReceiverRepository{}
+	history := &mocks.HistoryRepository{}
+	useCase := usecase.NewReceiverUseCases(repository, history)
+	ctx := context.Background()
+
+	t.Run("Create company receiver with a CPF key returns a warning", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier: "11.222.333/0001-81",
+			Name:       "Company 1",
+			Email:      "company1@gmail.com",
+			PixKeyType: "CPF",
+			PixKey:     "529.982.247-25",
+		}
+		created := &entity.Receiver{
+			ID:         "63f8c8d6c6ce914b5b00b88e",
+			Identifier: "11222333000181",
+			Pix:        entity.Pix{KeyType: entity.CPF, Key: "52998224725"},
+		}
+		repository.On("Create", ctx, mock.Anything).Return(created, nil).Once()
+		history.On("Append", ctx, mock.Anything).Return(nil).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []entity.Warning{
+			{
+				Code:    usecase.RULE_PIX_KEY_PERSON_TYPE_MISMATCH,
+				Field:   "pixKeyType",
+				Message: "CPF Pix Key belongs to a person, but the identifier is the CNPJ of a company",
+			},
+		}, result.Warnings)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Update company receiver to a CPF key returns a warning", func(t *testing.T) {
+		input := usecase.UpdateReceiverInput{Id: "63f8c8d6c6ce914b5b00b88e", PixKeyType: "CPF", PixKey: "529.982.247-25"}
+		stored := &entity.Receiver{
+			ID:         input.Id,
+			Identifier: "11222333000181",
+			Pix:        entity.Pix{KeyType: entity.Email, Key: "company1@gmail.com"},
+			Status:     entity.Draft,
+			Version:    2,
+		}
+		updated := &entity.Receiver{
+			ID:         input.Id,
+			Identifier: "11222333000181",
+			Pix:        entity.Pix{KeyType: entity.CPF, Key: "52998224725"},
+			Status:     entity.Draft,
+			Version:    3,
+		}
+		repository.On("FindById", ctx, input.Id).Return(stored, nil).Once()
+		repository.On("Update", ctx, input.Id, int64(2), mock.Anything).Return(nil).Once()
+		history.On("Append", ctx, mock.Anything).Return(nil).Once()
+		repository.On("FindById", ctx, input.Id).Return(updated, nil).Once()
+
+		result, err := useCase.Update(ctx, &input)
+
+		assert.Equal(t, nil, err)
+		assert.Equal(t, updated, result)
+		assert.Equal(t, []entity.Warning{
+			{
+				Code:    usecase.RULE_PIX_KEY_PERSON_TYPE_MISMATCH,
+				Field:   "pixKeyType",
+				Message: "CPF Pix Key belongs to a person, but the identifier is the CNPJ of a company",
+			},
+		}, result.Warnings)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("List receiver by id returns the warnings of blocking rules", func(t *testing.T) {
+		input := usecase.ListReceiverByIdInput{Id: "63f8c8d6c6ce914b5b00b88e"}
+		stored := &entity.Receiver{
+			ID:         input.Id,
+			Identifier: "52998224725",
+			Pix:        entity.Pix{KeyType: entity.CPF, Key: "29055159026"},
+		}
+		repository.On("FindById", ctx, input.Id).Return(stored, nil).Once()
+
+		result, err := useCase.ListById(ctx, &input)
+
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []entity.Warning{
+			{
+				Code:    usecase.RULE_PIX_KEY_IDENTIFIER_MISMATCH,
+				Field:   "pixKey",
+				Message: "CPF Pix Key must be the identifier 529.982.247-25",
+			},
+		}, result.Warnings)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("List receiver by id returns no warnings for consistent receivers", func(t *testing.T) {
+		input := usecase.ListReceiverByIdInput{Id: "63f8c8d6c6ce914b5b00b88e"}
+		repository.On("FindById", ctx, input.Id).Return(statusReceiver(entity.Draft), nil).Once()
+
+		result, err := useCase.ListById(ctx, &input)
+
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []entity.Warning{}, result.Warnings)
+		repository.AssertExpectations(t)
+	})
+}
+
+func Test_ReceiverUseCase_ConsistencyRules_Error(t *testing.T) {
+	repository := &mocks.ReceiverRepository{}
+	history := &mocks.HistoryRepository{}
+	useCase := usecase.NewReceiverUseCases(repository, history)
+	ctx := context.Background()
+
+	t.Run("Create receiver with a CPF key of another CPF returns error", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier: "529.982.247-25",
+			Name:       "Receiver 1",
+			Email:      "receiver1@gmail.com",
+			PixKeyType: "CPF",
+			PixKey:     "290.551.590-26",
+		}
+		expectedErrors := []usecase.FieldError{
+			{Code: usecase.RULE_PIX_KEY_IDENTIFIER_MISMATCH, Field: "pixKey", Message: "CPF Pix Key must be the identifier 529.982.247-25"},
+		}
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, (*entity.Receiver)(nil), result)
+		assert.Equal(t, expectedErrors, fieldErrors(t, err))
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Update identifier away from the CPF key returns error", func(t *testing.T) {
+		input := usecase.UpdateReceiverInput{Id: "63f8c8d6c6ce914b5b00b88e", Identifier: "290.551.590-26"}
+		expectedErrors := []usecase.FieldError{
+			{Code: usecase.RULE_PIX_KEY_IDENTIFIER_MISMATCH, Field: "pixKey", Message: "CPF Pix Key must be the identifier 290.551.590-26"},
+		}
+		repository.On("FindById", ctx, input.Id).Return(statusReceiver(entity.Draft), nil).Once()
+
+		_, err := useCase.Update(ctx, &input)
+
+		assert.Equal(t, expectedErrors, fieldErrors(t, err))
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Validate receiver breaking a blocking rule returns error", func(t *testing.T) {
+		input := usecase.ChangeReceiverStatusInput{
+			Id:         "63f8c8d6c6ce914b5b00b88e",
+			Transition: entity.TransitionValidate,
+			Reason:     "Documents checked",
+		}
+		stored := statusReceiver(entity.Draft)
+		stored.Pix.Key = "29055159026"
+		repository.On("FindById", ctx, input.Id).Return(stored, nil).Once()
+
+		result, err := useCase.ChangeStatus(ctx, &input)
+
+		assert.Equal(t, (*entity.Receiver)(nil), result)
+		assert.Equal(t, []usecase.FieldError{
+			{Code: usecase.RULE_PIX_KEY_IDENTIFIER_MISMATCH, Field: "pixKey", Message: "CPF Pix Key must be the identifier 529.982.247-25"},
+		}, fieldErrors(t, err))
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Create receiver breaking a rule configured as blocking returns error", func(t *testing.T) {
+		rules, _ := usecase.ConfigureRules(usecase.DefaultConsistencyRules(), "PIX_KEY_PERSON_TYPE_MISMATCH=BLOCKING")
+		strict := usecase.NewReceiverUseCases(repository, history, usecase.WithConsistencyRules(rules))
+		input := usecase.CreateReceiverInput{
+			Identifier: "529.982.247-25",
+			Name:       "Receiver 1",
+			Email:      "receiver1@gmail.com",
+			PixKeyType: "CNPJ",
+			PixKey:     "11.222.333/0001-81",
+		}
+		expectedErrors := []usecase.FieldError{
+			{
+				Code:    usecase.RULE_PIX_KEY_PERSON_TYPE_MISMATCH,
+				Field:   "pixKeyType",
+				Message: "CNPJ Pix Key belongs to a company, but the identifier is the CPF of a person",
+			},
+		}
+
+		_, err := strict.Create(ctx, &input)
+
+		assert.Equal(t, expectedErrors, fieldErrors(t, err))
+		repository.AssertExpectations(t)
+	})
+}
+
+func Test_ReceiverUseCase_ConfigureRules(t *testing.T) {
+	t.Run("Change severities of the rules", func(t *testing.T) {
+		rules, err := usecase.ConfigureRules(usecase.DefaultConsistencyRules(), "PIX_KEY_IDENTIFIER_MISMATCH=OFF, PIX_KEY_PERSON_TYPE_MISMATCH=BLOCKING")
+
+		assert.Equal(t, nil, err)
+		assert.Equal(t, usecase.RuleOff, rules[0].Severity)
+		assert.Equal(t, usecase.RuleBlocking, rules[1].Severity)
+	})
+
+	t.Run("Keep the defaults without settings", func(t *testing.T) {
+		rules, err := usecase.ConfigureRules(usecase.DefaultConsistencyRules(), "")
+
+		assert.Equal(t, nil, err)
+		assert.Equal(t, usecase.RuleBlocking, rules[0].Severity)
+		assert.Equal(t, usecase.RuleWarning, rules[1].Severity)
+	})
+
+	t.Run("Reject invalid settings", func(t *testing.T) {
+		_, err := usecase.ConfigureRules(usecase.DefaultConsistencyRules(), "PIX_KEY_IDENTIFIER_MISMATCH")
+		assert.Equal(t, errors.New(`invalid rule setting "PIX_KEY_IDENTIFIER_MISMATCH", expected CODE=SEVERITY`), err)
+
+		_, err = usecase.ConfigureRules(usecase.DefaultConsistencyRules(), "PIX_KEY_IDENTIFIER_MISMATCH=STRICT")
+		assert.Equal(t, errors.New(`invalid severity "STRICT" of rule PIX_KEY_IDENTIFIER_MISMATCH, expected BLOCKING, WARNING or OFF`), err)
+
+		_, err = usecase.ConfigureRules(usecase.DefaultConsistencyRules(), "UNKNOWN=OFF")
+		assert.Equal(t, errors.New("unknown rule UNKNOWN"), err)
+	})
+}
@@ -0,0 +1,12 @@
+package usecase
+
+import "context"
+
+func (u *receiverUseCase) Count(ctx context.Context, filter map[string]string) (int64, error) {
+	total, err := u.receiverRepository.Count(ctx, normalizeFilter(filter))
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
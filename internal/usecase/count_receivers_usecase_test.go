@@ -0,0 +1,48 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/teste-transfeera/internal/usecase"
+	"github.com/teste-transfeera/mocks"
+)
+
+func Test_ReceiverUseCase_Count_Success(t *testing.T) {
+	repository := &mocks.ReceiverRepository{}
+	history := &mocks.HistoryRepository{}
+	useCase := usecase.NewReceiverUseCases(repository, history)
+	ctx := context.Background()
+
+	t.Run("Count receivers successfully", func(t *testing.T) {
+		input := map[string]string{"status": "Draft"}
+		repository.On("Count", ctx, input).Return(int64(42), nil).Once()
+
+		result, err := useCase.Count(ctx, input)
+
+		assert.Equal(t, int64(42), result)
+		assert.Equal(t, nil, err)
+		repository.AssertExpectations(t)
+	})
+}
+
+func Test_ReceiverUseCase_Count_Error(t *testing.T) {
+	repository := &mocks.ReceiverRepository{}
+	history := &mocks.HistoryRepository{}
+	useCase := usecase.NewReceiverUseCases(repository, history)
+	ctx := context.Background()
+
+	t.Run("Count receivers returns error from repository", func(t *testing.T) {
+		input := map[string]string{}
+		repository.On("Count", ctx, input).Return(int64(0), errors.New("error")).Once()
+		expectedError := errors.New("error")
+
+		result, err := useCase.Count(ctx, input)
+
+		assert.Equal(t, int64(0), result)
+		assert.Equal(t, expectedError, err)
+		repository.AssertExpectations(t)
+	})
+}
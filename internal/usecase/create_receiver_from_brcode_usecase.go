@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/pkg/pix"
+	"github.com/teste-transfeera/pkg/validation"
+)
+
+type CreateReceiverFromBRCodeInput struct {
+	Payload    string `validate:"required"`
+	Identifier string
+	Email      string
+}
+
+// CreateFromBRCode creates a Draft receiver with the pix key and the merchant
+// name of a BR Code, inferring the key type from the key. Identifier and
+// email are not in BR Codes, so they come with the payload and Create
+// validates them.
+func (u *receiverUseCase) CreateFromBRCode(ctx context.Context, input *CreateReceiverFromBRCodeInput) (*entity.Receiver, error) {
+	if err := validateInput(input).orNil(); err != nil {
+		return nil, err
+	}
+
+	code, err := pix.Decode(input.Payload)
+	if err != nil {
+		return nil, &ValidationError{Errors: []*FieldError{brCodeFieldError(err)}}
+	}
+
+	keyType, ok := validation.InferPixKeyType(code.Key)
+	if !ok {
+		err := fmt.Errorf("Pix key %s of the BR Code is invalid", code.Key)
+		return nil, &ValidationError{Errors: []*FieldError{
+			{Code: ERROR_CODE_INVALID_VALUE, Field: "payload", Message: err.Error(), Err: err},
+		}}
+	}
+
+	return u.Create(ctx, &CreateReceiverInput{
+		Identifier: input.Identifier,
+		Name:       code.Name,
+		Email:      input.Email,
+		PixKeyType: string(keyType),
+		PixKey:     code.Key,
+	})
+}
+
+// brCodeFieldError converts the errors of pix.Decode, keeping their message.
+func brCodeFieldError(err error) *FieldError {
+	code := ERROR_CODE_INVALID_VALUE
+	switch {
+	case errors.Is(err, pix.ErrInvalidTag):
+		code = ERROR_CODE_INVALID_TAG
+	case errors.Is(err, pix.ErrInvalidLength):
+		code = ERROR_CODE_INVALID_LENGTH
+	case errors.Is(err, pix.ErrInvalidCRC), errors.Is(err, pix.ErrMissingCRC):
+		code = ERROR_CODE_INVALID_CRC
+	}
+
+	return &FieldError{Code: code, Field: "payload", Message: err.Error(), Err: err}
+}
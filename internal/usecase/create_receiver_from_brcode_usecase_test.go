@@ -0,0 +1,157 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/usecase"
+	"github.com/teste-transfeera/mocks"
+	"github.com/teste-transfeera/pkg/pix"
+)
+
+func Test_ReceiverUseCase_CreateFromBRCode_Success(t *testing.T) {
+	repository := &mocks.ReceiverRepository{}
+	history := &mocks.HistoryRepository{}
+	useCase := usecase.NewReceiverUseCases(repository, history)
+	ctx := context.Background()
+
+	t.Run("Create receiver from the BR Code of a CPF key successfully", func(t *testing.T) {
+		payload, _ := pix.BRCode{Key: "52998224725", Name: "João da Silva", City: "SAO PAULO", Amount: 1050}.Payload()
+		input := &usecase.CreateReceiverFromBRCodeInput{
+			Payload:    payload,
+			Identifier: "529.982.247-25",
+			Email:      "JOAO@GMAIL.COM",
+		}
+		mockInput := entity.Receiver{
+			Identifier:          "52998224725",
+			FormattedIdentifier: "529.982.247-25",
+			Name:                "Joao da Silva",
+			Email:               "joao@gmail.com",
+			Status:              entity.Draft,
+			Pix: entity.Pix{
+				KeyType:      entity.CPF,
+				Key:          "52998224725",
+				FormattedKey: "529.982.247-25",
+			},
+		}
+		expectedResult := mockInput
+		expectedResult.ID = "63fbbe585c3c3b8ab3a647aa"
+		repository.On("Create", ctx, mockInput).Return(&expectedResult, nil).Once()
+		history.On("Append", ctx, mock.Anything).Return(nil).Once()
+
+		result, err := useCase.CreateFromBRCode(ctx, input)
+
+		assert.Equal(t, &expectedResult, result)
+		assert.Equal(t, nil, err)
+		repository.AssertExpectations(t)
+		history.AssertExpectations(t)
+	})
+
+	t.Run("Create receiver from the BR Code of a random key successfully", func(t *testing.T) {
+		input := &usecase.CreateReceiverFromBRCodeInput{
+			Payload:    "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D",
+			Identifier: "11.222.333/0001-81",
+			Email:      "fulano@gmail.com",
+		}
+		mockInput := entity.Receiver{
+			Identifier:          "11222333000181",
+			FormattedIdentifier: "11.222.333/0001-81",
+			Name:                "Fulano de Tal",
+			Email:               "fulano@gmail.com",
+			Status:              entity.Draft,
+			Pix: entity.Pix{
+				KeyType:      entity.RandomKey,
+				Key:          "123e4567-e12b-12d1-a456-426655440000",
+				FormattedKey: "123e4567-e12b-12d1-a456-426655440000",
+			},
+		}
+		expectedResult := mockInput
+		expectedResult.ID = "63fbbe585c3c3b8ab3a647ab"
+		repository.On("Create", ctx, mockInput).Return(&expectedResult, nil).Once()
+		history.On("Append", ctx, mock.Anything).Return(nil).Once()
+
+		result, err := useCase.CreateFromBRCode(ctx, input)
+
+		assert.Equal(t, &expectedResult, result)
+		assert.Equal(t, nil, err)
+		repository.AssertExpectations(t)
+	})
+}
+
+func Test_ReceiverUseCase_CreateFromBRCode_Error(t *testing.T) {
+	repository := &mocks.ReceiverRepository{}
+	history := &mocks.HistoryRepository{}
+	useCase := usecase.NewReceiverUseCases(repository, history)
+	ctx := context.Background()
+	payload := "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D"
+
+	t.Run("Create receiver from BR Code returns required payload error", func(t *testing.T) {
+		input := &usecase.CreateReceiverFromBRCodeInput{Identifier: "529.982.247-25", Email: "fulano@gmail.com"}
+		expectedErrors := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_REQUIRED, Field: "payload", Message: "Payload is required"},
+		}
+
+		result, err := useCase.CreateFromBRCode(ctx, input)
+
+		assert.Equal(t, (*entity.Receiver)(nil), result)
+		assert.Equal(t, expectedErrors, fieldErrors(t, err))
+	})
+
+	t.Run("Create receiver from BR Code returns decode errors", func(t *testing.T) {
+		cases := []struct {
+			payload string
+			code    string
+			message string
+		}{
+			{
+				payload: "0002012X",
+				code:    usecase.ERROR_CODE_INVALID_TAG,
+				message: "Invalid BR Code at position 6: field id must have two digits",
+			},
+			{
+				payload: "0002012699br.gov.bcb.pix",
+				code:    usecase.ERROR_CODE_INVALID_LENGTH,
+				message: "Invalid BR Code at position 6, field 26: field length must have two digits and fit in the payload",
+			},
+			{
+				payload: payload[:len(payload)-4] + "0000",
+				code:    usecase.ERROR_CODE_INVALID_CRC,
+				message: "Invalid BR Code at position 129, field 63: CRC does not match the payload: expected 1D3D, found 0000",
+			},
+		}
+
+		for _, c := range cases {
+			input := &usecase.CreateReceiverFromBRCodeInput{Payload: c.payload, Identifier: "529.982.247-25", Email: "fulano@gmail.com"}
+
+			_, err := useCase.CreateFromBRCode(ctx, input)
+
+			assert.Equal(t, []usecase.FieldError{{Code: c.code, Field: "payload", Message: c.message}}, fieldErrors(t, err))
+		}
+	})
+
+	t.Run("Create receiver from BR Code returns error for a key of no type", func(t *testing.T) {
+		invalidKey, _ := pix.BRCode{Key: "chave invalida", Name: "Fulano de Tal", City: "BRASILIA"}.Payload()
+		input := &usecase.CreateReceiverFromBRCodeInput{Payload: invalidKey, Identifier: "529.982.247-25", Email: "fulano@gmail.com"}
+
+		_, err := useCase.CreateFromBRCode(ctx, input)
+
+		assert.Equal(t, []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_INVALID_VALUE, Field: "payload", Message: "Pix key chave invalida of the BR Code is invalid"},
+		}, fieldErrors(t, err))
+	})
+
+	t.Run("Create receiver from BR Code returns validation errors of Create", func(t *testing.T) {
+		input := &usecase.CreateReceiverFromBRCodeInput{Payload: payload, Identifier: "529.982.247-25"}
+		expectedErrors := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_REQUIRED, Field: "email", Message: "Email is required"},
+		}
+
+		_, err := useCase.CreateFromBRCode(ctx, input)
+
+		assert.Equal(t, expectedErrors, fieldErrors(t, err))
+		repository.AssertExpectations(t)
+	})
+}
@@ -1,9 +1,22 @@
 package usecase
 
 import (
-	"github.com/go-playground/validator/v10"
+	"context"
+	"fmt"
+
 	"github.com/teste-transfeera/internal/entity"
 	"github.com/teste-transfeera/pkg/validation"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// DuplicateStrategy is what Create does when a live receiver already has the
+// identifier of the input.
+type DuplicateStrategy string
+
+const (
+	DuplicateFail           DuplicateStrategy = "FAIL"
+	DuplicateReturnExisting DuplicateStrategy = "RETURN_EXISTING"
+	DuplicateUpdateDraft    DuplicateStrategy = "UPDATE_DRAFT"
 )
 
 type CreateReceiverInput struct {
@@ -12,36 +25,164 @@ type CreateReceiverInput struct {
 	Email      string `validate:"required,max=250,validateEmail"`
 	PixKeyType string `validate:"required,validatePixType"`
 	PixKey     string `validate:"required,validatePixKey"`
+	// BankAccount is optional, receivers may have only a pix key.
+	BankAccount *BankAccountInput
+	// OnDuplicate enables the duplicate check by identifier. When it is
+	// empty a new receiver is always created.
+	OnDuplicate DuplicateStrategy `validate:"omitempty,oneof=FAIL RETURN_EXISTING UPDATE_DRAFT"`
 }
 
-func (u *receiverUseCase) Create(input *CreateReceiverInput) (*entity.Receiver, error) {
-	validator := validator.New()
-	validator.RegisterValidation("validateIdentifier", validation.ValidatorIdentifier)
-	validator.RegisterValidation("validateEmail", validation.ValidatorEmail)
-	validator.RegisterValidation("validatePixType", validation.ValidatorPixType)
-	validator.RegisterValidation("validatePixKey", validation.ValidatorPixKey)
+// DuplicateReceiverError is returned by Create when a live receiver already
+// has the identifier and the duplicate strategy does not allow using it.
+type DuplicateReceiverError struct {
+	Identifier string
+	ReceiverID string
+	Status     entity.Status
+}
 
-	err := validator.Struct(input)
-	if err != nil {
+func (e *DuplicateReceiverError) Error() string {
+	if e.Status != entity.Draft {
+		return fmt.Sprintf("Receiver %s with identifier %s already exists with status %s", e.ReceiverID, e.Identifier, e.Status)
+	}
+	return fmt.Sprintf("Receiver %s with identifier %s already exists", e.ReceiverID, e.Identifier)
+}
+
+func (u *receiverUseCase) Create(ctx context.Context, input *CreateReceiverInput) (*entity.Receiver, error) {
+	validationErr := validateInput(input)
+	if !validationErr.has("identifier") {
+		if err := validation.CheckIdentifier(input.Identifier); err != nil {
+			validationErr.add(documentFieldError("identifier", fmt.Errorf("Invalid Identifier: %w", err)))
+		}
+	}
+	if !validationErr.has("pixKey") && !validationErr.has("pixKeyType") {
+		if err := validation.CheckPixKey(input.PixKey, input.PixKeyType); err != nil {
+			validationErr.add(documentFieldError("pixKey", err))
+		}
+	}
+	if input.BankAccount != nil && !validationErr.hasWithin("bankAccount") {
+		if fieldErr := input.BankAccount.checkDigits(); fieldErr != nil {
+			validationErr.add(fieldErr)
+		}
+	}
+	if err := validationErr.orNil(); err != nil {
 		return nil, err
 	}
 
 	keyType, _ := entity.GetKeyType(input.PixKeyType)
 	receiver := entity.Receiver{
-		Identifier: input.Identifier,
-		Name:       input.Name,
-		Email:      input.Email,
+		Identifier:          validation.NormalizeIdentifier(input.Identifier),
+		FormattedIdentifier: validation.FormatIdentifier(input.Identifier),
+		Name:                input.Name,
+		Email:               validation.NormalizeEmail(input.Email),
 		Pix: entity.Pix{
-			KeyType: keyType,
-			Key:     input.PixKey,
+			KeyType:      keyType,
+			Key:          validation.NormalizePixKey(input.PixKey, input.PixKeyType),
+			FormattedKey: validation.FormatPixKey(input.PixKey, input.PixKeyType),
 		},
-		Status: entity.Draft,
+		BankAccount: input.BankAccount.toEntity(),
+		Status:      entity.Draft,
+	}
+
+	if err := u.checkConsistency(&receiver); err != nil {
+		return nil, err
+	}
+
+	fieldErr, err := u.checkPixOwner(ctx, receiver.Identifier, receiver.Pix.Key)
+	if err != nil {
+		return nil, err
+	}
+	if fieldErr != nil {
+		return nil, &ValidationError{Errors: []*FieldError{fieldErr}}
+	}
+
+	// The duplicate check is best-effort: identifier_1 is not unique, since
+	// duplicates are allowed without a strategy, so concurrent creates with
+	// the same identifier can both pass it and be inserted.
+	if input.OnDuplicate != "" {
+		existing, err := u.receiverRepository.FindByIdentifier(ctx, receiver.Identifier)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return nil, err
+		}
+		if existing != nil {
+			result, err := u.resolveDuplicate(ctx, input.OnDuplicate, existing, &receiver)
+			if err != nil {
+				return nil, err
+			}
+			return u.withWarnings(result), nil
+		}
+	}
+
+	newReceiver, err := u.receiverRepository.Create(ctx, receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := newHistoryEntry(ctx, newReceiver.ID, entity.HistoryCreate, diffReceivers(nil, newReceiver))
+	if err := u.recordHistory(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return u.withWarnings(newReceiver), nil
+}
+
+// resolveDuplicate applies the strategy to an existing receiver with the
+// identifier of receiver, which is the one the input would create.
+func (u *receiverUseCase) resolveDuplicate(ctx context.Context, strategy DuplicateStrategy, existing *entity.Receiver, receiver *entity.Receiver) (*entity.Receiver, error) {
+	duplicateErr := &DuplicateReceiverError{Identifier: existing.Identifier, ReceiverID: existing.ID, Status: existing.Status}
+
+	switch strategy {
+	case DuplicateReturnExisting:
+		return existing, nil
+	case DuplicateUpdateDraft:
+		if existing.Status != entity.Draft {
+			return nil, duplicateErr
+		}
+	default:
+		return nil, duplicateErr
+	}
+
+	fieldsToUpdate := mergeFields(existing, receiver)
+	if len(fieldsToUpdate) == 0 {
+		return existing, nil
 	}
 
-	newReceiver, err := u.receiverRepository.Create(receiver)
+	err := u.receiverRepository.Update(ctx, existing.ID, existing.Version, fieldsToUpdate)
 	if err != nil {
 		return nil, err
 	}
 
-	return newReceiver, nil
+	changes := diffReceivers(existing, applyFields(*existing, fieldsToUpdate))
+	if err := u.recordHistory(ctx, newHistoryEntry(ctx, existing.ID, entity.HistoryUpdate, changes)); err != nil {
+		return nil, err
+	}
+
+	return u.receiverRepository.FindById(ctx, existing.ID)
+}
+
+// mergeFields returns the repository update fields that make existing match
+// receiver. The identifier is the same in both.
+func mergeFields(existing *entity.Receiver, receiver *entity.Receiver) map[string]string {
+	fieldsToUpdate := make(map[string]string)
+	if receiver.FormattedIdentifier != existing.FormattedIdentifier {
+		fieldsToUpdate["formatted_identifier"] = receiver.FormattedIdentifier
+	}
+	if receiver.Name != existing.Name {
+		fieldsToUpdate["name"] = receiver.Name
+	}
+	if receiver.Email != existing.Email {
+		fieldsToUpdate["email"] = receiver.Email
+	}
+	if receiver.Pix.KeyType != existing.Pix.KeyType {
+		fieldsToUpdate["key_type"] = string(receiver.Pix.KeyType)
+	}
+	if receiver.Pix.Key != existing.Pix.Key {
+		fieldsToUpdate["key"] = receiver.Pix.Key
+	}
+	if receiver.Pix.FormattedKey != existing.Pix.FormattedKey {
+		fieldsToUpdate["formatted_key"] = receiver.Pix.FormattedKey
+	}
+	if receiver.BankAccount != nil && (existing.BankAccount == nil || *receiver.BankAccount != *existing.BankAccount) {
+		addBankAccountFields(fieldsToUpdate, receiver.BankAccount)
+	}
+	return fieldsToUpdate
 }
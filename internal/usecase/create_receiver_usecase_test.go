@@ -1,152 +1,620 @@
 package usecase_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/magiconair/properties/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/teste-transfeera/internal/entity"
+	repositoryPkg "github.com/teste-transfeera/internal/repository"
 	"github.com/teste-transfeera/internal/usecase"
 	"github.com/teste-transfeera/mocks"
+	"github.com/teste-transfeera/pkg/actor"
+	"github.com/teste-transfeera/pkg/shared"
+	"github.com/teste-transfeera/pkg/validation"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 func Test_ReceiverUseCase_Create_Success(t *testing.T) {
 	repository := &mocks.ReceiverRepository{}
-	useCase := usecase.NewReceiverUseCases(repository)
+	history := &mocks.HistoryRepository{}
+	useCase := usecase.NewReceiverUseCases(repository, history)
+	ctx := context.Background()
 
 	t.Run("Create receiver successfully", func(t *testing.T) {
 		input := usecase.CreateReceiverInput{
-			Identifier: "111.111.111-11",
+			Identifier: "529.982.247-25",
 			Name:       "Receiver 1",
 			Email:      "RECEIVER1@GMAIL.COM",
 			PixKeyType: "CPF",
-			PixKey:     "111.111.111-11",
+			PixKey:     "529.982.247-25",
 		}
 		mockInput := entity.Receiver{
+			Identifier:          "52998224725",
+			FormattedIdentifier: "529.982.247-25",
+			Name:                input.Name,
+			Email:               "receiver1@gmail.com",
+			Status:              entity.Draft,
+			Pix: entity.Pix{
+				KeyType:      entity.PixKeyType(input.PixKeyType),
+				Key:          "52998224725",
+				FormattedKey: "529.982.247-25",
+			},
+		}
+		expectedResult := &entity.Receiver{
+			ID:                  uuid.New().String(),
+			Identifier:          "52998224725",
+			FormattedIdentifier: "529.982.247-25",
+			Name:                "Receiver 1",
+			Email:               "receiver1@gmail.com",
+			Status:              entity.Draft,
+			Pix: entity.Pix{
+				KeyType:      entity.CPF,
+				Key:          "52998224725",
+				FormattedKey: "529.982.247-25",
+			},
+		}
+		expectedEntry := entity.HistoryEntry{
+			ReceiverID: expectedResult.ID,
+			Actor:      actor.Anonymous,
+			Operation:  entity.HistoryCreate,
+			Changes: []entity.FieldChange{
+				{Field: "identifier", After: shared.GetPointerStr("52998224725")},
+				{Field: "name", After: shared.GetPointerStr("Receiver 1")},
+				{Field: "email", After: shared.GetPointerStr("receiver1@gmail.com")},
+				{Field: "pixKeyType", After: shared.GetPointerStr("CPF")},
+				{Field: "pixKey", After: shared.GetPointerStr("52998224725")},
+				{Field: "status", After: shared.GetPointerStr("Draft")},
+			},
+		}
+		repository.On("Create", ctx, mockInput).Return(expectedResult, nil).Once()
+		history.On("Append", ctx, expectedEntry).Return(nil).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, expectedResult, result)
+		assert.Equal(t, nil, err)
+		repository.AssertExpectations(t)
+		history.AssertExpectations(t)
+	})
+
+	t.Run("Create receiver with bank account of a bank without check digit algorithm as not verified", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier: "529.982.247-25",
+			Name:       "Receiver 1",
+			Email:      "RECEIVER1@GMAIL.COM",
+			PixKeyType: "CPF",
+			PixKey:     "529.982.247-25",
+			BankAccount: &usecase.BankAccountInput{
+				BankCode:     "748",
+				Agency:       "0101",
+				Account:      "123456",
+				AccountDigit: "0",
+				AccountType:  "CHECKING",
+			},
+		}
+		expectedResult := &entity.Receiver{ID: uuid.New().String()}
+		repository.On("Create", ctx, mock.MatchedBy(func(receiver entity.Receiver) bool {
+			return receiver.BankAccount.BankCode == "748" && !receiver.BankAccount.Verified
+		})).Return(expectedResult, nil).Once()
+		history.On("Append", ctx, mock.Anything).Return(nil).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, expectedResult, result)
+		assert.Equal(t, nil, err)
+		repository.AssertExpectations(t)
+		history.AssertExpectations(t)
+	})
+
+	t.Run("Create receiver with alphanumeric CNPJ successfully", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier: "12.ABC.345/01DE-35",
+			Name:       "Receiver 1",
+			Email:      "RECEIVER1@GMAIL.COM",
+			PixKeyType: "CNPJ",
+			PixKey:     "12.ABC.345/01DE-35",
+		}
+		expectedResult := &entity.Receiver{
+			ID:         uuid.New().String(),
 			Identifier: input.Identifier,
 			Name:       input.Name,
 			Email:      input.Email,
 			Status:     entity.Draft,
 			Pix: entity.Pix{
-				KeyType: entity.PixKeyType(input.PixKeyType),
+				KeyType: entity.CNPJ,
 				Key:     input.PixKey,
 			},
 		}
+		repository.On("Create", ctx, mock.Anything).Return(expectedResult, nil).Once()
+		history.On("Append", ctx, mock.Anything).Return(nil).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, expectedResult, result)
+		assert.Equal(t, nil, err)
+		repository.AssertExpectations(t)
+		history.AssertExpectations(t)
+	})
+
+	t.Run("Create receiver without duplicate creates a new receiver", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier:  "529.982.247-25",
+			Name:        "Receiver 1",
+			Email:       "RECEIVER1@GMAIL.COM",
+			PixKeyType:  "CPF",
+			PixKey:      "529.982.247-25",
+			OnDuplicate: usecase.DuplicateFail,
+		}
 		expectedResult := &entity.Receiver{
 			ID:         uuid.New().String(),
-			Identifier: "111.111.111-11",
+			Identifier: "52998224725",
+			Name:       "Receiver 1",
+			Email:      "receiver1@gmail.com",
+			Status:     entity.Draft,
+			Pix: entity.Pix{
+				KeyType: entity.CPF,
+				Key:     "52998224725",
+			},
+		}
+		repository.On("FindByIdentifier", ctx, "52998224725").Return(nil, mongo.ErrNoDocuments).Once()
+		repository.On("Create", ctx, mock.Anything).Return(expectedResult, nil).Once()
+		history.On("Append", ctx, mock.Anything).Return(nil).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, expectedResult, result)
+		assert.Equal(t, nil, err)
+		repository.AssertExpectations(t)
+		history.AssertExpectations(t)
+	})
+
+	t.Run("Create duplicate receiver returns the existing receiver", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier:  "52998224725",
+			Name:        "Receiver 2",
+			Email:       "RECEIVER2@GMAIL.COM",
+			PixKeyType:  "CPF",
+			PixKey:      "529.982.247-25",
+			OnDuplicate: usecase.DuplicateReturnExisting,
+		}
+		existing := &entity.Receiver{
+			ID:         "63f8c8d6c6ce914b5b00b88e",
+			Identifier: "52998224725",
+			Name:       "Receiver 1",
+			Email:      "receiver1@gmail.com",
+			Status:     entity.Validated,
+			Pix: entity.Pix{
+				KeyType: entity.CPF,
+				Key:     "52998224725",
+			},
+		}
+		repository.On("FindByIdentifier", ctx, "52998224725").Return(existing, nil).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, existing, result)
+		assert.Equal(t, nil, err)
+		repository.AssertExpectations(t)
+		history.AssertExpectations(t)
+	})
+
+	t.Run("Create duplicate receiver merges the input into the Draft", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier:  "529.982.247-25",
+			Name:        "Receiver 2",
+			Email:       "RECEIVER1@GMAIL.COM",
+			PixKeyType:  "EMAIL",
+			PixKey:      "RECEIVER2@GMAIL.COM",
+			OnDuplicate: usecase.DuplicateUpdateDraft,
+		}
+		existing := &entity.Receiver{
+			ID:                  "63f8c8d6c6ce914b5b00b88e",
+			Identifier:          "52998224725",
+			FormattedIdentifier: "529.982.247-25",
+			Name:                "Receiver 1",
+			Email:               "receiver1@gmail.com",
+			Status:              entity.Draft,
+			Pix: entity.Pix{
+				KeyType:      entity.CPF,
+				Key:          "52998224725",
+				FormattedKey: "529.982.247-25",
+			},
+			Version: 3,
+		}
+		fieldsToUpdate := map[string]string{
+			"name":          "Receiver 2",
+			"key_type":      "EMAIL",
+			"key":           "receiver2@gmail.com",
+			"formatted_key": "receiver2@gmail.com",
+		}
+		expectedEntry := entity.HistoryEntry{
+			ReceiverID: existing.ID,
+			Actor:      actor.Anonymous,
+			Operation:  entity.HistoryUpdate,
+			Changes: []entity.FieldChange{
+				{Field: "name", Before: shared.GetPointerStr("Receiver 1"), After: shared.GetPointerStr("Receiver 2")},
+				{Field: "pixKeyType", Before: shared.GetPointerStr("CPF"), After: shared.GetPointerStr("EMAIL")},
+				{Field: "pixKey", Before: shared.GetPointerStr("52998224725"), After: shared.GetPointerStr("receiver2@gmail.com")},
+			},
+		}
+		updated := &entity.Receiver{ID: existing.ID, Name: "Receiver 2", Version: 4}
+		repository.On("FindByIdentifier", ctx, "52998224725").Return(existing, nil).Once()
+		repository.On("Update", ctx, existing.ID, int64(3), fieldsToUpdate).Return(nil).Once()
+		history.On("Append", ctx, expectedEntry).Return(nil).Once()
+		repository.On("FindById", ctx, existing.ID).Return(updated, nil).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, updated, result)
+		assert.Equal(t, nil, err)
+		repository.AssertExpectations(t)
+		history.AssertExpectations(t)
+	})
+
+	t.Run("Create duplicate receiver equal to the Draft returns it unchanged", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier:  "529.982.247-25",
+			Name:        "Receiver 1",
+			Email:       "receiver1@gmail.com",
+			PixKeyType:  "CPF",
+			PixKey:      "529.982.247-25",
+			OnDuplicate: usecase.DuplicateUpdateDraft,
+		}
+		existing := &entity.Receiver{
+			ID:                  "63f8c8d6c6ce914b5b00b88e",
+			Identifier:          "52998224725",
+			FormattedIdentifier: "529.982.247-25",
+			Name:                "Receiver 1",
+			Email:               "receiver1@gmail.com",
+			Status:              entity.Draft,
+			Pix: entity.Pix{
+				KeyType:      entity.CPF,
+				Key:          "52998224725",
+				FormattedKey: "529.982.247-25",
+			},
+		}
+		repository.On("FindByIdentifier", ctx, "52998224725").Return(existing, nil).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, existing, result)
+		assert.Equal(t, nil, err)
+		repository.AssertExpectations(t)
+		history.AssertExpectations(t)
+	})
+
+	t.Run("Create receiver records the actor of the request", func(t *testing.T) {
+		actorCtx := actor.WithActor(ctx, "maria@transfeera.com")
+		input := usecase.CreateReceiverInput{
+			Identifier: "529.982.247-25",
+			Name:       "Receiver 1",
+			Email:      "RECEIVER1@GMAIL.COM",
+			PixKeyType: "CPF",
+			PixKey:     "529.982.247-25",
+		}
+		expectedResult := &entity.Receiver{
+			ID:         uuid.New().String(),
+			Identifier: "529.982.247-25",
 			Name:       "Receiver 1",
 			Email:      "RECEIVER1@GMAIL.COM",
 			Status:     entity.Draft,
 			Pix: entity.Pix{
 				KeyType: entity.CPF,
-				Key:     "111.111.111-11",
+				Key:     "529.982.247-25",
 			},
 		}
-		repository.On("Create", mockInput).Return(expectedResult, nil).Once()
+		repository.On("Create", actorCtx, mock.Anything).Return(expectedResult, nil).Once()
+		history.On("Append", actorCtx, mock.MatchedBy(func(entry entity.HistoryEntry) bool {
+			return entry.Actor == "maria@transfeera.com" && entry.ReceiverID == expectedResult.ID
+		})).Return(nil).Once()
 
-		result, err := useCase.Create(&input)
+		_, err := useCase.Create(actorCtx, &input)
+
+		assert.Equal(t, nil, err)
+		repository.AssertExpectations(t)
+		history.AssertExpectations(t)
+	})
+
+	t.Run("Create receiver with bank account successfully", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier: "529.982.247-25",
+			Name:       "Receiver 1",
+			Email:      "RECEIVER1@GMAIL.COM",
+			PixKeyType: "CPF",
+			PixKey:     "529.982.247-25",
+			BankAccount: &usecase.BankAccountInput{
+				BankCode:     "001",
+				Agency:       "0006",
+				AgencyDigit:  "x",
+				Account:      "210169",
+				AccountDigit: "6",
+				AccountType:  "CHECKING",
+			},
+		}
+		expectedAccount := &entity.BankAccount{
+			BankCode:     "001",
+			ISPB:         "00000000",
+			Agency:       "0006",
+			AgencyDigit:  "X",
+			Account:      "210169",
+			AccountDigit: "6",
+			AccountType:  entity.Checking,
+			Verified:     true,
+		}
+		expectedResult := &entity.Receiver{
+			ID:          uuid.New().String(),
+			Identifier:  "52998224725",
+			Name:        "Receiver 1",
+			Email:       "receiver1@gmail.com",
+			Status:      entity.Draft,
+			BankAccount: expectedAccount,
+		}
+		repository.On("Create", ctx, mock.MatchedBy(func(receiver entity.Receiver) bool {
+			return receiver.BankAccount != nil && *receiver.BankAccount == *expectedAccount
+		})).Return(expectedResult, nil).Once()
+		history.On("Append", ctx, mock.MatchedBy(func(entry entity.HistoryEntry) bool {
+			for _, change := range entry.Changes {
+				if change.Field == "agency" {
+					return *change.After == "0006-X"
+				}
+			}
+			return false
+		})).Return(nil).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, expectedResult, result)
+		assert.Equal(t, nil, err)
+		repository.AssertExpectations(t)
+		history.AssertExpectations(t)
+	})
+
+	t.Run("Create receiver without the agency digit leaves the account unverified", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier: "529.982.247-25",
+			Name:       "Receiver 1",
+			Email:      "RECEIVER1@GMAIL.COM",
+			PixKeyType: "CPF",
+			PixKey:     "529.982.247-25",
+			BankAccount: &usecase.BankAccountInput{
+				BankCode:     "001",
+				Agency:       "0006",
+				Account:      "210169",
+				AccountDigit: "6",
+				AccountType:  "CHECKING",
+			},
+		}
+		expectedResult := &entity.Receiver{
+			ID:         uuid.New().String(),
+			Identifier: "52998224725",
+			Name:       "Receiver 1",
+			Email:      "receiver1@gmail.com",
+			Status:     entity.Draft,
+		}
+		repository.On("Create", ctx, mock.MatchedBy(func(receiver entity.Receiver) bool {
+			return receiver.BankAccount != nil && receiver.BankAccount.AgencyDigit == "" && !receiver.BankAccount.Verified
+		})).Return(expectedResult, nil).Once()
+		history.On("Append", ctx, mock.Anything).Return(nil).Once()
+
+		result, err := useCase.Create(ctx, &input)
 
 		assert.Equal(t, expectedResult, result)
 		assert.Equal(t, nil, err)
 		repository.AssertExpectations(t)
+		history.AssertExpectations(t)
 	})
 }
 
 func Test_ReceiverUseCase_Create_Error(t *testing.T) {
 	repository := &mocks.ReceiverRepository{}
-	useCase := usecase.NewReceiverUseCases(repository)
+	history := &mocks.HistoryRepository{}
+	useCase := usecase.NewReceiverUseCases(repository, history)
+	ctx := context.Background()
 
 	t.Run("Create receiver returns error from repository", func(t *testing.T) {
 		input := usecase.CreateReceiverInput{
-			Identifier: "111.111.111-11",
+			Identifier: "529.982.247-25",
 			Name:       "Receiver 1",
 			Email:      "RECEIVER1@GMAIL.COM",
 			PixKeyType: "CPF",
-			PixKey:     "111.111.111-11",
+			PixKey:     "529.982.247-25",
 		}
 		mockInput := entity.Receiver{
-			Identifier: input.Identifier,
-			Name:       input.Name,
-			Email:      input.Email,
-			Status:     entity.Draft,
+			Identifier:          "52998224725",
+			FormattedIdentifier: input.Identifier,
+			Name:                input.Name,
+			Email:               "receiver1@gmail.com",
+			Status:              entity.Draft,
 			Pix: entity.Pix{
-				KeyType: entity.PixKeyType(input.PixKeyType),
-				Key:     input.PixKey,
+				KeyType:      entity.PixKeyType(input.PixKeyType),
+				Key:          "52998224725",
+				FormattedKey: input.PixKey,
 			},
 		}
 		expectedError := errors.New("error")
-		repository.On("Create", mockInput).Return(nil, errors.New("error")).Once()
+		repository.On("Create", ctx, mockInput).Return(nil, errors.New("error")).Once()
 
-		result, err := useCase.Create(&input)
+		result, err := useCase.Create(ctx, &input)
 
 		assert.Equal(t, (*entity.Receiver)(nil), result)
 		assert.Equal(t, expectedError, err)
 		repository.AssertExpectations(t)
 	})
 
+	t.Run("Create receiver with a pix key in use returns duplicate error", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier: "529.982.247-25",
+			Name:       "Receiver 1",
+			Email:      "RECEIVER1@GMAIL.COM",
+			PixKeyType: "EMAIL",
+			PixKey:     "Receiver1@Gmail.com",
+		}
+		expectedError := &repositoryPkg.DuplicatePixKeyError{Key: "receiver1@gmail.com", ReceiverID: "63f8c8d6c6ce914b5b00b88e"}
+		repository.On("Create", ctx, mock.MatchedBy(func(receiver entity.Receiver) bool {
+			return receiver.Pix.Key == "receiver1@gmail.com"
+		})).Return(nil, expectedError).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, (*entity.Receiver)(nil), result)
+		assert.Equal(t, expectedError, err)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Create duplicate receiver with FAIL returns duplicate error", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier:  "529.982.247-25",
+			Name:        "Receiver 1",
+			Email:       "RECEIVER1@GMAIL.COM",
+			PixKeyType:  "CPF",
+			PixKey:      "529.982.247-25",
+			OnDuplicate: usecase.DuplicateFail,
+		}
+		existing := &entity.Receiver{ID: "63f8c8d6c6ce914b5b00b88e", Identifier: "52998224725", Status: entity.Draft}
+		expectedError := &usecase.DuplicateReceiverError{Identifier: "52998224725", ReceiverID: existing.ID, Status: entity.Draft}
+		repository.On("FindByIdentifier", ctx, "52998224725").Return(existing, nil).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, (*entity.Receiver)(nil), result)
+		assert.Equal(t, expectedError, err)
+		assert.Equal(t, "Receiver 63f8c8d6c6ce914b5b00b88e with identifier 52998224725 already exists", err.Error())
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Create duplicate of a Validated receiver with UPDATE_DRAFT returns duplicate error", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier:  "529.982.247-25",
+			Name:        "Receiver 2",
+			Email:       "RECEIVER1@GMAIL.COM",
+			PixKeyType:  "CPF",
+			PixKey:      "529.982.247-25",
+			OnDuplicate: usecase.DuplicateUpdateDraft,
+		}
+		existing := &entity.Receiver{ID: "63f8c8d6c6ce914b5b00b88e", Identifier: "52998224725", Status: entity.Validated}
+		expectedError := &usecase.DuplicateReceiverError{Identifier: "52998224725", ReceiverID: existing.ID, Status: entity.Validated}
+		repository.On("FindByIdentifier", ctx, "52998224725").Return(existing, nil).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, (*entity.Receiver)(nil), result)
+		assert.Equal(t, expectedError, err)
+		assert.Equal(t, "Receiver 63f8c8d6c6ce914b5b00b88e with identifier 52998224725 already exists with status Validated", err.Error())
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Create receiver returns error from duplicate lookup", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier:  "529.982.247-25",
+			Name:        "Receiver 1",
+			Email:       "RECEIVER1@GMAIL.COM",
+			PixKeyType:  "CPF",
+			PixKey:      "529.982.247-25",
+			OnDuplicate: usecase.DuplicateReturnExisting,
+		}
+		expectedError := errors.New("error")
+		repository.On("FindByIdentifier", ctx, "52998224725").Return(nil, errors.New("error")).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, (*entity.Receiver)(nil), result)
+		assert.Equal(t, expectedError, err)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Create receiver returns validation error for duplicate strategy", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier:  "529.982.247-25",
+			Name:        "Receiver 1",
+			Email:       "RECEIVER1@GMAIL.COM",
+			PixKeyType:  "CPF",
+			PixKey:      "529.982.247-25",
+			OnDuplicate: "IGNORE",
+		}
+		expectedErrors := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_INVALID_VALUE, Field: "onDuplicate", Message: "On Duplicate must be one of FAIL, RETURN_EXISTING, UPDATE_DRAFT"},
+		}
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, (*entity.Receiver)(nil), result)
+		assert.Equal(t, expectedErrors, fieldErrors(t, err))
+	})
+
 	t.Run("Create receiver returns validation error for pix key", func(t *testing.T) {
 		input := usecase.CreateReceiverInput{
-			Identifier: "111.111.111-11",
+			Identifier: "529.982.247-25",
 			Name:       "Receiver 1",
 			Email:      "RECEIVER1@GMAIL.COM",
 			PixKeyType: "EMAIL",
-			PixKey:     "a@a",
+			PixKey:     "a@",
 		}
-		expectedError := errors.New(`Key: 'CreateReceiverInput.PixKey' Error:Field validation for 'PixKey' failed on the 'validatePixKey' tag`)
-		result, err := useCase.Create(&input)
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_INVALID_FORMAT, Field: "pixKey", Message: "Pix Key does not match the Pix Key Type"},
+		}
+		result, err := useCase.Create(ctx, &input)
 
 		assert.Equal(t, (*entity.Receiver)(nil), result)
-		assert.Equal(t, expectedError.Error(), err.Error())
+		assert.Equal(t, expectedError, fieldErrors(t, err))
 		repository.AssertExpectations(t)
 	})
 
 	t.Run("Create receiver returns validation error for pix key type", func(t *testing.T) {
 		input := usecase.CreateReceiverInput{
-			Identifier: "111.111.111-11",
+			Identifier: "529.982.247-25",
 			Name:       "Receiver 1",
 			Email:      "RECEIVER1@GMAIL.COM",
 			PixKeyType: "CPFF",
-			PixKey:     "111.111.111-11",
+			PixKey:     "529.982.247-25",
 		}
-		expectedError := errors.New("Key: 'CreateReceiverInput.PixKeyType' Error:Field validation for 'PixKeyType' failed on the 'validatePixType' tag\nKey: 'CreateReceiverInput.PixKey' Error:Field validation for 'PixKey' failed on the 'validatePixKey' tag")
-		result, err := useCase.Create(&input)
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_INVALID_VALUE, Field: "pixKeyType", Message: "Pix Key Type must be one of CPF, CNPJ, EMAIL, TELEFONE or CHAVE_ALEATORIA"},
+			{Code: usecase.ERROR_CODE_INVALID_FORMAT, Field: "pixKey", Message: "Pix Key does not match the Pix Key Type"},
+		}
+		result, err := useCase.Create(ctx, &input)
 
 		assert.Equal(t, (*entity.Receiver)(nil), result)
-		assert.Equal(t, expectedError.Error(), err.Error())
+		assert.Equal(t, expectedError, fieldErrors(t, err))
 		repository.AssertExpectations(t)
 	})
 
 	t.Run("Create receiver returns validation error for email", func(t *testing.T) {
 		input := usecase.CreateReceiverInput{
-			Identifier: "111.111.111-11",
+			Identifier: "529.982.247-25",
 			Name:       "Receiver 1",
-			Email:      "receiver1@gmail.com",
+			Email:      "receiver1@",
 			PixKeyType: "EMAIL",
 			PixKey:     "A@A",
 		}
-		expectedError := errors.New(`Key: 'CreateReceiverInput.Email' Error:Field validation for 'Email' failed on the 'validateEmail' tag`)
-		result, err := useCase.Create(&input)
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_INVALID_FORMAT, Field: "email", Message: "Email must be a valid email"},
+		}
+		result, err := useCase.Create(ctx, &input)
 
 		assert.Equal(t, (*entity.Receiver)(nil), result)
-		assert.Equal(t, expectedError.Error(), err.Error())
+		assert.Equal(t, expectedError, fieldErrors(t, err))
 		repository.AssertExpectations(t)
 	})
 
 	t.Run("Create receiver returns validation error for email with more than 250 characters", func(t *testing.T) {
 		input := usecase.CreateReceiverInput{
-			Identifier: "111.111.111-11",
+			Identifier: "529.982.247-25",
 			Name:       "Receiver 1",
 			Email:      "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA@AA",
 			PixKeyType: "EMAIL",
 			PixKey:     "A@A",
 		}
-		expectedError := errors.New(`Key: 'CreateReceiverInput.Email' Error:Field validation for 'Email' failed on the 'max' tag`)
-		result, err := useCase.Create(&input)
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_TOO_LONG, Field: "email", Message: "Email must have at most 250 characters"},
+		}
+		result, err := useCase.Create(ctx, &input)
 
 		assert.Equal(t, (*entity.Receiver)(nil), result)
-		assert.Equal(t, expectedError.Error(), err.Error())
+		assert.Equal(t, expectedError, fieldErrors(t, err))
 		repository.AssertExpectations(t)
 	})
 
@@ -158,26 +626,194 @@ func Test_ReceiverUseCase_Create_Error(t *testing.T) {
 			PixKeyType: "EMAIL",
 			PixKey:     "A@A",
 		}
-		expectedError := errors.New(`Key: 'CreateReceiverInput.Identifier' Error:Field validation for 'Identifier' failed on the 'validateIdentifier' tag`)
-		result, err := useCase.Create(&input)
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_INVALID_FORMAT, Field: "identifier", Message: "Identifier must be a CPF or CNPJ"},
+		}
+		result, err := useCase.Create(ctx, &input)
 
 		assert.Equal(t, (*entity.Receiver)(nil), result)
-		assert.Equal(t, expectedError.Error(), err.Error())
+		assert.Equal(t, expectedError, fieldErrors(t, err))
 		repository.AssertExpectations(t)
 	})
 
-	t.Run("Create receiver returns validation error for name", func(t *testing.T) {
+	t.Run("Create receiver returns check digit error for identifier", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier: "529.982.247-24",
+			Name:       "Receiver 1",
+			Email:      "RECEIVER1@GMAIL.COM",
+			PixKeyType: "EMAIL",
+			PixKey:     "A@A",
+		}
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, (*entity.Receiver)(nil), result)
+		assert.Equal(t, []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_INVALID_CHECK_DIGITS, Field: "identifier", Message: "Invalid Identifier: CPF check digits are invalid"},
+		}, fieldErrors(t, err))
+		assert.Equal(t, true, errors.Is(err, validation.ErrInvalidCPFCheckDigits))
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Create receiver returns repeated digits error for Pix Key", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier: "11.222.333/0001-81",
+			Name:       "Receiver 1",
+			Email:      "RECEIVER1@GMAIL.COM",
+			PixKeyType: "CNPJ",
+			PixKey:     "11.111.111/1111-11",
+		}
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, (*entity.Receiver)(nil), result)
+		assert.Equal(t, []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_REPEATED_DIGITS, Field: "pixKey", Message: "Invalid Pix Key for CNPJ Key Type: CNPJ must not be a sequence of repeated digits"},
+		}, fieldErrors(t, err))
+		assert.Equal(t, true, errors.Is(err, validation.ErrCNPJRepeatedDigits))
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Create receiver returns every field error together", func(t *testing.T) {
 		input := usecase.CreateReceiverInput{
 			Identifier: "111.111.111-11",
+			Email:      "receiver1@",
+			PixKeyType: "CPF",
+			PixKey:     "529.982.247-24",
+		}
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_REQUIRED, Field: "name", Message: "Name is required"},
+			{Code: usecase.ERROR_CODE_INVALID_FORMAT, Field: "email", Message: "Email must be a valid email"},
+			{Code: usecase.ERROR_CODE_REPEATED_DIGITS, Field: "identifier", Message: "Invalid Identifier: CPF must not be a sequence of repeated digits"},
+			{Code: usecase.ERROR_CODE_INVALID_CHECK_DIGITS, Field: "pixKey", Message: "Invalid Pix Key for CPF Key Type: CPF check digits are invalid"},
+		}
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, (*entity.Receiver)(nil), result)
+		assert.Equal(t, expectedError, fieldErrors(t, err))
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Create receiver returns validation error for name", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier: "529.982.247-25",
 			Email:      "RECEIVER1@GMAIL.COM",
 			PixKeyType: "EMAIL",
 			PixKey:     "A@A",
 		}
-		expectedError := errors.New(`Key: 'CreateReceiverInput.Name' Error:Field validation for 'Name' failed on the 'required' tag`)
-		result, err := useCase.Create(&input)
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_REQUIRED, Field: "name", Message: "Name is required"},
+		}
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, (*entity.Receiver)(nil), result)
+		assert.Equal(t, expectedError, fieldErrors(t, err))
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Create receiver returns validation error for unknown bank", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier: "529.982.247-25",
+			Name:       "Receiver 1",
+			Email:      "RECEIVER1@GMAIL.COM",
+			PixKeyType: "CPF",
+			PixKey:     "529.982.247-25",
+			BankAccount: &usecase.BankAccountInput{
+				BankCode:     "999",
+				Agency:       "0814",
+				Account:      "12345",
+				AccountDigit: "6",
+				AccountType:  "CHECKING",
+			},
+		}
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_INVALID_VALUE, Field: "bankAccount.bankCode", Message: "Bank Code is not a known bank"},
+		}
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, (*entity.Receiver)(nil), result)
+		assert.Equal(t, expectedError, fieldErrors(t, err))
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Create receiver returns check digit error for bank account", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier: "529.982.247-25",
+			Name:       "Receiver 1",
+			Email:      "RECEIVER1@GMAIL.COM",
+			PixKeyType: "CPF",
+			PixKey:     "529.982.247-25",
+			BankAccount: &usecase.BankAccountInput{
+				BankCode:     "341",
+				Agency:       "2545",
+				Account:      "02366",
+				AccountDigit: "2",
+				AccountType:  "CHECKING",
+			},
+		}
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_INVALID_CHECK_DIGITS, Field: "bankAccount.accountDigit", Message: "Account check digit is invalid"},
+		}
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, (*entity.Receiver)(nil), result)
+		assert.Equal(t, expectedError, fieldErrors(t, err))
+		assert.Equal(t, true, errors.Is(err, validation.ErrInvalidAccountCheckDigit))
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Create receiver returns validation errors for bank account", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier: "529.982.247-25",
+			Name:       "Receiver 1",
+			Email:      "RECEIVER1@GMAIL.COM",
+			PixKeyType: "CPF",
+			PixKey:     "529.982.247-25",
+			BankAccount: &usecase.BankAccountInput{
+				BankCode:     "1A",
+				Agency:       "0814",
+				Account:      "12345",
+				AccountDigit: "66",
+				AccountType:  "INVESTMENT",
+			},
+		}
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_INVALID_FORMAT, Field: "bankAccount.bankCode", Message: "Bank Code must have 3 characters"},
+			{Code: usecase.ERROR_CODE_INVALID_FORMAT, Field: "bankAccount.accountDigit", Message: "Account Digit must be a digit, X or P"},
+			{Code: usecase.ERROR_CODE_INVALID_VALUE, Field: "bankAccount.accountType", Message: "Account Type must be one of CHECKING, SAVINGS, PAYMENT"},
+		}
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, (*entity.Receiver)(nil), result)
+		assert.Equal(t, expectedError, fieldErrors(t, err))
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Create receiver returns error from history", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier: "529.982.247-25",
+			Name:       "Receiver 1",
+			Email:      "RECEIVER1@GMAIL.COM",
+			PixKeyType: "CPF",
+			PixKey:     "529.982.247-25",
+		}
+		createdReceiver := &entity.Receiver{
+			ID:         uuid.New().String(),
+			Identifier: "529.982.247-25",
+			Name:       "Receiver 1",
+			Email:      "RECEIVER1@GMAIL.COM",
+			Status:     entity.Draft,
+			Pix: entity.Pix{
+				KeyType: entity.CPF,
+				Key:     "529.982.247-25",
+			},
+		}
+		repository.On("Create", ctx, mock.Anything).Return(createdReceiver, nil).Once()
+		history.On("Append", ctx, mock.Anything).Return(errors.New("error")).Once()
+
+		result, err := useCase.Create(ctx, &input)
 
 		assert.Equal(t, (*entity.Receiver)(nil), result)
-		assert.Equal(t, expectedError.Error(), err.Error())
+		assert.Equal(t, errors.New("error"), err)
 		repository.AssertExpectations(t)
+		history.AssertExpectations(t)
 	})
 }
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/repository"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type CreateTransferInput struct {
+	ReceiverId  string                `validate:"required"`
+	Amount      int64                 `validate:"required,min=1"`
+	Method      entity.TransferMethod `validate:"required,oneof=PIX TED"`
+	Description string                `validate:"omitempty,max=140"`
+	// IdempotencyKey makes retries return the transfer the first request
+	// created instead of creating another one.
+	IdempotencyKey string `validate:"omitempty,max=255"`
+}
+
+// IdempotencyKeyReusedError is returned when an idempotency key is sent again
+// with a different receiver, amount or method than its transfer.
+type IdempotencyKeyReusedError struct {
+	Key        string
+	TransferID string
+}
+
+func (e *IdempotencyKeyReusedError) Error() string {
+	return fmt.Sprintf("Idempotency key %s was used for transfer %s with other data", e.Key, e.TransferID)
+}
+
+// Create registers a transfer to a Validated receiver. Deleted receivers are
+// not found. The destination is copied from the receiver: its pix key for
+// Pix transfers and its bank account for TED ones. A request repeating an
+// idempotency key returns the transfer created with it.
+func (u *transferUseCase) Create(ctx context.Context, input *CreateTransferInput) (*entity.Transfer, error) {
+	if err := validateInput(input).orNil(); err != nil {
+		return nil, err
+	}
+
+	if input.IdempotencyKey != "" {
+		existing, err := u.idempotentTransfer(ctx, input)
+		if existing != nil || err != nil {
+			return existing, err
+		}
+	}
+
+	receiver, err := u.receiverRepository.FindById(ctx, input.ReceiverId)
+	if err != nil {
+		return nil, err
+	}
+
+	if receiver.Status != entity.Validated {
+		return nil, &ReceiverNotValidatedError{ID: receiver.ID, Status: receiver.Status}
+	}
+
+	transfer := entity.Transfer{
+		ReceiverID:     receiver.ID,
+		Amount:         input.Amount,
+		Method:         input.Method,
+		Status:         entity.TransferCreated,
+		Description:    input.Description,
+		IdempotencyKey: input.IdempotencyKey,
+	}
+
+	switch input.Method {
+	case entity.TransferPix:
+		if receiver.Pix.Key == "" {
+			return nil, destinationError(fmt.Sprintf("Receiver %s has no pix key", receiver.ID))
+		}
+		transfer.PixKey = receiver.Pix.Key
+	case entity.TransferTED:
+		if receiver.BankAccount == nil {
+			return nil, destinationError(fmt.Sprintf("Receiver %s has no bank account", receiver.ID))
+		}
+		account := *receiver.BankAccount
+		transfer.BankAccount = &account
+	}
+
+	created, err := u.transferRepository.Create(ctx, transfer)
+	if errors.Is(err, repository.ErrDuplicateIdempotencyKey) {
+		return u.idempotentTransfer(ctx, input)
+	}
+	return created, err
+}
+
+// idempotentTransfer returns the transfer created with the input's
+// idempotency key, or nil when there is none yet.
+func (u *transferUseCase) idempotentTransfer(ctx context.Context, input *CreateTransferInput) (*entity.Transfer, error) {
+	existing, err := u.transferRepository.FindByIdempotencyKey(ctx, input.IdempotencyKey)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if existing.ReceiverID != input.ReceiverId || existing.Amount != input.Amount || existing.Method != input.Method {
+		return nil, &IdempotencyKeyReusedError{Key: input.IdempotencyKey, TransferID: existing.ID}
+	}
+
+	return existing, nil
+}
+
+func destinationError(message string) error {
+	return &ValidationError{Errors: []*FieldError{{Code: ERROR_CODE_INVALID_VALUE, Field: "method", Message: message}}}
+}
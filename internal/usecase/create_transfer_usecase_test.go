@@ -0,0 +1,315 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/teste-transfeera/internal/entity"
+	repositoryPkg "github.com/teste-transfeera/internal/repository"
+	"github.com/teste-transfeera/internal/usecase"
+	"github.com/teste-transfeera/mocks"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func Test_TransferUseCase_Create_Success(t *testing.T) {
+	transfers := &mocks.TransferRepository{}
+	receivers := &mocks.ReceiverRepository{}
+	useCase := usecase.NewTransferUseCases(transfers, receivers)
+	ctx := context.Background()
+
+	t.Run("Create Pix transfer to the receiver pix key successfully", func(t *testing.T) {
+		input := usecase.CreateTransferInput{
+			ReceiverId:  "63f8c8d6c6ce914b5b00b88e",
+			Amount:      1050,
+			Method:      entity.TransferPix,
+			Description: "Aluguel",
+		}
+		transfer := entity.Transfer{
+			ReceiverID:  input.ReceiverId,
+			Amount:      1050,
+			Method:      entity.TransferPix,
+			Status:      entity.TransferCreated,
+			Description: "Aluguel",
+			PixKey:      "52998224725",
+		}
+		created := transfer
+		created.ID = "63f8c8d6c6ce914b5b00b900"
+		receivers.On("FindById", ctx, input.ReceiverId).Return(statusReceiver(entity.Validated), nil).Once()
+		transfers.On("Create", ctx, transfer).Return(&created, nil).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, &created, result)
+		assert.Equal(t, nil, err)
+		receivers.AssertExpectations(t)
+		transfers.AssertExpectations(t)
+	})
+
+	t.Run("Create TED transfer to the receiver bank account successfully", func(t *testing.T) {
+		input := usecase.CreateTransferInput{
+			ReceiverId: "63f8c8d6c6ce914b5b00b88e",
+			Amount:     250000,
+			Method:     entity.TransferTED,
+		}
+		receiver := statusReceiver(entity.Validated)
+		receiver.BankAccount = &entity.BankAccount{
+			BankCode:     "001",
+			Agency:       "0814",
+			AgencyDigit:  "0",
+			Account:      "01002713",
+			AccountDigit: "9",
+			AccountType:  entity.Checking,
+		}
+		transfer := entity.Transfer{
+			ReceiverID:  input.ReceiverId,
+			Amount:      250000,
+			Method:      entity.TransferTED,
+			Status:      entity.TransferCreated,
+			BankAccount: receiver.BankAccount,
+		}
+		created := transfer
+		created.ID = "63f8c8d6c6ce914b5b00b900"
+		receivers.On("FindById", ctx, input.ReceiverId).Return(receiver, nil).Once()
+		transfers.On("Create", ctx, transfer).Return(&created, nil).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, &created, result)
+		assert.Equal(t, nil, err)
+		receivers.AssertExpectations(t)
+		transfers.AssertExpectations(t)
+	})
+
+	t.Run("Create transfer with a new idempotency key stores the key", func(t *testing.T) {
+		input := usecase.CreateTransferInput{
+			ReceiverId:     "63f8c8d6c6ce914b5b00b88e",
+			Amount:         1050,
+			Method:         entity.TransferPix,
+			IdempotencyKey: "order-1",
+		}
+		transfer := entity.Transfer{
+			ReceiverID:     input.ReceiverId,
+			Amount:         1050,
+			Method:         entity.TransferPix,
+			Status:         entity.TransferCreated,
+			PixKey:         "52998224725",
+			IdempotencyKey: "order-1",
+		}
+		created := transfer
+		created.ID = "63f8c8d6c6ce914b5b00b900"
+		transfers.On("FindByIdempotencyKey", ctx, "order-1").Return(nil, mongo.ErrNoDocuments).Once()
+		receivers.On("FindById", ctx, input.ReceiverId).Return(statusReceiver(entity.Validated), nil).Once()
+		transfers.On("Create", ctx, transfer).Return(&created, nil).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, &created, result)
+		assert.Equal(t, nil, err)
+		receivers.AssertExpectations(t)
+		transfers.AssertExpectations(t)
+	})
+
+	t.Run("Create transfer with a used idempotency key returns the existing transfer", func(t *testing.T) {
+		input := usecase.CreateTransferInput{
+			ReceiverId:     "63f8c8d6c6ce914b5b00b88e",
+			Amount:         1050,
+			Method:         entity.TransferPix,
+			IdempotencyKey: "order-1",
+		}
+		existing := &entity.Transfer{
+			ID:             "63f8c8d6c6ce914b5b00b900",
+			ReceiverID:     input.ReceiverId,
+			Amount:         1050,
+			Method:         entity.TransferPix,
+			Status:         entity.TransferProcessing,
+			IdempotencyKey: "order-1",
+		}
+		transfers.On("FindByIdempotencyKey", ctx, "order-1").Return(existing, nil).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, existing, result)
+		assert.Equal(t, nil, err)
+		transfers.AssertExpectations(t)
+	})
+
+	t.Run("Create transfer losing the race for an idempotency key returns the winner", func(t *testing.T) {
+		input := usecase.CreateTransferInput{
+			ReceiverId:     "63f8c8d6c6ce914b5b00b88e",
+			Amount:         1050,
+			Method:         entity.TransferPix,
+			IdempotencyKey: "order-2",
+		}
+		existing := &entity.Transfer{
+			ID:             "63f8c8d6c6ce914b5b00b901",
+			ReceiverID:     input.ReceiverId,
+			Amount:         1050,
+			Method:         entity.TransferPix,
+			Status:         entity.TransferCreated,
+			IdempotencyKey: "order-2",
+		}
+		transfers.On("FindByIdempotencyKey", ctx, "order-2").Return(nil, mongo.ErrNoDocuments).Once()
+		receivers.On("FindById", ctx, input.ReceiverId).Return(statusReceiver(entity.Validated), nil).Once()
+		transfers.On("Create", ctx, mock.Anything).Return(nil, repositoryPkg.ErrDuplicateIdempotencyKey).Once()
+		transfers.On("FindByIdempotencyKey", ctx, "order-2").Return(existing, nil).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, existing, result)
+		assert.Equal(t, nil, err)
+		receivers.AssertExpectations(t)
+		transfers.AssertExpectations(t)
+	})
+}
+
+func Test_TransferUseCase_Create_Error(t *testing.T) {
+	transfers := &mocks.TransferRepository{}
+	receivers := &mocks.ReceiverRepository{}
+	useCase := usecase.NewTransferUseCases(transfers, receivers)
+	ctx := context.Background()
+
+	t.Run("Create transfer returns validation errors for every invalid field", func(t *testing.T) {
+		input := usecase.CreateTransferInput{
+			Amount:      -1,
+			Method:      "BOLETO",
+			Description: strings.Repeat("a", 141),
+		}
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_REQUIRED, Field: "receiverId", Message: "Receiver Id is required"},
+			{Code: usecase.ERROR_CODE_INVALID_VALUE, Field: "amount", Message: "Amount must be at least 1"},
+			{Code: usecase.ERROR_CODE_INVALID_VALUE, Field: "method", Message: "Method must be one of PIX, TED"},
+			{Code: usecase.ERROR_CODE_TOO_LONG, Field: "description", Message: "Description must have at most 140 characters"},
+		}
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, (*entity.Transfer)(nil), result)
+		assert.Equal(t, expectedError, fieldErrors(t, err))
+	})
+
+	for _, status := range []entity.Status{entity.Draft, entity.Blocked, entity.Archived} {
+		status := status
+		t.Run("Create transfer to "+string(status)+" receiver returns error", func(t *testing.T) {
+			input := usecase.CreateTransferInput{
+				ReceiverId: "63f8c8d6c6ce914b5b00b88e",
+				Amount:     1050,
+				Method:     entity.TransferPix,
+			}
+			expectedError := &usecase.ReceiverNotValidatedError{ID: input.ReceiverId, Status: status}
+			receivers.On("FindById", ctx, input.ReceiverId).Return(statusReceiver(status), nil).Once()
+
+			result, err := useCase.Create(ctx, &input)
+
+			assert.Equal(t, (*entity.Transfer)(nil), result)
+			assert.Equal(t, expectedError, err)
+			assert.Equal(t, "Receiver 63f8c8d6c6ce914b5b00b88e with status "+string(status)+" cannot receive transfers", err.Error())
+			receivers.AssertExpectations(t)
+			transfers.AssertNotCalled(t, "Create")
+		})
+	}
+
+	t.Run("Create transfer to deleted receiver returns not found", func(t *testing.T) {
+		input := usecase.CreateTransferInput{
+			ReceiverId: "63f8c8d6c6ce914b5b00b88e",
+			Amount:     1050,
+			Method:     entity.TransferPix,
+		}
+		receivers.On("FindById", ctx, input.ReceiverId).Return(nil, mongo.ErrNoDocuments).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, (*entity.Transfer)(nil), result)
+		assert.Equal(t, mongo.ErrNoDocuments, err)
+		receivers.AssertExpectations(t)
+	})
+
+	t.Run("Create TED transfer to receiver without bank account returns validation error", func(t *testing.T) {
+		input := usecase.CreateTransferInput{
+			ReceiverId: "63f8c8d6c6ce914b5b00b88e",
+			Amount:     1050,
+			Method:     entity.TransferTED,
+		}
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_INVALID_VALUE, Field: "method", Message: "Receiver 63f8c8d6c6ce914b5b00b88e has no bank account"},
+		}
+		receivers.On("FindById", ctx, input.ReceiverId).Return(statusReceiver(entity.Validated), nil).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, (*entity.Transfer)(nil), result)
+		assert.Equal(t, expectedError, fieldErrors(t, err))
+		receivers.AssertExpectations(t)
+	})
+
+	t.Run("Create Pix transfer to receiver without pix key returns validation error", func(t *testing.T) {
+		input := usecase.CreateTransferInput{
+			ReceiverId: "63f8c8d6c6ce914b5b00b88e",
+			Amount:     1050,
+			Method:     entity.TransferPix,
+		}
+		receiver := statusReceiver(entity.Validated)
+		receiver.Pix = entity.Pix{}
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_INVALID_VALUE, Field: "method", Message: "Receiver 63f8c8d6c6ce914b5b00b88e has no pix key"},
+		}
+		receivers.On("FindById", ctx, input.ReceiverId).Return(receiver, nil).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, (*entity.Transfer)(nil), result)
+		assert.Equal(t, expectedError, fieldErrors(t, err))
+		receivers.AssertExpectations(t)
+	})
+
+	t.Run("Create transfer reusing an idempotency key with other data returns error", func(t *testing.T) {
+		input := usecase.CreateTransferInput{
+			ReceiverId:     "63f8c8d6c6ce914b5b00b88e",
+			Amount:         2000,
+			Method:         entity.TransferPix,
+			IdempotencyKey: "order-1",
+		}
+		existing := &entity.Transfer{
+			ID:             "63f8c8d6c6ce914b5b00b900",
+			ReceiverID:     input.ReceiverId,
+			Amount:         1050,
+			Method:         entity.TransferPix,
+			IdempotencyKey: "order-1",
+		}
+		expectedError := &usecase.IdempotencyKeyReusedError{Key: "order-1", TransferID: existing.ID}
+		transfers.On("FindByIdempotencyKey", ctx, "order-1").Return(existing, nil).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, (*entity.Transfer)(nil), result)
+		assert.Equal(t, expectedError, err)
+		transfers.AssertExpectations(t)
+	})
+
+	t.Run("Create transfer returns error from repository", func(t *testing.T) {
+		input := usecase.CreateTransferInput{
+			ReceiverId: "63f8c8d6c6ce914b5b00b88e",
+			Amount:     1050,
+			Method:     entity.TransferPix,
+		}
+		expectedError := errors.New("error")
+		receivers.On("FindById", ctx, input.ReceiverId).Return(statusReceiver(entity.Validated), nil).Once()
+		transfers.On("Create", ctx, entity.Transfer{
+			ReceiverID: input.ReceiverId,
+			Amount:     1050,
+			Method:     entity.TransferPix,
+			Status:     entity.TransferCreated,
+			PixKey:     "52998224725",
+		}).Return(nil, errors.New("error")).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, (*entity.Transfer)(nil), result)
+		assert.Equal(t, expectedError, err)
+		receivers.AssertExpectations(t)
+		transfers.AssertExpectations(t)
+	})
+}
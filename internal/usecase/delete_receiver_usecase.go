@@ -1,29 +1,47 @@
 package usecase
 
 import (
-	"errors"
+	"context"
 
-	"github.com/go-playground/validator/v10"
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/pkg/shared"
 )
 
 type DeleteReceiverInput struct {
 	Ids []string `validate:"required"`
 }
 
-func (u *receiverUseCase) Delete(input *DeleteReceiverInput) error {
-	err := validator.New().Struct(input)
-	if err != nil {
+func (u *receiverUseCase) Delete(ctx context.Context, input *DeleteReceiverInput) error {
+	if err := validateInput(input).orNil(); err != nil {
 		return err
 	}
 
 	if len(input.Ids) == 0 {
-		return errors.New("At leat one id is required to delete receiver")
+		return &ValidationError{Errors: []*FieldError{
+			{Code: ERROR_CODE_REQUIRED, Field: "ids", Message: "At leat one id is required to delete receiver"},
+		}}
+	}
+
+	// Only receivers that were live get a history entry: ids that do not
+	// exist or were already deleted are left out.
+	receivers, err := u.receiverRepository.FindByIds(ctx, input.Ids)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]entity.HistoryEntry, 0, len(receivers))
+	for _, receiver := range receivers {
+		changes := []entity.FieldChange{{Field: "deleted", Before: shared.GetPointerStr("false"), After: shared.GetPointerStr("true")}}
+		entries = append(entries, newHistoryEntry(ctx, receiver.ID, entity.HistoryDelete, changes))
 	}
 
-	err = u.receiverRepository.Delete(input.Ids)
+	err = u.receiverRepository.Delete(ctx, input.Ids)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	if len(entries) == 0 {
+		return nil
+	}
+	return u.recordHistory(ctx, entries...)
 }
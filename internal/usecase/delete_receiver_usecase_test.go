@@ -1,43 +1,81 @@
 package usecase_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
 	"github.com/magiconair/properties/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/teste-transfeera/internal/entity"
 	"github.com/teste-transfeera/internal/usecase"
 	"github.com/teste-transfeera/mocks"
+	"github.com/teste-transfeera/pkg/actor"
+	"github.com/teste-transfeera/pkg/shared"
 )
 
 func Test_ReceiverUseCase_Delete_Success(t *testing.T) {
 	repository := &mocks.ReceiverRepository{}
-	useCase := usecase.NewReceiverUseCases(repository)
+	history := &mocks.HistoryRepository{}
+	useCase := usecase.NewReceiverUseCases(repository, history)
+	ctx := context.Background()
 
 	t.Run("Delete receiver by id successfully", func(t *testing.T) {
 		input := usecase.DeleteReceiverInput{
 			Ids: []string{"63f8c8d6c6ce914b5b00b88e"},
 		}
-		repository.On("Delete", input.Ids).Return(nil).Once()
+		expectedEntry := entity.HistoryEntry{
+			ReceiverID: "63f8c8d6c6ce914b5b00b88e",
+			Actor:      actor.Anonymous,
+			Operation:  entity.HistoryDelete,
+			Changes: []entity.FieldChange{
+				{Field: "deleted", Before: shared.GetPointerStr("false"), After: shared.GetPointerStr("true")},
+			},
+		}
+		repository.On("FindByIds", ctx, input.Ids).Return([]entity.Receiver{{ID: input.Ids[0]}}, nil).Once()
+		repository.On("Delete", ctx, input.Ids).Return(nil).Once()
+		history.On("Append", ctx, expectedEntry).Return(nil).Once()
+
+		err := useCase.Delete(ctx, &input)
+
+		assert.Equal(t, nil, err)
+		repository.AssertExpectations(t)
+		history.AssertExpectations(t)
+	})
+
+	t.Run("Delete receivers records history only for live receivers", func(t *testing.T) {
+		input := usecase.DeleteReceiverInput{
+			Ids: []string{"63f8c8d6c6ce914b5b00b88e", "63f8c8d6c6ce914b5b00b88f"},
+		}
+		repository.On("FindByIds", ctx, input.Ids).Return([]entity.Receiver{{ID: input.Ids[1]}}, nil).Once()
+		repository.On("Delete", ctx, input.Ids).Return(nil).Once()
+		history.On("Append", ctx, mock.MatchedBy(func(entry entity.HistoryEntry) bool {
+			return entry.ReceiverID == input.Ids[1]
+		})).Return(nil).Once()
 
-		err := useCase.Delete(&input)
+		err := useCase.Delete(ctx, &input)
 
 		assert.Equal(t, nil, err)
 		repository.AssertExpectations(t)
+		history.AssertExpectations(t)
 	})
 }
 
 func Test_ReceiverUseCase_Delete_Error(t *testing.T) {
 	repository := &mocks.ReceiverRepository{}
-	useCase := usecase.NewReceiverUseCases(repository)
+	history := &mocks.HistoryRepository{}
+	useCase := usecase.NewReceiverUseCases(repository, history)
+	ctx := context.Background()
 
 	t.Run("Delete receiver by id returns error from repository", func(t *testing.T) {
 		input := usecase.DeleteReceiverInput{
 			Ids: []string{"63f8c8d6c6ce914b5b00b88e"},
 		}
 		expectedError := errors.New("error")
-		repository.On("Delete", input.Ids).Return(errors.New("error")).Once()
+		repository.On("FindByIds", ctx, input.Ids).Return([]entity.Receiver{{ID: input.Ids[0]}}, nil).Once()
+		repository.On("Delete", ctx, input.Ids).Return(errors.New("error")).Once()
 
-		err := useCase.Delete(&input)
+		err := useCase.Delete(ctx, &input)
 
 		assert.Equal(t, expectedError, err)
 		repository.AssertExpectations(t)
@@ -47,20 +85,38 @@ func Test_ReceiverUseCase_Delete_Error(t *testing.T) {
 		input := usecase.DeleteReceiverInput{
 			Ids: []string{},
 		}
-		expectedError := errors.New("At leat one id is required to delete receiver")
-		err := useCase.Delete(&input)
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_REQUIRED, Field: "ids", Message: "At leat one id is required to delete receiver"},
+		}
+		err := useCase.Delete(ctx, &input)
 
-		assert.Equal(t, expectedError.Error(), err.Error())
+		assert.Equal(t, expectedError, fieldErrors(t, err))
 		repository.AssertExpectations(t)
 	})
 
 	t.Run("Delete receiver by id returns validation error for id", func(t *testing.T) {
 		input := usecase.DeleteReceiverInput{}
-		expectedError := errors.New("Key: 'DeleteReceiverInput.Ids' Error:Field validation for 'Ids' failed on the 'required' tag")
-		err := useCase.Delete(&input)
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_REQUIRED, Field: "ids", Message: "Ids is required"},
+		}
+		err := useCase.Delete(ctx, &input)
 
-		assert.Equal(t, expectedError.Error(), err.Error())
+		assert.Equal(t, expectedError, fieldErrors(t, err))
 		repository.AssertExpectations(t)
 	})
 
+	t.Run("Delete receiver returns error from history", func(t *testing.T) {
+		input := usecase.DeleteReceiverInput{
+			Ids: []string{"63f8c8d6c6ce914b5b00b88e"},
+		}
+		repository.On("FindByIds", ctx, input.Ids).Return([]entity.Receiver{{ID: input.Ids[0]}}, nil).Once()
+		repository.On("Delete", ctx, input.Ids).Return(nil).Once()
+		history.On("Append", ctx, mock.Anything).Return(errors.New("error")).Once()
+
+		err := useCase.Delete(ctx, &input)
+
+		assert.Equal(t, errors.New("error"), err)
+		repository.AssertExpectations(t)
+		history.AssertExpectations(t)
+	})
 }
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/teste-transfeera/pkg/pix"
+)
+
+// DefaultPixMerchantCity is the city written in BR Codes unless
+// WithPixMerchantCity sets another. Receivers have no address, and the city
+// is only shown to the payer.
+const DefaultPixMerchantCity = "SAO PAULO"
+
+type GeneratePixBRCodeInput struct {
+	Id string `validate:"required"`
+	// Amount is in cents. Without it the payer chooses the amount.
+	Amount      *int64 `validate:"omitempty,min=1"`
+	Txid        string `validate:"omitempty,max=25,alphanum"`
+	Description string `validate:"omitempty,max=72"`
+}
+
+// GeneratePixBRCode builds the static BR Code of a charge to the receiver's
+// pix key, to be shown as a QR code or copied and pasted.
+func (u *receiverUseCase) GeneratePixBRCode(ctx context.Context, input *GeneratePixBRCodeInput) (string, error) {
+	if err := validateInput(input).orNil(); err != nil {
+		return "", err
+	}
+
+	receiver, err := u.receiverRepository.FindById(ctx, input.Id)
+	if err != nil {
+		return "", err
+	}
+
+	if receiver.Pix.Key == "" {
+		return "", fmt.Errorf("Receiver %s has no Pix key", receiver.ID)
+	}
+
+	code := pix.BRCode{
+		Key:         receiver.Pix.Key,
+		Name:        receiver.Name,
+		City:        u.pixMerchantCity,
+		TxID:        input.Txid,
+		Description: input.Description,
+	}
+	if input.Amount != nil {
+		code.Amount = *input.Amount
+	}
+
+	payload, err := code.Payload()
+	if errors.Is(err, pix.ErrMerchantAccountTooLong) {
+		return "", &ValidationError{Errors: []*FieldError{
+			{Code: ERROR_CODE_TOO_LONG, Field: "description", Message: err.Error(), Err: err},
+		}}
+	}
+	if errors.Is(err, pix.ErrInvalidMerchantName) {
+		return "", &ValidationError{Errors: []*FieldError{
+			{Code: ERROR_CODE_INVALID_VALUE, Field: "name", Message: err.Error(), Err: err},
+		}}
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,156 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/usecase"
+	"github.com/teste-transfeera/mocks"
+	"github.com/teste-transfeera/pkg/pix"
+	"github.com/teste-transfeera/pkg/shared"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func Test_ReceiverUseCase_GeneratePixBRCode_Success(t *testing.T) {
+	repository := &mocks.ReceiverRepository{}
+	history := &mocks.HistoryRepository{}
+	useCase := usecase.NewReceiverUseCases(repository, history)
+	ctx := context.Background()
+
+	t.Run("Generate BR Code of a receiver successfully", func(t *testing.T) {
+		input := &usecase.GeneratePixBRCodeInput{
+			Id:          "63f8c8d6c6ce914b5b00b88e",
+			Amount:      shared.GetPointerInt64(1050),
+			Txid:        "PEDIDO123",
+			Description: "Pagamento",
+		}
+		receiver := &entity.Receiver{
+			ID:   input.Id,
+			Name: "João da Silva",
+			Pix:  entity.Pix{KeyType: entity.CPF, Key: "52998224725"},
+		}
+		expectedResult, _ := pix.BRCode{
+			Key:         "52998224725",
+			Name:        "João da Silva",
+			City:        "SAO PAULO",
+			Amount:      1050,
+			TxID:        "PEDIDO123",
+			Description: "Pagamento",
+		}.Payload()
+		repository.On("FindById", ctx, input.Id).Return(receiver, nil).Once()
+
+		result, err := useCase.GeneratePixBRCode(ctx, input)
+
+		assert.Equal(t, expectedResult, result)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, true, strings.Contains(result, "5913Joao da Silva6009SAO PAULO"))
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Generate BR Code with the configured city successfully", func(t *testing.T) {
+		useCase := usecase.NewReceiverUseCases(repository, history, usecase.WithPixMerchantCity("Florianópolis"))
+		input := &usecase.GeneratePixBRCodeInput{Id: "63f8c8d6c6ce914b5b00b88e"}
+		receiver := &entity.Receiver{
+			ID:   input.Id,
+			Name: "João da Silva",
+			Pix:  entity.Pix{KeyType: entity.CPF, Key: "52998224725"},
+		}
+		repository.On("FindById", ctx, input.Id).Return(receiver, nil).Once()
+
+		result, err := useCase.GeneratePixBRCode(ctx, input)
+
+		assert.Equal(t, nil, err)
+		assert.Equal(t, true, strings.Contains(result, "5913Joao da Silva6013Florianopolis"))
+		repository.AssertExpectations(t)
+	})
+}
+
+func Test_ReceiverUseCase_GeneratePixBRCode_Error(t *testing.T) {
+	repository := &mocks.ReceiverRepository{}
+	history := &mocks.HistoryRepository{}
+	useCase := usecase.NewReceiverUseCases(repository, history)
+	ctx := context.Background()
+
+	t.Run("Generate BR Code returns validation errors for amount and txid", func(t *testing.T) {
+		input := &usecase.GeneratePixBRCodeInput{
+			Id:     "63f8c8d6c6ce914b5b00b88e",
+			Amount: shared.GetPointerInt64(0),
+			Txid:   "pedido-1",
+		}
+		expectedErrors := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_INVALID_VALUE, Field: "amount", Message: "Amount must be at least 1"},
+			{Code: usecase.ERROR_CODE_INVALID_FORMAT, Field: "txid", Message: "Txid must contain only letters and digits"},
+		}
+
+		result, err := useCase.GeneratePixBRCode(ctx, input)
+
+		assert.Equal(t, "", result)
+		assert.Equal(t, expectedErrors, fieldErrors(t, err))
+	})
+
+	t.Run("Generate BR Code returns validation error for description too long for the key", func(t *testing.T) {
+		input := &usecase.GeneratePixBRCodeInput{
+			Id:          "63f8c8d6c6ce914b5b00b88e",
+			Description: strings.Repeat("a", 50),
+		}
+		receiver := &entity.Receiver{
+			ID:  input.Id,
+			Pix: entity.Pix{KeyType: entity.Email, Key: "a.very.long.email.address.for.pix@transfeera.com"},
+		}
+		expectedErrors := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_TOO_LONG, Field: "description", Message: pix.ErrMerchantAccountTooLong.Error()},
+		}
+		repository.On("FindById", ctx, input.Id).Return(receiver, nil).Once()
+
+		result, err := useCase.GeneratePixBRCode(ctx, input)
+
+		assert.Equal(t, "", result)
+		assert.Equal(t, expectedErrors, fieldErrors(t, err))
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Generate BR Code returns validation error for a name with no Latin letters", func(t *testing.T) {
+		input := &usecase.GeneratePixBRCodeInput{Id: "63f8c8d6c6ce914b5b00b88e"}
+		receiver := &entity.Receiver{
+			ID:   input.Id,
+			Name: "张伟",
+			Pix:  entity.Pix{KeyType: entity.CPF, Key: "52998224725"},
+		}
+		expectedErrors := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_INVALID_VALUE, Field: "name", Message: pix.ErrInvalidMerchantName.Error()},
+		}
+		repository.On("FindById", ctx, input.Id).Return(receiver, nil).Once()
+
+		result, err := useCase.GeneratePixBRCode(ctx, input)
+
+		assert.Equal(t, "", result)
+		assert.Equal(t, expectedErrors, fieldErrors(t, err))
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Generate BR Code of a receiver without pix key returns error", func(t *testing.T) {
+		input := &usecase.GeneratePixBRCodeInput{Id: "63f8c8d6c6ce914b5b00b88e"}
+		repository.On("FindById", ctx, input.Id).Return(&entity.Receiver{ID: input.Id}, nil).Once()
+
+		result, err := useCase.GeneratePixBRCode(ctx, input)
+
+		assert.Equal(t, "", result)
+		assert.Equal(t, errors.New("Receiver 63f8c8d6c6ce914b5b00b88e has no Pix key"), err)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Generate BR Code returns error from repository", func(t *testing.T) {
+		input := &usecase.GeneratePixBRCodeInput{Id: "63f8c8d6c6ce914b5b00b88e"}
+		repository.On("FindById", ctx, input.Id).Return(nil, mongo.ErrNoDocuments).Once()
+
+		result, err := useCase.GeneratePixBRCode(ctx, input)
+
+		assert.Equal(t, "", result)
+		assert.Equal(t, mongo.ErrNoDocuments, err)
+		repository.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/teste-transfeera/pkg/bank"
+)
+
+type ListBanksInput struct {
+	Search string
+}
+
+// ListBanks returns the institutions of the bank directory matching the
+// search, ordered by COMPE code.
+func (u *receiverUseCase) ListBanks(ctx context.Context, input *ListBanksInput) ([]bank.Institution, error) {
+	return bank.Search(input.Search), nil
+}
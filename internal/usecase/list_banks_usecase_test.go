@@ -0,0 +1,36 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/teste-transfeera/internal/usecase"
+	"github.com/teste-transfeera/mocks"
+	"github.com/teste-transfeera/pkg/bank"
+)
+
+func Test_ReceiverUseCase_ListBanks_Success(t *testing.T) {
+	repository := &mocks.ReceiverRepository{}
+	history := &mocks.HistoryRepository{}
+	useCase := usecase.NewReceiverUseCases(repository, history)
+	ctx := context.Background()
+
+	t.Run("List banks matching the search successfully", func(t *testing.T) {
+		expectedResult := []bank.Institution{
+			{Code: "104", ISPB: "00360305", ShortName: "CAIXA ECONOMICA FEDERAL", Name: "Caixa Econômica Federal", PixParticipant: true},
+		}
+
+		result, err := useCase.ListBanks(ctx, &usecase.ListBanksInput{Search: "caixa"})
+
+		assert.Equal(t, expectedResult, result)
+		assert.Equal(t, nil, err)
+	})
+
+	t.Run("List every bank without search successfully", func(t *testing.T) {
+		result, err := useCase.ListBanks(ctx, &usecase.ListBanksInput{})
+
+		assert.Equal(t, bank.Search(""), result)
+		assert.Equal(t, nil, err)
+	})
+}
@@ -1,7 +1,8 @@
 package usecase
 
 import (
-	"github.com/go-playground/validator/v10"
+	"context"
+
 	"github.com/teste-transfeera/internal/entity"
 )
 
@@ -9,15 +10,14 @@ type ListReceiverByIdInput struct {
 	Id string `validate:"required"`
 }
 
-func (u *receiverUseCase) ListById(input *ListReceiverByIdInput) (*entity.Receiver, error) {
-	err := validator.New().Struct(input)
-	if err != nil {
+func (u *receiverUseCase) ListById(ctx context.Context, input *ListReceiverByIdInput) (*entity.Receiver, error) {
+	if err := validateInput(input).orNil(); err != nil {
 		return nil, err
 	}
-	receiver, err := u.receiverRepository.FindById(input.Id)
+	receiver, err := u.receiverRepository.FindById(ctx, input.Id)
 	if err != nil {
 		return nil, err
 	}
 
-	return receiver, nil
+	return u.withWarnings(receiver), nil
 }
@@ -1,6 +1,7 @@
 package usecase_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -12,7 +13,9 @@ import (
 
 func Test_ReceiverUseCase_ListById_Success(t *testing.T) {
 	repository := &mocks.ReceiverRepository{}
-	useCase := usecase.NewReceiverUseCases(repository)
+	history := &mocks.HistoryRepository{}
+	useCase := usecase.NewReceiverUseCases(repository, history)
+	ctx := context.Background()
 
 	t.Run("List receiver by id successfully", func(t *testing.T) {
 		input := usecase.ListReceiverByIdInput{
@@ -20,18 +23,18 @@ func Test_ReceiverUseCase_ListById_Success(t *testing.T) {
 		}
 		expectedResult := &entity.Receiver{
 			ID:         input.Id,
-			Identifier: "111.111.111-11",
+			Identifier: "529.982.247-25",
 			Name:       "Receiver 1",
 			Email:      "RECEIVER1@GMAIL.COM",
 			Status:     entity.Draft,
 			Pix: entity.Pix{
 				KeyType: entity.CPF,
-				Key:     "111.111.111-11",
+				Key:     "529.982.247-25",
 			},
 		}
-		repository.On("FindById", input.Id).Return(expectedResult, nil).Once()
+		repository.On("FindById", ctx, input.Id).Return(expectedResult, nil).Once()
 
-		result, err := useCase.ListById(&input)
+		result, err := useCase.ListById(ctx, &input)
 
 		assert.Equal(t, expectedResult, result)
 		assert.Equal(t, nil, err)
@@ -41,16 +44,18 @@ func Test_ReceiverUseCase_ListById_Success(t *testing.T) {
 
 func Test_ReceiverUseCase_ListById_Error(t *testing.T) {
 	repository := &mocks.ReceiverRepository{}
-	useCase := usecase.NewReceiverUseCases(repository)
+	history := &mocks.HistoryRepository{}
+	useCase := usecase.NewReceiverUseCases(repository, history)
+	ctx := context.Background()
 
 	t.Run("List receiver by id returns error from repository", func(t *testing.T) {
 		input := usecase.ListReceiverByIdInput{
 			Id: "63f8c8d6c6ce914b5b00b88e",
 		}
 		expectedError := errors.New("error")
-		repository.On("FindById", input.Id).Return(nil, errors.New("error")).Once()
+		repository.On("FindById", ctx, input.Id).Return(nil, errors.New("error")).Once()
 
-		result, err := useCase.ListById(&input)
+		result, err := useCase.ListById(ctx, &input)
 
 		assert.Equal(t, (*entity.Receiver)(nil), result)
 		assert.Equal(t, expectedError, err)
@@ -59,11 +64,13 @@ func Test_ReceiverUseCase_ListById_Error(t *testing.T) {
 
 	t.Run("List receiver by id returns validation error for id", func(t *testing.T) {
 		input := usecase.ListReceiverByIdInput{}
-		expectedError := errors.New(`Key: 'ListReceiverByIdInput.Id' Error:Field validation for 'Id' failed on the 'required' tag`)
-		result, err := useCase.ListById(&input)
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_REQUIRED, Field: "id", Message: "Id is required"},
+		}
+		result, err := useCase.ListById(ctx, &input)
 
 		assert.Equal(t, (*entity.Receiver)(nil), result)
-		assert.Equal(t, expectedError.Error(), err.Error())
+		assert.Equal(t, expectedError, fieldErrors(t, err))
 		repository.AssertExpectations(t)
 	})
 
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/teste-transfeera/internal/entity"
+)
+
+type ListReceiverHistoryInput struct {
+	ReceiverId string `validate:"required"`
+	Limit      int
+	After      *string
+}
+
+// ListHistory returns the history of a receiver oldest first. Deleted
+// receivers keep their history.
+func (u *receiverUseCase) ListHistory(ctx context.Context, input *ListReceiverHistoryInput) (*entity.HistoryPage, error) {
+	if err := validateInput(input).orNil(); err != nil {
+		return nil, err
+	}
+
+	if input.Limit < 0 {
+		return nil, errors.New("first must not be negative")
+	}
+
+	history, err := u.historyRepository.List(ctx, input.ReceiverId, input.Limit, input.After)
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
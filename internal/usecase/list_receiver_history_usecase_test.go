@@ -0,0 +1,100 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/usecase"
+	"github.com/teste-transfeera/mocks"
+	"github.com/teste-transfeera/pkg/shared"
+)
+
+func Test_ReceiverUseCase_ListHistory_Success(t *testing.T) {
+	repository := &mocks.ReceiverRepository{}
+	history := &mocks.HistoryRepository{}
+	useCase := usecase.NewReceiverUseCases(repository, history)
+	ctx := context.Background()
+
+	t.Run("List receiver history successfully", func(t *testing.T) {
+		input := usecase.ListReceiverHistoryInput{
+			ReceiverId: "63f8c8d6c6ce914b5b00b88e",
+			Limit:      2,
+			After:      shared.GetPointerStr("63f8c8d6c6ce914b5b00b890"),
+		}
+		expectedResult := &entity.HistoryPage{
+			Entries: []entity.HistoryEntry{
+				{
+					ID:         "63f8c8d6c6ce914b5b00b891",
+					ReceiverID: input.ReceiverId,
+					Actor:      "maria@transfeera.com",
+					Operation:  entity.HistoryUpdate,
+					Changes: []entity.FieldChange{
+						{Field: "email", Before: shared.GetPointerStr("RECEIVER1@GMAIL.COM"), After: shared.GetPointerStr("RECEIVER2@GMAIL.COM")},
+					},
+					CreatedAt: time.Date(2023, 2, 24, 12, 0, 0, 0, time.UTC),
+				},
+			},
+			HasNextPage: false,
+		}
+		history.On("List", ctx, input.ReceiverId, input.Limit, input.After).Return(expectedResult, nil).Once()
+
+		result, err := useCase.ListHistory(ctx, &input)
+
+		assert.Equal(t, expectedResult, result)
+		assert.Equal(t, nil, err)
+		history.AssertExpectations(t)
+	})
+}
+
+func Test_ReceiverUseCase_ListHistory_Error(t *testing.T) {
+	repository := &mocks.ReceiverRepository{}
+	history := &mocks.HistoryRepository{}
+	useCase := usecase.NewReceiverUseCases(repository, history)
+	ctx := context.Background()
+
+	t.Run("List receiver history returns error from repository", func(t *testing.T) {
+		input := usecase.ListReceiverHistoryInput{
+			ReceiverId: "63f8c8d6c6ce914b5b00b88e",
+			Limit:      10,
+		}
+		expectedError := errors.New("error")
+		history.On("List", ctx, input.ReceiverId, input.Limit, input.After).Return(nil, errors.New("error")).Once()
+
+		result, err := useCase.ListHistory(ctx, &input)
+
+		assert.Equal(t, (*entity.HistoryPage)(nil), result)
+		assert.Equal(t, expectedError, err)
+		history.AssertExpectations(t)
+	})
+
+	t.Run("List receiver history returns error for negative limit", func(t *testing.T) {
+		input := usecase.ListReceiverHistoryInput{
+			ReceiverId: "63f8c8d6c6ce914b5b00b88e",
+			Limit:      -1,
+		}
+		expectedError := errors.New("first must not be negative")
+
+		result, err := useCase.ListHistory(ctx, &input)
+
+		assert.Equal(t, (*entity.HistoryPage)(nil), result)
+		assert.Equal(t, expectedError, err)
+		history.AssertExpectations(t)
+	})
+
+	t.Run("List receiver history returns validation error for id", func(t *testing.T) {
+		input := usecase.ListReceiverHistoryInput{}
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_REQUIRED, Field: "receiverId", Message: "Receiver Id is required"},
+		}
+
+		result, err := useCase.ListHistory(ctx, &input)
+
+		assert.Equal(t, (*entity.HistoryPage)(nil), result)
+		assert.Equal(t, expectedError, fieldErrors(t, err))
+		history.AssertExpectations(t)
+	})
+}
@@ -1,14 +1,42 @@
 package usecase
 
 import (
+	"context"
+
 	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/pkg/validation"
 )
 
-func (u *receiverUseCase) List(filter map[string]string) ([]entity.Receiver, error) {
-	receivers, err := u.receiverRepository.List(filter)
+func (u *receiverUseCase) List(ctx context.Context, filter map[string]string, page entity.PageRequest) (*entity.ReceiverPage, error) {
+	receivers, err := u.receiverRepository.List(ctx, normalizeFilter(filter), page)
 	if err != nil {
 		return nil, err
 	}
 
+	for i := range receivers.Receivers {
+		u.withWarnings(&receivers.Receivers[i])
+	}
+
 	return receivers, nil
 }
+
+// normalizeFilter puts the key filter in the canonical form the keys are
+// stored in. Without a key type, the key is normalized as the first type it
+// is valid for.
+func normalizeFilter(filter map[string]string) map[string]string {
+	if filter["key"] == "" {
+		return filter
+	}
+
+	normalized := make(map[string]string, len(filter))
+	for field, value := range filter {
+		normalized[field] = value
+	}
+	if filter["key_type"] != "" {
+		normalized["key"] = validation.NormalizePixKey(filter["key"], filter["key_type"])
+	} else {
+		normalized["key"] = validation.NormalizeAnyPixKey(filter["key"])
+	}
+
+	return normalized
+}
@@ -1,6 +1,7 @@
 package usecase_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -13,25 +14,28 @@ import (
 
 func Test_ReceiverUseCase_List_Success(t *testing.T) {
 	repository := &mocks.ReceiverRepository{}
-	useCase := usecase.NewReceiverUseCases(repository)
+	history := &mocks.HistoryRepository{}
+	useCase := usecase.NewReceiverUseCases(repository, history)
+	ctx := context.Background()
 
 	t.Run("List all receivers successfully", func(t *testing.T) {
 		input := map[string]string{}
-		expectedResult := []entity.Receiver{
+		page := entity.PageRequest{Limit: 10}
+		receivers := []entity.Receiver{
 			{
 				ID:         uuid.New().String(),
-				Identifier: "111.111.111-11",
+				Identifier: "529.982.247-25",
 				Name:       "Receiver 1",
 				Email:      "receiver1@gmail.com",
 				Status:     entity.Draft,
 				Pix: entity.Pix{
 					KeyType: entity.CPF,
-					Key:     "111.111.111-11",
+					Key:     "529.982.247-25",
 				},
 			},
 			{
 				ID:         uuid.New().String(),
-				Identifier: "222.222.222-22",
+				Identifier: "123.456.789-09",
 				Name:       "Receiver 2",
 				Email:      "receiver2@gmail.com",
 				Status:     entity.Validated,
@@ -41,28 +45,50 @@ func Test_ReceiverUseCase_List_Success(t *testing.T) {
 				},
 			},
 		}
-		repository.On("List", input).Return(expectedResult, nil).Once()
+		expectedResult := &entity.ReceiverPage{
+			Receivers:   receivers,
+			HasNextPage: true,
+		}
+		repository.On("List", ctx, input, page).Return(expectedResult, nil).Once()
 
-		result, err := useCase.List(input)
+		result, err := useCase.List(ctx, input, page)
 
 		assert.Equal(t, expectedResult, result)
 		assert.Equal(t, nil, err)
 		repository.AssertExpectations(t)
 	})
+
+	t.Run("List receivers normalizes the key filter", func(t *testing.T) {
+		page := entity.PageRequest{Limit: 10}
+		expectedResult := &entity.ReceiverPage{Receivers: []entity.Receiver{}}
+		repository.On("List", ctx, map[string]string{"key_type": "TELEFONE", "key": "+5548991000001"}, page).Return(expectedResult, nil).Once()
+		repository.On("List", ctx, map[string]string{"key": "29055159026"}, page).Return(expectedResult, nil).Once()
+
+		_, err := useCase.List(ctx, map[string]string{"key_type": "TELEFONE", "key": "48991000001"}, page)
+		assert.Equal(t, nil, err)
+
+		_, err = useCase.List(ctx, map[string]string{"key": "290.551.590-26"}, page)
+		assert.Equal(t, nil, err)
+
+		repository.AssertExpectations(t)
+	})
 }
 
 func Test_ReceiverUseCase_List_Error(t *testing.T) {
 	repository := &mocks.ReceiverRepository{}
-	useCase := usecase.NewReceiverUseCases(repository)
+	history := &mocks.HistoryRepository{}
+	useCase := usecase.NewReceiverUseCases(repository, history)
+	ctx := context.Background()
 
 	t.Run("List all receivers returns error from repository", func(t *testing.T) {
 		input := map[string]string{}
-		repository.On("List", input).Return(nil, errors.New("error")).Once()
+		page := entity.PageRequest{Limit: 10}
+		repository.On("List", ctx, input, page).Return(nil, errors.New("error")).Once()
 		expectedError := errors.New("error")
 
-		result, err := useCase.List(input)
+		result, err := useCase.List(ctx, input, page)
 
-		assert.Equal(t, []entity.Receiver(nil), result)
+		assert.Equal(t, (*entity.ReceiverPage)(nil), result)
 		assert.Equal(t, expectedError, err)
 		repository.AssertExpectations(t)
 	})
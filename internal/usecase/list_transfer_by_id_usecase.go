@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/teste-transfeera/internal/entity"
+)
+
+type ListTransferByIdInput struct {
+	Id string `validate:"required"`
+}
+
+func (u *transferUseCase) ListById(ctx context.Context, input *ListTransferByIdInput) (*entity.Transfer, error) {
+	if err := validateInput(input).orNil(); err != nil {
+		return nil, err
+	}
+
+	return u.transferRepository.FindById(ctx, input.Id)
+}
@@ -0,0 +1,60 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/usecase"
+	"github.com/teste-transfeera/mocks"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func Test_TransferUseCase_ListById(t *testing.T) {
+	transfers := &mocks.TransferRepository{}
+	receivers := &mocks.ReceiverRepository{}
+	useCase := usecase.NewTransferUseCases(transfers, receivers)
+	ctx := context.Background()
+
+	t.Run("List transfer by id successfully", func(t *testing.T) {
+		input := usecase.ListTransferByIdInput{Id: "63f8c8d6c6ce914b5b00b900"}
+		expectedResult := &entity.Transfer{
+			ID:         input.Id,
+			ReceiverID: "63f8c8d6c6ce914b5b00b88e",
+			Amount:     1050,
+			Method:     entity.TransferPix,
+			Status:     entity.TransferCreated,
+			PixKey:     "52998224725",
+		}
+		transfers.On("FindById", ctx, input.Id).Return(expectedResult, nil).Once()
+
+		result, err := useCase.ListById(ctx, &input)
+
+		assert.Equal(t, expectedResult, result)
+		assert.Equal(t, nil, err)
+		transfers.AssertExpectations(t)
+	})
+
+	t.Run("List unknown transfer returns not found", func(t *testing.T) {
+		input := usecase.ListTransferByIdInput{Id: "63f8c8d6c6ce914b5b00b900"}
+		transfers.On("FindById", ctx, input.Id).Return(nil, mongo.ErrNoDocuments).Once()
+
+		result, err := useCase.ListById(ctx, &input)
+
+		assert.Equal(t, (*entity.Transfer)(nil), result)
+		assert.Equal(t, mongo.ErrNoDocuments, err)
+		transfers.AssertExpectations(t)
+	})
+
+	t.Run("List transfer returns validation error for id", func(t *testing.T) {
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_REQUIRED, Field: "id", Message: "Id is required"},
+		}
+
+		result, err := useCase.ListById(ctx, &usecase.ListTransferByIdInput{})
+
+		assert.Equal(t, (*entity.Transfer)(nil), result)
+		assert.Equal(t, expectedError, fieldErrors(t, err))
+	})
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/repository"
+)
+
+type ListTransfersInput struct {
+	ReceiverId string
+	Status     entity.TransferStatus `validate:"omitempty,oneof=CREATED PROCESSING FINISHED FAILED RETURNED"`
+	Limit      int
+	After      *string
+}
+
+// List returns transfers newest first, optionally of a single receiver or
+// status.
+func (u *transferUseCase) List(ctx context.Context, input *ListTransfersInput) (*entity.TransferPage, error) {
+	if err := validateInput(input).orNil(); err != nil {
+		return nil, err
+	}
+
+	if input.Limit < 0 {
+		return nil, errors.New("first must not be negative")
+	}
+
+	filter := repository.TransferFilter{ReceiverID: input.ReceiverId, Status: input.Status}
+	return u.transferRepository.List(ctx, filter, input.Limit, input.After)
+}
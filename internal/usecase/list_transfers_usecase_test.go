@@ -0,0 +1,91 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/teste-transfeera/internal/entity"
+	repositoryPkg "github.com/teste-transfeera/internal/repository"
+	"github.com/teste-transfeera/internal/usecase"
+	"github.com/teste-transfeera/mocks"
+	"github.com/teste-transfeera/pkg/shared"
+)
+
+func Test_TransferUseCase_List_Success(t *testing.T) {
+	transfers := &mocks.TransferRepository{}
+	receivers := &mocks.ReceiverRepository{}
+	useCase := usecase.NewTransferUseCases(transfers, receivers)
+	ctx := context.Background()
+
+	t.Run("List transfers of a receiver with a status successfully", func(t *testing.T) {
+		input := usecase.ListTransfersInput{
+			ReceiverId: "63f8c8d6c6ce914b5b00b88e",
+			Status:     entity.TransferFinished,
+			Limit:      2,
+			After:      shared.GetPointerStr("63f8c8d6c6ce914b5b00b902"),
+		}
+		expectedResult := &entity.TransferPage{
+			Transfers: []entity.Transfer{
+				{
+					ID:         "63f8c8d6c6ce914b5b00b901",
+					ReceiverID: input.ReceiverId,
+					Amount:     1050,
+					Method:     entity.TransferPix,
+					Status:     entity.TransferFinished,
+					PixKey:     "52998224725",
+				},
+			},
+		}
+		filter := repositoryPkg.TransferFilter{ReceiverID: input.ReceiverId, Status: entity.TransferFinished}
+		transfers.On("List", ctx, filter, input.Limit, input.After).Return(expectedResult, nil).Once()
+
+		result, err := useCase.List(ctx, &input)
+
+		assert.Equal(t, expectedResult, result)
+		assert.Equal(t, nil, err)
+		transfers.AssertExpectations(t)
+	})
+}
+
+func Test_TransferUseCase_List_Error(t *testing.T) {
+	transfers := &mocks.TransferRepository{}
+	receivers := &mocks.ReceiverRepository{}
+	useCase := usecase.NewTransferUseCases(transfers, receivers)
+	ctx := context.Background()
+
+	t.Run("List transfers returns error from repository", func(t *testing.T) {
+		input := usecase.ListTransfersInput{Limit: 10}
+		expectedError := errors.New("error")
+		transfers.On("List", ctx, repositoryPkg.TransferFilter{}, input.Limit, input.After).Return(nil, errors.New("error")).Once()
+
+		result, err := useCase.List(ctx, &input)
+
+		assert.Equal(t, (*entity.TransferPage)(nil), result)
+		assert.Equal(t, expectedError, err)
+		transfers.AssertExpectations(t)
+	})
+
+	t.Run("List transfers returns error for negative limit", func(t *testing.T) {
+		input := usecase.ListTransfersInput{Limit: -1}
+		expectedError := errors.New("first must not be negative")
+
+		result, err := useCase.List(ctx, &input)
+
+		assert.Equal(t, (*entity.TransferPage)(nil), result)
+		assert.Equal(t, expectedError, err)
+	})
+
+	t.Run("List transfers returns validation error for status", func(t *testing.T) {
+		input := usecase.ListTransfersInput{Status: "PAID", Limit: 10}
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_INVALID_VALUE, Field: "status", Message: "Status must be one of CREATED, PROCESSING, FINISHED, FAILED, RETURNED"},
+		}
+
+		result, err := useCase.List(ctx, &input)
+
+		assert.Equal(t, (*entity.TransferPage)(nil), result)
+		assert.Equal(t, expectedError, fieldErrors(t, err))
+	})
+}
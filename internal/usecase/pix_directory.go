@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/teste-transfeera/internal/dict"
+	"github.com/teste-transfeera/pkg/validation"
+)
+
+// checkPixOwner looks the pix key up in the Pix directory, returning a field
+// error when the key is not registered or belongs to a document other than
+// identifier. Both are in canonical form. The error is only for failures of
+// the directory itself.
+func (u *receiverUseCase) checkPixOwner(ctx context.Context, identifier string, key string) (*FieldError, error) {
+	if u.pixDirectory == nil {
+		return nil, nil
+	}
+
+	entry, err := u.pixDirectory.Lookup(ctx, key)
+	if errors.Is(err, dict.ErrKeyNotFound) {
+		message := fmt.Sprintf("Pix key %s is not registered in the Pix directory", key)
+		return &FieldError{Code: ERROR_CODE_PIX_KEY_NOT_FOUND, Field: "pixKey", Message: message, Err: err}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Pix directory lookup failed: %w", err)
+	}
+
+	if validation.NormalizeIdentifier(entry.OwnerDocument) != identifier {
+		err := fmt.Errorf("Pix key %s belongs to another document than the identifier %s", key, validation.FormatIdentifier(identifier))
+		return &FieldError{Code: ERROR_CODE_PIX_KEY_OWNER_MISMATCH, Field: "pixKey", Message: err.Error(), Err: err}, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,193 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/teste-transfeera/internal/dict"
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/usecase"
+	"github.com/teste-transfeera/mocks"
+)
+
+func Test_ReceiverUseCase_PixDirectory_Success(t *testing.T) {
+	repository := &mocks.ReceiverRepository{}
+	history := &mocks.HistoryRepository{}
+	directory := &mocks.PixDirectory{}
+	useCase := usecase.NewReceiverUseCases(repository, history, usecase.WithPixDirectory(directory))
+	ctx := context.Background()
+
+	t.Run("Create receiver with a key of the identifier successfully", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier: "529.982.247-25",
+			Name:       "Receiver 1",
+			Email:      "receiver1@gmail.com",
+			PixKeyType: "EMAIL",
+			PixKey:     "Receiver1@Gmail.com",
+		}
+		created := &entity.Receiver{ID: "63f8c8d6c6ce914b5b00b88e", Identifier: "52998224725"}
+		directory.On("Lookup", ctx, "receiver1@gmail.com").Return(&dict.Entry{OwnerDocument: "529.982.247-25"}, nil).Once()
+		repository.On("Create", ctx, mock.Anything).Return(created, nil).Once()
+		history.On("Append", ctx, mock.Anything).Return(nil).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, created, result)
+		assert.Equal(t, nil, err)
+		directory.AssertExpectations(t)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Validate receiver with a key of the identifier successfully", func(t *testing.T) {
+		input := usecase.ChangeReceiverStatusInput{
+			Id:         "63f8c8d6c6ce914b5b00b88e",
+			Transition: entity.TransitionValidate,
+			Reason:     "Documents checked",
+		}
+		updated := statusReceiver(entity.Validated)
+		directory.On("Lookup", ctx, "52998224725").Return(&dict.Entry{OwnerDocument: "52998224725"}, nil).Once()
+		repository.On("FindById", ctx, input.Id).Return(statusReceiver(entity.Draft), nil).Once()
+		repository.On("Update", ctx, input.Id, int64(2), map[string]string{"status": "Validated"}).Return(nil).Once()
+		history.On("Append", ctx, mock.Anything).Return(nil).Once()
+		repository.On("FindById", ctx, input.Id).Return(updated, nil).Once()
+
+		result, err := useCase.ChangeStatus(ctx, &input)
+
+		assert.Equal(t, updated, result)
+		assert.Equal(t, nil, err)
+		directory.AssertExpectations(t)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Update only Email does not look the key up", func(t *testing.T) {
+		input := usecase.UpdateReceiverInput{Id: "63f8c8d6c6ce914b5b00b88e", Email: "receiver2@gmail.com"}
+		repository.On("FindById", ctx, input.Id).Return(statusReceiver(entity.Draft), nil).Once()
+		repository.On("Update", ctx, input.Id, int64(2), map[string]string{"email": "receiver2@gmail.com"}).Return(nil).Once()
+		history.On("Append", ctx, mock.Anything).Return(nil).Once()
+		repository.On("FindById", ctx, input.Id).Return(statusReceiver(entity.Draft), nil).Once()
+
+		_, err := useCase.Update(ctx, &input)
+
+		assert.Equal(t, nil, err)
+		directory.AssertExpectations(t)
+		repository.AssertExpectations(t)
+	})
+}
+
+func Test_ReceiverUseCase_PixDirectory_Error(t *testing.T) {
+	repository := &mocks.ReceiverRepository{}
+	history := &mocks.HistoryRepository{}
+	directory := &mocks.PixDirectory{}
+	useCase := usecase.NewReceiverUseCases(repository, history, usecase.WithPixDirectory(directory))
+	ctx := context.Background()
+
+	t.Run("Create receiver with a key of another document returns error", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier: "529.982.247-25",
+			Name:       "Receiver 1",
+			Email:      "receiver1@gmail.com",
+			PixKeyType: "EMAIL",
+			PixKey:     "receiver2@gmail.com",
+		}
+		expectedErrors := []usecase.FieldError{
+			{
+				Code:    usecase.ERROR_CODE_PIX_KEY_OWNER_MISMATCH,
+				Field:   "pixKey",
+				Message: "Pix key receiver2@gmail.com belongs to another document than the identifier 529.982.247-25",
+			},
+		}
+		directory.On("Lookup", ctx, "receiver2@gmail.com").Return(&dict.Entry{OwnerDocument: "29055159026"}, nil).Once()
+
+		result, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, (*entity.Receiver)(nil), result)
+		assert.Equal(t, expectedErrors, fieldErrors(t, err))
+		directory.AssertExpectations(t)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Create receiver with a key not in the directory returns error", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier: "529.982.247-25",
+			Name:       "Receiver 1",
+			Email:      "receiver1@gmail.com",
+			PixKeyType: "CPF",
+			PixKey:     "529.982.247-25",
+		}
+		expectedErrors := []usecase.FieldError{
+			{
+				Code:    usecase.ERROR_CODE_PIX_KEY_NOT_FOUND,
+				Field:   "pixKey",
+				Message: "Pix key 52998224725 is not registered in the Pix directory",
+			},
+		}
+		directory.On("Lookup", ctx, "52998224725").Return(nil, dict.ErrKeyNotFound).Once()
+
+		_, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, expectedErrors, fieldErrors(t, err))
+		directory.AssertExpectations(t)
+	})
+
+	t.Run("Create receiver returns error when the directory fails", func(t *testing.T) {
+		input := usecase.CreateReceiverInput{
+			Identifier: "529.982.247-25",
+			Name:       "Receiver 1",
+			Email:      "receiver1@gmail.com",
+			PixKeyType: "CPF",
+			PixKey:     "529.982.247-25",
+		}
+		directory.On("Lookup", ctx, "52998224725").Return(nil, errors.New("connection refused")).Once()
+
+		_, err := useCase.Create(ctx, &input)
+
+		assert.Equal(t, "Pix directory lookup failed: connection refused", err.Error())
+		directory.AssertExpectations(t)
+	})
+
+	t.Run("Update pix key to a key of another document returns error", func(t *testing.T) {
+		input := usecase.UpdateReceiverInput{Id: "63f8c8d6c6ce914b5b00b88e", PixKeyType: "TELEFONE", PixKey: "+5548991000001"}
+		expectedErrors := []usecase.FieldError{
+			{
+				Code:    usecase.ERROR_CODE_PIX_KEY_OWNER_MISMATCH,
+				Field:   "pixKey",
+				Message: "Pix key +5548991000001 belongs to another document than the identifier 529.982.247-25",
+			},
+		}
+		repository.On("FindById", ctx, input.Id).Return(statusReceiver(entity.Draft), nil).Once()
+		directory.On("Lookup", ctx, "+5548991000001").Return(&dict.Entry{OwnerDocument: "290.551.590-26"}, nil).Once()
+
+		_, err := useCase.Update(ctx, &input)
+
+		assert.Equal(t, expectedErrors, fieldErrors(t, err))
+		directory.AssertExpectations(t)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Validate receiver with a key of another document returns error", func(t *testing.T) {
+		input := usecase.ChangeReceiverStatusInput{
+			Id:         "63f8c8d6c6ce914b5b00b88e",
+			Transition: entity.TransitionValidate,
+			Reason:     "Documents checked",
+		}
+		expectedErrors := []usecase.FieldError{
+			{
+				Code:    usecase.ERROR_CODE_PIX_KEY_OWNER_MISMATCH,
+				Field:   "pixKey",
+				Message: "Pix key 52998224725 belongs to another document than the identifier 529.982.247-25",
+			},
+		}
+		repository.On("FindById", ctx, input.Id).Return(statusReceiver(entity.Draft), nil).Once()
+		directory.On("Lookup", ctx, "52998224725").Return(&dict.Entry{OwnerDocument: "11222333000181"}, nil).Once()
+
+		result, err := useCase.ChangeStatus(ctx, &input)
+
+		assert.Equal(t, (*entity.Receiver)(nil), result)
+		assert.Equal(t, expectedErrors, fieldErrors(t, err))
+		directory.AssertExpectations(t)
+		repository.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/pkg/actor"
+)
+
+// historyFields are the receiver fields tracked by the history, in the
+// order their changes are listed.
+var historyFields = []struct {
+	name  string
+	value func(receiver *entity.Receiver) *string
+}{
+	{"identifier", func(r *entity.Receiver) *string { return &r.Identifier }},
+	{"name", func(r *entity.Receiver) *string { return &r.Name }},
+	{"email", func(r *entity.Receiver) *string { return &r.Email }},
+	{"pixKeyType", func(r *entity.Receiver) *string { return (*string)(&r.Pix.KeyType) }},
+	{"pixKey", func(r *entity.Receiver) *string { return &r.Pix.Key }},
+	{"bank", bankAccountValue(func(a *entity.BankAccount) string { return a.BankCode })},
+	{"agency", bankAccountValue((*entity.BankAccount).FormattedAgency)},
+	{"account", bankAccountValue((*entity.BankAccount).FormattedAccount)},
+	{"accountType", bankAccountValue(func(a *entity.BankAccount) string { return string(a.AccountType) })},
+	{"status", func(r *entity.Receiver) *string { return (*string)(&r.Status) }},
+}
+
+// bankAccountValue reads a field of the bank account, which has no value
+// when the receiver has no bank account.
+func bankAccountValue(value func(account *entity.BankAccount) string) func(receiver *entity.Receiver) *string {
+	return func(r *entity.Receiver) *string {
+		if r.BankAccount == nil {
+			return nil
+		}
+		result := value(r.BankAccount)
+		return &result
+	}
+}
+
+// diffReceivers lists the tracked fields whose value differs between before
+// and after. A nil receiver has no value for any field.
+func diffReceivers(before, after *entity.Receiver) []entity.FieldChange {
+	changes := []entity.FieldChange{}
+	for _, field := range historyFields {
+		var beforeValue, afterValue *string
+		if before != nil {
+			beforeValue = copyValue(field.value(before))
+		}
+		if after != nil {
+			afterValue = copyValue(field.value(after))
+		}
+
+		if sameValue(beforeValue, afterValue) {
+			continue
+		}
+		changes = append(changes, entity.FieldChange{
+			Field:  field.name,
+			Before: beforeValue,
+			After:  afterValue,
+		})
+	}
+	return changes
+}
+
+func copyValue(value *string) *string {
+	if value == nil || *value == "" {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
+
+func sameValue(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// applyFields returns a copy of receiver with the repository update fields
+// applied, which is how the receiver looks after Update.
+func applyFields(receiver entity.Receiver, fields map[string]string) *entity.Receiver {
+	if fields["identifier"] != "" {
+		receiver.Identifier = fields["identifier"]
+	}
+	if fields["name"] != "" {
+		receiver.Name = fields["name"]
+	}
+	if fields["email"] != "" {
+		receiver.Email = fields["email"]
+	}
+	if fields["key_type"] != "" {
+		receiver.Pix.KeyType = entity.PixKeyType(fields["key_type"])
+	}
+	if fields["key"] != "" {
+		receiver.Pix.Key = fields["key"]
+	}
+	if fields["status"] != "" {
+		receiver.Status = entity.Status(fields["status"])
+	}
+	if fields["bank_code"] != "" {
+		receiver.BankAccount = &entity.BankAccount{
+			BankCode:     fields["bank_code"],
+			Agency:       fields["agency"],
+			AgencyDigit:  fields["agency_digit"],
+			Account:      fields["account"],
+			AccountDigit: fields["account_digit"],
+			AccountType:  entity.AccountType(fields["account_type"]),
+		}
+	}
+	return &receiver
+}
+
+func newHistoryEntry(ctx context.Context, receiverID string, operation entity.HistoryOperation, changes []entity.FieldChange) entity.HistoryEntry {
+	return entity.HistoryEntry{
+		ReceiverID: receiverID,
+		Actor:      actor.FromContext(ctx),
+		Operation:  operation,
+		Changes:    changes,
+	}
+}
+
+// recordHistory appends the entries after the receiver change they describe
+// was committed. A failed append is returned to the caller, so a change is
+// never reported as successful without its history entry.
+func (u *receiverUseCase) recordHistory(ctx context.Context, entries ...entity.HistoryEntry) error {
+	return u.historyRepository.Append(ctx, entries...)
+}
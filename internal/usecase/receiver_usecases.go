@@ -1,24 +1,70 @@
 package usecase
 
 import (
+	"context"
+
+	"github.com/teste-transfeera/internal/dict"
 	"github.com/teste-transfeera/internal/entity"
 	"github.com/teste-transfeera/internal/repository"
+	"github.com/teste-transfeera/pkg/bank"
 )
 
 type ReceiverUseCases interface {
-	Create(input *CreateReceiverInput) (*entity.Receiver, error)
-	List(filter map[string]string) ([]entity.Receiver, error)
-	ListById(input *ListReceiverByIdInput) (*entity.Receiver, error)
-	Update(input *UpdateReceiverInput) error
-	Delete(input *DeleteReceiverInput) error
+	Create(ctx context.Context, input *CreateReceiverInput) (*entity.Receiver, error)
+	List(ctx context.Context, filter map[string]string, page entity.PageRequest) (*entity.ReceiverPage, error)
+	Count(ctx context.Context, filter map[string]string) (int64, error)
+	ListById(ctx context.Context, input *ListReceiverByIdInput) (*entity.Receiver, error)
+	Update(ctx context.Context, input *UpdateReceiverInput) (*entity.Receiver, error)
+	Delete(ctx context.Context, input *DeleteReceiverInput) error
+	ChangeStatus(ctx context.Context, input *ChangeReceiverStatusInput) (*entity.Receiver, error)
+	ListHistory(ctx context.Context, input *ListReceiverHistoryInput) (*entity.HistoryPage, error)
+	ListBanks(ctx context.Context, input *ListBanksInput) ([]bank.Institution, error)
+	GeneratePixBRCode(ctx context.Context, input *GeneratePixBRCodeInput) (string, error)
+	CreateFromBRCode(ctx context.Context, input *CreateReceiverFromBRCodeInput) (*entity.Receiver, error)
 }
 
 type receiverUseCase struct {
 	receiverRepository repository.ReceiverRepository
+	historyRepository  repository.HistoryRepository
+	pixDirectory       dict.PixDirectory
+	rules              []ConsistencyRule
+	pixMerchantCity    string
+}
+
+// Option configures the optional dependencies of the receiver usecases.
+type Option func(*receiverUseCase)
+
+// WithPixDirectory makes the usecases check in the Pix directory that pix
+// keys belong to the receiver identifier. Without it keys are not checked.
+func WithPixDirectory(directory dict.PixDirectory) Option {
+	return func(u *receiverUseCase) {
+		u.pixDirectory = directory
+	}
+}
+
+// WithConsistencyRules replaces the default consistency rules.
+func WithConsistencyRules(rules []ConsistencyRule) Option {
+	return func(u *receiverUseCase) {
+		u.rules = rules
+	}
+}
+
+// WithPixMerchantCity sets the city written in the BR Codes of receivers.
+func WithPixMerchantCity(city string) Option {
+	return func(u *receiverUseCase) {
+		u.pixMerchantCity = city
+	}
 }
 
-func NewReceiverUseCases(repository repository.ReceiverRepository) ReceiverUseCases {
-	return &receiverUseCase{
+func NewReceiverUseCases(repository repository.ReceiverRepository, historyRepository repository.HistoryRepository, options ...Option) ReceiverUseCases {
+	u := &receiverUseCase{
 		receiverRepository: repository,
+		historyRepository:  historyRepository,
+		rules:              DefaultConsistencyRules(),
+		pixMerchantCity:    DefaultPixMerchantCity,
+	}
+	for _, option := range options {
+		option(u)
 	}
+	return u
 }
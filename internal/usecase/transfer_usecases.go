@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/repository"
+)
+
+type TransferUseCases interface {
+	Create(ctx context.Context, input *CreateTransferInput) (*entity.Transfer, error)
+	ListById(ctx context.Context, input *ListTransferByIdInput) (*entity.Transfer, error)
+	List(ctx context.Context, input *ListTransfersInput) (*entity.TransferPage, error)
+	ChangeStatus(ctx context.Context, input *ChangeTransferStatusInput) (*entity.Transfer, error)
+}
+
+type transferUseCase struct {
+	transferRepository repository.TransferRepository
+	receiverRepository repository.ReceiverRepository
+}
+
+func NewTransferUseCases(transferRepository repository.TransferRepository, receiverRepository repository.ReceiverRepository) TransferUseCases {
+	return &transferUseCase{
+		transferRepository: transferRepository,
+		receiverRepository: receiverRepository,
+	}
+}
+
+// ReceiverNotValidatedError is returned when a transfer is created for a
+// receiver that is not Validated.
+type ReceiverNotValidatedError struct {
+	ID     string
+	Status entity.Status
+}
+
+func (e *ReceiverNotValidatedError) Error() string {
+	return fmt.Sprintf("Receiver %s with status %s cannot receive transfers", e.ID, e.Status)
+}
@@ -1,11 +1,12 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/internal/repository"
 	"github.com/teste-transfeera/pkg/validation"
 )
 
@@ -16,90 +17,141 @@ type UpdateReceiverInput struct {
 	Email      string `validate:"omitempty,max=250,validateEmail"`
 	PixKeyType string `validate:"omitempty"`
 	PixKey     string `validate:"omitempty"`
+	// BankAccount replaces the receiver's bank account when present.
+	BankAccount *BankAccountInput
+	// ExpectedVersion is the version the client last read. When it is nil
+	// the update is guarded by the version read here instead.
+	ExpectedVersion *int64
 }
 
-func (u *receiverUseCase) Update(input *UpdateReceiverInput) error {
-	validator := validator.New()
-	validator.RegisterValidation("validateIdentifier", validation.ValidatorIdentifier)
-	validator.RegisterValidation("validateEmail", validation.ValidatorEmail)
+func (u *receiverUseCase) Update(ctx context.Context, input *UpdateReceiverInput) (*entity.Receiver, error) {
+	validationErr := validateInput(input)
+	if input.Identifier != "" && !validationErr.has("identifier") {
+		if err := validation.CheckIdentifier(input.Identifier); err != nil {
+			validationErr.add(documentFieldError("identifier", fmt.Errorf("Invalid Identifier: %w", err)))
+		}
+	}
+	if input.BankAccount != nil && !validationErr.hasWithin("bankAccount") {
+		if fieldErr := input.BankAccount.checkDigits(); fieldErr != nil {
+			validationErr.add(fieldErr)
+		}
+	}
+	if err := validationErr.orNil(); err != nil {
+		return nil, err
+	}
 
-	err := validator.Struct(input)
+	receiver, err := u.receiverRepository.FindById(ctx, input.Id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	receiver, err := u.receiverRepository.FindById(input.Id)
-	if err != nil {
-		return err
+	if input.ExpectedVersion != nil && *input.ExpectedVersion != receiver.Version {
+		return nil, &repository.VersionConflictError{ID: input.Id, ExpectedVersion: *input.ExpectedVersion}
+	}
+
+	if !receiver.Status.Editable() {
+		return nil, &entity.NotEditableError{Status: receiver.Status}
 	}
 
 	if err := validatePix(input, receiver); err != nil {
-		return err
+		return nil, &ValidationError{Errors: []*FieldError{err}}
 	}
 
-	fieldsToUpdate := buildUpdateByStatus(receiver.Status, input)
+	keyType := input.PixKeyType
+	if keyType == "" {
+		keyType = string(receiver.Pix.KeyType)
+	}
+
+	fieldsToUpdate := buildUpdateByStatus(receiver.Status, input, keyType)
 	if len(fieldsToUpdate) == 0 {
-		return errors.New("Required at least one field to be updated")
+		return nil, errors.New("Required at least one field to be updated")
+	}
+
+	if fieldsToUpdate["key"] != "" || fieldsToUpdate["identifier"] != "" {
+		updated := applyFields(*receiver, fieldsToUpdate)
+		if err := u.checkConsistency(updated); err != nil {
+			return nil, err
+		}
+
+		fieldErr, err := u.checkPixOwner(ctx, updated.Identifier, updated.Pix.Key)
+		if err != nil {
+			return nil, err
+		}
+		if fieldErr != nil {
+			return nil, &ValidationError{Errors: []*FieldError{fieldErr}}
+		}
 	}
 
-	err = u.receiverRepository.Update(input.Id, fieldsToUpdate)
+	err = u.receiverRepository.Update(ctx, input.Id, receiver.Version, fieldsToUpdate)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	changes := diffReceivers(receiver, applyFields(*receiver, fieldsToUpdate))
+	if err := u.recordHistory(ctx, newHistoryEntry(ctx, input.Id, entity.HistoryUpdate, changes)); err != nil {
+		return nil, err
+	}
+
+	updated, err := u.receiverRepository.FindById(ctx, input.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.withWarnings(updated), nil
 }
 
-func validatePix(input *UpdateReceiverInput, receiver *entity.Receiver) error {
+// validatePix checks the pix key against the new key type, or the current
+// one when only the key changes.
+func validatePix(input *UpdateReceiverInput, receiver *entity.Receiver) *FieldError {
 	bothKeyAndTypeChanged := input.PixKeyType != "" && input.PixKey != ""
 	if bothKeyAndTypeChanged {
 		if !validation.ValidatePixType(input.PixKeyType) {
-			return errors.New("Invalid Pix Key Type")
+			return &FieldError{Code: ERROR_CODE_INVALID_VALUE, Field: "pixKeyType", Message: "Invalid Pix Key Type"}
 		}
-		if !validation.ValidatePixKey(input.PixKey, input.PixKeyType) {
-			return errors.New(fmt.Sprintf("Invalid Pix Key for %s Key Type", input.PixKeyType))
+		if err := validation.CheckPixKey(input.PixKey, input.PixKeyType); err != nil {
+			return documentFieldError("pixKey", err)
 		}
 		return nil
 	}
 
 	onlyKeyChanged := input.PixKey != ""
 	if onlyKeyChanged {
-		if !validation.ValidatePixKey(input.PixKey, string(receiver.Pix.KeyType)) {
-			return errors.New(fmt.Sprintf("Invalid Pix Key for %s Key Type", receiver.Pix.KeyType))
+		if err := validation.CheckPixKey(input.PixKey, string(receiver.Pix.KeyType)); err != nil {
+			return documentFieldError("pixKey", err)
 		}
 		return nil
 	}
 
 	onlyTypeChanged := input.PixKeyType != ""
 	if onlyTypeChanged {
-		return errors.New("Updating Pix Key Type requires also updating Pix Key")
+		return &FieldError{Code: ERROR_CODE_REQUIRED, Field: "pixKey", Message: "Updating Pix Key Type requires also updating Pix Key"}
 	}
 	return nil
 }
 
-func buildUpdateByStatus(status entity.Status, input *UpdateReceiverInput) map[string]string {
+// buildUpdateByStatus returns the fields the status allows to update, in
+// their canonical form. keyType is the type the pix key is normalized for.
+func buildUpdateByStatus(status entity.Status, input *UpdateReceiverInput, keyType string) map[string]string {
 	fieldsToUpdate := make(map[string]string)
-	switch status {
-	case entity.Validated:
-		if input.Email != "" {
-			fieldsToUpdate["email"] = input.Email
-		}
-	case entity.Draft:
-		if input.Identifier != "" {
-			fieldsToUpdate["identifier"] = input.Identifier
-		}
-		if input.Name != "" {
-			fieldsToUpdate["name"] = input.Name
-		}
-		if input.Email != "" {
-			fieldsToUpdate["email"] = input.Email
-		}
-		if input.PixKey != "" {
-			fieldsToUpdate["key"] = input.PixKey
-		}
-		if input.PixKeyType != "" {
-			fieldsToUpdate["key_type"] = input.PixKeyType
-		}
+	if input.Identifier != "" && status.CanEdit("identifier") {
+		fieldsToUpdate["identifier"] = validation.NormalizeIdentifier(input.Identifier)
+		fieldsToUpdate["formatted_identifier"] = validation.FormatIdentifier(input.Identifier)
+	}
+	if input.Name != "" && status.CanEdit("name") {
+		fieldsToUpdate["name"] = input.Name
+	}
+	if input.Email != "" && status.CanEdit("email") {
+		fieldsToUpdate["email"] = validation.NormalizeEmail(input.Email)
+	}
+	if input.PixKey != "" && status.CanEdit("pixKey") {
+		fieldsToUpdate["key"] = validation.NormalizePixKey(input.PixKey, keyType)
+		fieldsToUpdate["formatted_key"] = validation.FormatPixKey(input.PixKey, keyType)
+	}
+	if input.PixKeyType != "" && status.CanEdit("pixKeyType") {
+		fieldsToUpdate["key_type"] = input.PixKeyType
+	}
+	if input.BankAccount != nil && status.CanEdit("bankAccount") {
+		addBankAccountFields(fieldsToUpdate, input.BankAccount.toEntity())
 	}
 	return fieldsToUpdate
 }
@@ -1,23 +1,31 @@
 package usecase_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 
 	"github.com/magiconair/properties/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/teste-transfeera/internal/entity"
+	repositoryPkg "github.com/teste-transfeera/internal/repository"
 	"github.com/teste-transfeera/internal/usecase"
 	"github.com/teste-transfeera/mocks"
+	"github.com/teste-transfeera/pkg/actor"
+	"github.com/teste-transfeera/pkg/shared"
+	"github.com/teste-transfeera/pkg/validation"
 )
 
 func Test_ReceiverUseCase_Update_Success(t *testing.T) {
 	repository := &mocks.ReceiverRepository{}
-	useCase := usecase.NewReceiverUseCases(repository)
+	history := &mocks.HistoryRepository{}
+	useCase := usecase.NewReceiverUseCases(repository, history)
+	ctx := context.Background()
 
 	t.Run("Update all fields from receiver successfully", func(t *testing.T) {
 		input := usecase.UpdateReceiverInput{
 			Id:         "63f8c8d6c6ce914b5b00b88e",
-			Identifier: "222.222.222-222",
+			Identifier: "123.456.789-09",
 			Name:       "Receiver 2",
 			Email:      "RECEIVER2@GMAIL.COM",
 			PixKeyType: "EMAIL",
@@ -25,57 +33,78 @@ func Test_ReceiverUseCase_Update_Success(t *testing.T) {
 		}
 		mockOutput := &entity.Receiver{
 			ID:         input.Id,
-			Identifier: "111.111.111-11",
+			Identifier: "529.982.247-25",
 			Name:       "Receiver 1",
 			Email:      "RECEIVER1@GMAIL.COM",
 			Pix: entity.Pix{
 				KeyType: entity.CPF,
-				Key:     "111.111.111-11",
+				Key:     "529.982.247-25",
 			},
 			Status: entity.Draft,
 		}
 		fieldsToUpdate := map[string]string{
-			"identifier": input.Identifier,
-			"name":       input.Name,
-			"email":      input.Email,
-			"key_type":   input.PixKeyType,
-			"key":        input.PixKey,
+			"identifier":           "12345678909",
+			"formatted_identifier": "123.456.789-09",
+			"name":                 input.Name,
+			"email":                "receiver2@gmail.com",
+			"key_type":             input.PixKeyType,
+			"key":                  "receiver2@gmail.com",
+			"formatted_key":        "receiver2@gmail.com",
 		}
-		repository.On("FindById", input.Id).Return(mockOutput, nil).Once()
-		repository.On("Update", input.Id, fieldsToUpdate).Return(nil).Once()
+		repository.On("FindById", ctx, input.Id).Return(mockOutput, nil).Once()
+		expectedEntry := entity.HistoryEntry{
+			ReceiverID: input.Id,
+			Actor:      actor.Anonymous,
+			Operation:  entity.HistoryUpdate,
+			Changes: []entity.FieldChange{
+				{Field: "identifier", Before: shared.GetPointerStr("529.982.247-25"), After: shared.GetPointerStr("12345678909")},
+				{Field: "name", Before: shared.GetPointerStr("Receiver 1"), After: shared.GetPointerStr("Receiver 2")},
+				{Field: "email", Before: shared.GetPointerStr("RECEIVER1@GMAIL.COM"), After: shared.GetPointerStr("receiver2@gmail.com")},
+				{Field: "pixKeyType", Before: shared.GetPointerStr("CPF"), After: shared.GetPointerStr("EMAIL")},
+				{Field: "pixKey", Before: shared.GetPointerStr("529.982.247-25"), After: shared.GetPointerStr("receiver2@gmail.com")},
+			},
+		}
+		repository.On("Update", ctx, input.Id, mockOutput.Version, fieldsToUpdate).Return(nil).Once()
+		repository.On("FindById", ctx, input.Id).Return(mockOutput, nil).Once()
+		history.On("Append", ctx, expectedEntry).Return(nil).Once()
 
-		err := useCase.Update(&input)
+		_, err := useCase.Update(ctx, &input)
 
 		assert.Equal(t, nil, err)
 		repository.AssertExpectations(t)
+		history.AssertExpectations(t)
 	})
 
 	t.Run("Update only Pix Key from receiver successfully", func(t *testing.T) {
 		input := usecase.UpdateReceiverInput{
 			Id:     "63f8c8d6c6ce914b5b00b88e",
-			PixKey: "222.222.222-22",
+			PixKey: "123.456.789-09",
 		}
 		mockOutput := &entity.Receiver{
 			ID:         input.Id,
-			Identifier: "111.111.111-11",
+			Identifier: "529.982.247-25",
 			Name:       "Receiver 1",
 			Email:      "RECEIVER1@GMAIL.COM",
 			Pix: entity.Pix{
 				KeyType: entity.CPF,
-				Key:     "111.111.111-11",
+				Key:     "529.982.247-25",
 			},
 			Status: entity.Draft,
 		}
 		fieldsToUpdate := map[string]string{
-			"key": input.PixKey,
+			"key":           "12345678909",
+			"formatted_key": "123.456.789-09",
 		}
-		repository.On("FindById", input.Id).Return(mockOutput, nil).Once()
-		repository.On("Update", input.Id, fieldsToUpdate).Return(nil).Once()
+		repository.On("FindById", ctx, input.Id).Return(mockOutput, nil).Once()
+		repository.On("Update", ctx, input.Id, mockOutput.Version, fieldsToUpdate).Return(nil).Once()
+		repository.On("FindById", ctx, input.Id).Return(mockOutput, nil).Once()
+		history.On("Append", ctx, mock.Anything).Return(nil).Once()
 
-		err := useCase.Update(&input)
+		_, err := useCase.Update(ctx, &input)
 
 		assert.Equal(t, nil, err)
 		repository.AssertExpectations(t)
+		history.AssertExpectations(t)
 	})
 
 	t.Run("Update only Email from receiver with Validated status successfully", func(t *testing.T) {
@@ -86,56 +115,258 @@ func Test_ReceiverUseCase_Update_Success(t *testing.T) {
 		}
 		mockOutput := &entity.Receiver{
 			ID:         input.Id,
-			Identifier: "111.111.111-11",
+			Identifier: "529.982.247-25",
 			Name:       "Receiver 1",
 			Email:      "RECEIVER1@GMAIL.COM",
 			Pix: entity.Pix{
 				KeyType: entity.CPF,
-				Key:     "111.111.111-11",
+				Key:     "529.982.247-25",
 			},
 			Status: entity.Validated,
 		}
 		fieldsToUpdate := map[string]string{
-			"email": input.Email,
+			"email": "receiver2@gmail.com",
 		}
-		repository.On("FindById", input.Id).Return(mockOutput, nil).Once()
-		repository.On("Update", input.Id, fieldsToUpdate).Return(nil).Once()
+		repository.On("FindById", ctx, input.Id).Return(mockOutput, nil).Once()
+		repository.On("Update", ctx, input.Id, mockOutput.Version, fieldsToUpdate).Return(nil).Once()
+		repository.On("FindById", ctx, input.Id).Return(mockOutput, nil).Once()
+		history.On("Append", ctx, mock.Anything).Return(nil).Once()
 
-		err := useCase.Update(&input)
+		_, err := useCase.Update(ctx, &input)
 
 		assert.Equal(t, nil, err)
 		repository.AssertExpectations(t)
+		history.AssertExpectations(t)
+	})
+
+	t.Run("Update receiver at the expected version successfully", func(t *testing.T) {
+		input := usecase.UpdateReceiverInput{
+			Id:              "63f8c8d6c6ce914b5b00b88e",
+			Name:            "Receiver 2",
+			ExpectedVersion: shared.GetPointerInt64(4),
+		}
+		mockOutput := &entity.Receiver{
+			ID:         input.Id,
+			Identifier: "529.982.247-25",
+			Name:       "Receiver 1",
+			Email:      "RECEIVER1@GMAIL.COM",
+			Pix: entity.Pix{
+				KeyType: entity.CPF,
+				Key:     "529.982.247-25",
+			},
+			Status:  entity.Draft,
+			Version: 4,
+		}
+		fieldsToUpdate := map[string]string{
+			"name": input.Name,
+		}
+		repository.On("FindById", ctx, input.Id).Return(mockOutput, nil).Once()
+		repository.On("Update", ctx, input.Id, int64(4), fieldsToUpdate).Return(nil).Once()
+		repository.On("FindById", ctx, input.Id).Return(mockOutput, nil).Once()
+		history.On("Append", ctx, mock.Anything).Return(nil).Once()
+
+		_, err := useCase.Update(ctx, &input)
+
+		assert.Equal(t, nil, err)
+		repository.AssertExpectations(t)
+		history.AssertExpectations(t)
+	})
+
+	t.Run("Update bank account from Draft receiver successfully", func(t *testing.T) {
+		input := usecase.UpdateReceiverInput{
+			Id: "63f8c8d6c6ce914b5b00b88e",
+			BankAccount: &usecase.BankAccountInput{
+				BankCode:     "341",
+				Agency:       "1234",
+				Account:      "98765",
+				AccountDigit: "7",
+				AccountType:  "SAVINGS",
+			},
+		}
+		mockOutput := &entity.Receiver{
+			ID:     input.Id,
+			Name:   "Receiver 1",
+			Status: entity.Draft,
+			BankAccount: &entity.BankAccount{
+				BankCode:     "001",
+				Agency:       "0814",
+				AgencyDigit:  "0",
+				Account:      "12345",
+				AccountDigit: "6",
+				AccountType:  entity.Checking,
+			},
+		}
+		fieldsToUpdate := map[string]string{
+			"bank_code":     "341",
+			"ispb":          "60701190",
+			"agency":        "1234",
+			"agency_digit":  "",
+			"account":       "98765",
+			"account_digit": "7",
+			"account_type":  "SAVINGS",
+			"verified":      "true",
+		}
+		expectedEntry := entity.HistoryEntry{
+			ReceiverID: input.Id,
+			Actor:      actor.Anonymous,
+			Operation:  entity.HistoryUpdate,
+			Changes: []entity.FieldChange{
+				{Field: "bank", Before: shared.GetPointerStr("001"), After: shared.GetPointerStr("341")},
+				{Field: "agency", Before: shared.GetPointerStr("0814-0"), After: shared.GetPointerStr("1234")},
+				{Field: "account", Before: shared.GetPointerStr("12345-6"), After: shared.GetPointerStr("98765-7")},
+				{Field: "accountType", Before: shared.GetPointerStr("CHECKING"), After: shared.GetPointerStr("SAVINGS")},
+			},
+		}
+		repository.On("FindById", ctx, input.Id).Return(mockOutput, nil).Once()
+		repository.On("Update", ctx, input.Id, mockOutput.Version, fieldsToUpdate).Return(nil).Once()
+		repository.On("FindById", ctx, input.Id).Return(mockOutput, nil).Once()
+		history.On("Append", ctx, expectedEntry).Return(nil).Once()
+
+		_, err := useCase.Update(ctx, &input)
+
+		assert.Equal(t, nil, err)
+		repository.AssertExpectations(t)
+		history.AssertExpectations(t)
 	})
 }
 
 func Test_ReceiverUseCase_Update_Error(t *testing.T) {
 	repository := &mocks.ReceiverRepository{}
-	useCase := usecase.NewReceiverUseCases(repository)
+	history := &mocks.HistoryRepository{}
+	useCase := usecase.NewReceiverUseCases(repository, history)
+	ctx := context.Background()
 
 	t.Run("Update receiver returns error from repository on Update", func(t *testing.T) {
 		input := usecase.UpdateReceiverInput{
 			Id:     "63f8c8d6c6ce914b5b00b88e",
-			PixKey: "222.222.222-22",
+			PixKey: "123.456.789-09",
 		}
 		mockOutput := &entity.Receiver{
 			ID:         input.Id,
-			Identifier: "111.111.111-11",
+			Identifier: "529.982.247-25",
 			Name:       "Receiver 1",
 			Email:      "RECEIVER1@GMAIL.COM",
 			Pix: entity.Pix{
 				KeyType: entity.CPF,
-				Key:     "111.111.111-11",
+				Key:     "529.982.247-25",
 			},
 			Status: entity.Draft,
 		}
 		fieldsToUpdate := map[string]string{
-			"key": input.PixKey,
+			"key":           "12345678909",
+			"formatted_key": "123.456.789-09",
 		}
 		expectedError := errors.New("error")
-		repository.On("FindById", input.Id).Return(mockOutput, nil).Once()
-		repository.On("Update", input.Id, fieldsToUpdate).Return(errors.New("error")).Once()
+		repository.On("FindById", ctx, input.Id).Return(mockOutput, nil).Once()
+		repository.On("Update", ctx, input.Id, mockOutput.Version, fieldsToUpdate).Return(errors.New("error")).Once()
+
+		_, err := useCase.Update(ctx, &input)
+
+		assert.Equal(t, expectedError, err)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Update receiver with outdated expected version returns conflict error", func(t *testing.T) {
+		input := usecase.UpdateReceiverInput{
+			Id:              "63f8c8d6c6ce914b5b00b88e",
+			Name:            "Receiver 2",
+			ExpectedVersion: shared.GetPointerInt64(4),
+		}
+		mockOutput := &entity.Receiver{
+			ID:         input.Id,
+			Identifier: "529.982.247-25",
+			Name:       "Receiver 1",
+			Email:      "RECEIVER1@GMAIL.COM",
+			Pix: entity.Pix{
+				KeyType: entity.CPF,
+				Key:     "529.982.247-25",
+			},
+			Status:  entity.Validated,
+			Version: 5,
+		}
+		expectedError := &repositoryPkg.VersionConflictError{ID: input.Id, ExpectedVersion: 4}
+		repository.On("FindById", ctx, input.Id).Return(mockOutput, nil).Once()
 
-		err := useCase.Update(&input)
+		_, err := useCase.Update(ctx, &input)
+
+		assert.Equal(t, expectedError, err)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Update receiver changed concurrently returns conflict error from repository", func(t *testing.T) {
+		input := usecase.UpdateReceiverInput{
+			Id:   "63f8c8d6c6ce914b5b00b88e",
+			Name: "Receiver 2",
+		}
+		mockOutput := &entity.Receiver{
+			ID:         input.Id,
+			Identifier: "529.982.247-25",
+			Name:       "Receiver 1",
+			Email:      "RECEIVER1@GMAIL.COM",
+			Pix: entity.Pix{
+				KeyType: entity.CPF,
+				Key:     "529.982.247-25",
+			},
+			Status:  entity.Draft,
+			Version: 2,
+		}
+		fieldsToUpdate := map[string]string{
+			"name": input.Name,
+		}
+		expectedError := &repositoryPkg.VersionConflictError{ID: input.Id, ExpectedVersion: 2}
+		repository.On("FindById", ctx, input.Id).Return(mockOutput, nil).Once()
+		repository.On("Update", ctx, input.Id, int64(2), fieldsToUpdate).Return(expectedError).Once()
+
+		_, err := useCase.Update(ctx, &input)
+
+		assert.Equal(t, expectedError, err)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Update receiver to a pix key in use returns duplicate error", func(t *testing.T) {
+		input := usecase.UpdateReceiverInput{
+			Id:     "63f8c8d6c6ce914b5b00b88e",
+			PixKey: "123.456.789-09",
+		}
+		mockOutput := &entity.Receiver{
+			ID:         input.Id,
+			Identifier: "529.982.247-25",
+			Name:       "Receiver 1",
+			Email:      "RECEIVER1@GMAIL.COM",
+			Pix: entity.Pix{
+				KeyType: entity.CPF,
+				Key:     "529.982.247-25",
+			},
+			Status: entity.Draft,
+		}
+		fieldsToUpdate := map[string]string{
+			"key":           "12345678909",
+			"formatted_key": "123.456.789-09",
+		}
+		expectedError := &repositoryPkg.DuplicatePixKeyError{Key: "12345678909", ReceiverID: "63f8c8d6c6ce914b5b00b88f"}
+		repository.On("FindById", ctx, input.Id).Return(mockOutput, nil).Once()
+		repository.On("Update", ctx, input.Id, mockOutput.Version, fieldsToUpdate).Return(expectedError).Once()
+
+		_, err := useCase.Update(ctx, &input)
+
+		assert.Equal(t, expectedError, err)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Update Blocked receiver returns error", func(t *testing.T) {
+		input := usecase.UpdateReceiverInput{
+			Id:    "63f8c8d6c6ce914b5b00b88e",
+			Email: "RECEIVER2@GMAIL.COM",
+		}
+		mockOutput := &entity.Receiver{
+			ID:     input.Id,
+			Name:   "Receiver 1",
+			Status: entity.Blocked,
+		}
+		expectedError := &entity.NotEditableError{Status: entity.Blocked}
+		repository.On("FindById", ctx, input.Id).Return(mockOutput, nil).Once()
+
+		_, err := useCase.Update(ctx, &input)
 
 		assert.Equal(t, expectedError, err)
 		repository.AssertExpectations(t)
@@ -147,19 +378,19 @@ func Test_ReceiverUseCase_Update_Error(t *testing.T) {
 		}
 		mockOutput := &entity.Receiver{
 			ID:         input.Id,
-			Identifier: "111.111.111-11",
+			Identifier: "529.982.247-25",
 			Name:       "Receiver 1",
 			Email:      "RECEIVER1@GMAIL.COM",
 			Pix: entity.Pix{
 				KeyType: entity.CPF,
-				Key:     "111.111.111-11",
+				Key:     "529.982.247-25",
 			},
 			Status: entity.Draft,
 		}
 		expectedError := errors.New("Required at least one field to be updated")
-		repository.On("FindById", input.Id).Return(mockOutput, nil).Once()
+		repository.On("FindById", ctx, input.Id).Return(mockOutput, nil).Once()
 
-		err := useCase.Update(&input)
+		_, err := useCase.Update(ctx, &input)
 
 		assert.Equal(t, expectedError, err)
 		repository.AssertExpectations(t)
@@ -172,21 +403,23 @@ func Test_ReceiverUseCase_Update_Error(t *testing.T) {
 		}
 		mockOutput := &entity.Receiver{
 			ID:         input.Id,
-			Identifier: "111.111.111-11",
+			Identifier: "529.982.247-25",
 			Name:       "Receiver 1",
 			Email:      "RECEIVER1@GMAIL.COM",
 			Pix: entity.Pix{
 				KeyType: entity.CPF,
-				Key:     "111.111.111-11",
+				Key:     "529.982.247-25",
 			},
 			Status: entity.Draft,
 		}
-		expectedError := errors.New("Updating Pix Key Type requires also updating Pix Key")
-		repository.On("FindById", input.Id).Return(mockOutput, nil).Once()
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_REQUIRED, Field: "pixKey", Message: "Updating Pix Key Type requires also updating Pix Key"},
+		}
+		repository.On("FindById", ctx, input.Id).Return(mockOutput, nil).Once()
 
-		err := useCase.Update(&input)
+		_, err := useCase.Update(ctx, &input)
 
-		assert.Equal(t, expectedError, err)
+		assert.Equal(t, expectedError, fieldErrors(t, err))
 		repository.AssertExpectations(t)
 	})
 
@@ -197,21 +430,23 @@ func Test_ReceiverUseCase_Update_Error(t *testing.T) {
 		}
 		mockOutput := &entity.Receiver{
 			ID:         input.Id,
-			Identifier: "111.111.111-11",
+			Identifier: "529.982.247-25",
 			Name:       "Receiver 1",
 			Email:      "RECEIVER1@GMAIL.COM",
 			Pix: entity.Pix{
 				KeyType: entity.CPF,
-				Key:     "111.111.111-11",
+				Key:     "529.982.247-25",
 			},
 			Status: entity.Draft,
 		}
-		expectedError := errors.New("Invalid Pix Key for CPF Key Type")
-		repository.On("FindById", input.Id).Return(mockOutput, nil).Once()
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_INVALID_FORMAT, Field: "pixKey", Message: "Invalid Pix Key for CPF Key Type"},
+		}
+		repository.On("FindById", ctx, input.Id).Return(mockOutput, nil).Once()
 
-		err := useCase.Update(&input)
+		_, err := useCase.Update(ctx, &input)
 
-		assert.Equal(t, expectedError, err)
+		assert.Equal(t, expectedError, fieldErrors(t, err))
 		repository.AssertExpectations(t)
 	})
 
@@ -223,21 +458,23 @@ func Test_ReceiverUseCase_Update_Error(t *testing.T) {
 		}
 		mockOutput := &entity.Receiver{
 			ID:         input.Id,
-			Identifier: "111.111.111-11",
+			Identifier: "529.982.247-25",
 			Name:       "Receiver 1",
 			Email:      "RECEIVER1@GMAIL.COM",
 			Pix: entity.Pix{
 				KeyType: entity.CPF,
-				Key:     "111.111.111-11",
+				Key:     "529.982.247-25",
 			},
 			Status: entity.Draft,
 		}
-		expectedError := errors.New("Invalid Pix Key for EMAIL Key Type")
-		repository.On("FindById", input.Id).Return(mockOutput, nil).Once()
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_INVALID_FORMAT, Field: "pixKey", Message: "Invalid Pix Key for EMAIL Key Type"},
+		}
+		repository.On("FindById", ctx, input.Id).Return(mockOutput, nil).Once()
 
-		err := useCase.Update(&input)
+		_, err := useCase.Update(ctx, &input)
 
-		assert.Equal(t, expectedError, err)
+		assert.Equal(t, expectedError, fieldErrors(t, err))
 		repository.AssertExpectations(t)
 	})
 
@@ -249,21 +486,23 @@ func Test_ReceiverUseCase_Update_Error(t *testing.T) {
 		}
 		mockOutput := &entity.Receiver{
 			ID:         input.Id,
-			Identifier: "111.111.111-11",
+			Identifier: "529.982.247-25",
 			Name:       "Receiver 1",
 			Email:      "RECEIVER1@GMAIL.COM",
 			Pix: entity.Pix{
 				KeyType: entity.CPF,
-				Key:     "111.111.111-11",
+				Key:     "529.982.247-25",
 			},
 			Status: entity.Draft,
 		}
-		expectedError := errors.New("Invalid Pix Key Type")
-		repository.On("FindById", input.Id).Return(mockOutput, nil).Once()
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_INVALID_VALUE, Field: "pixKeyType", Message: "Invalid Pix Key Type"},
+		}
+		repository.On("FindById", ctx, input.Id).Return(mockOutput, nil).Once()
 
-		err := useCase.Update(&input)
+		_, err := useCase.Update(ctx, &input)
 
-		assert.Equal(t, expectedError, err)
+		assert.Equal(t, expectedError, fieldErrors(t, err))
 		repository.AssertExpectations(t)
 	})
 
@@ -274,9 +513,9 @@ func Test_ReceiverUseCase_Update_Error(t *testing.T) {
 			PixKey:     "RECEIVER2@GMAIL.COM",
 		}
 		expectedError := errors.New("error")
-		repository.On("FindById", input.Id).Return(nil, errors.New("error")).Once()
+		repository.On("FindById", ctx, input.Id).Return(nil, errors.New("error")).Once()
 
-		err := useCase.Update(&input)
+		_, err := useCase.Update(ctx, &input)
 
 		assert.Equal(t, expectedError, err)
 		repository.AssertExpectations(t)
@@ -287,11 +526,13 @@ func Test_ReceiverUseCase_Update_Error(t *testing.T) {
 			Id:    "63f8c8d6c6ce914b5b00b88e",
 			Email: "a",
 		}
-		expectedError := errors.New(`Key: 'UpdateReceiverInput.Email' Error:Field validation for 'Email' failed on the 'validateEmail' tag`)
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_INVALID_FORMAT, Field: "email", Message: "Email must be a valid email"},
+		}
 
-		err := useCase.Update(&input)
+		_, err := useCase.Update(ctx, &input)
 
-		assert.Equal(t, expectedError.Error(), err.Error())
+		assert.Equal(t, expectedError, fieldErrors(t, err))
 		repository.AssertExpectations(t)
 	})
 
@@ -300,11 +541,13 @@ func Test_ReceiverUseCase_Update_Error(t *testing.T) {
 			Id:    "63f8c8d6c6ce914b5b00b88e",
 			Email: "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA@AA",
 		}
-		expectedError := errors.New(`Key: 'UpdateReceiverInput.Email' Error:Field validation for 'Email' failed on the 'max' tag`)
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_TOO_LONG, Field: "email", Message: "Email must have at most 250 characters"},
+		}
 
-		err := useCase.Update(&input)
+		_, err := useCase.Update(ctx, &input)
 
-		assert.Equal(t, expectedError.Error(), err.Error())
+		assert.Equal(t, expectedError, fieldErrors(t, err))
 		repository.AssertExpectations(t)
 	})
 
@@ -313,11 +556,53 @@ func Test_ReceiverUseCase_Update_Error(t *testing.T) {
 			Id:         "63f8c8d6c6ce914b5b00b88e",
 			Identifier: "a",
 		}
-		expectedError := errors.New(`Key: 'UpdateReceiverInput.Identifier' Error:Field validation for 'Identifier' failed on the 'validateIdentifier' tag`)
+		expectedError := []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_INVALID_FORMAT, Field: "identifier", Message: "Identifier must be a CPF or CNPJ"},
+		}
+
+		_, err := useCase.Update(ctx, &input)
+
+		assert.Equal(t, expectedError, fieldErrors(t, err))
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Update receiver returns check digit error for Identifier", func(t *testing.T) {
+		input := usecase.UpdateReceiverInput{
+			Id:         "63f8c8d6c6ce914b5b00b88e",
+			Identifier: "11.222.333/0001-80",
+		}
 
-		err := useCase.Update(&input)
+		_, err := useCase.Update(ctx, &input)
 
-		assert.Equal(t, expectedError.Error(), err.Error())
+		assert.Equal(t, []usecase.FieldError{
+			{Code: usecase.ERROR_CODE_INVALID_CHECK_DIGITS, Field: "identifier", Message: "Invalid Identifier: CNPJ check digits are invalid"},
+		}, fieldErrors(t, err))
+		assert.Equal(t, true, errors.Is(err, validation.ErrInvalidCNPJCheckDigits))
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("Update bank account from Validated receiver returns error", func(t *testing.T) {
+		input := usecase.UpdateReceiverInput{
+			Id: "63f8c8d6c6ce914b5b00b88e",
+			BankAccount: &usecase.BankAccountInput{
+				BankCode:     "341",
+				Agency:       "1234",
+				Account:      "98765",
+				AccountDigit: "7",
+				AccountType:  "SAVINGS",
+			},
+		}
+		mockOutput := &entity.Receiver{
+			ID:     input.Id,
+			Name:   "Receiver 1",
+			Status: entity.Validated,
+		}
+		expectedError := errors.New("Required at least one field to be updated")
+		repository.On("FindById", ctx, input.Id).Return(mockOutput, nil).Once()
+
+		_, err := useCase.Update(ctx, &input)
+
+		assert.Equal(t, expectedError, err)
 		repository.AssertExpectations(t)
 	})
 }
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/teste-transfeera/pkg/validation"
+)
+
+const ERROR_CODE_REQUIRED string = "REQUIRED"
+const ERROR_CODE_TOO_LONG string = "TOO_LONG"
+const ERROR_CODE_INVALID_FORMAT string = "INVALID_FORMAT"
+const ERROR_CODE_INVALID_VALUE string = "INVALID_VALUE"
+const ERROR_CODE_REPEATED_DIGITS string = "REPEATED_DIGITS"
+const ERROR_CODE_INVALID_CHECK_DIGITS string = "INVALID_CHECK_DIGITS"
+const ERROR_CODE_INVALID_TAG string = "INVALID_TAG"
+const ERROR_CODE_INVALID_LENGTH string = "INVALID_LENGTH"
+const ERROR_CODE_INVALID_CRC string = "INVALID_CRC"
+const ERROR_CODE_PIX_KEY_NOT_FOUND string = "PIX_KEY_NOT_FOUND"
+const ERROR_CODE_PIX_KEY_OWNER_MISMATCH string = "PIX_KEY_OWNER_MISMATCH"
+
+// FieldError is a validation failure of a single input field. Field is the
+// name of the field in the GraphQL input, such as pixKey.
+type FieldError struct {
+	Code    string
+	Field   string
+	Message string
+	Err     error
+}
+
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError holds every field error found in an input, so clients can
+// show them all at once.
+type ValidationError struct {
+	Errors []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, fieldErr := range e.Errors {
+		messages[i] = fieldErr.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, fieldErr := range e.Errors {
+		errs[i] = fieldErr
+	}
+	return errs
+}
+
+func (e *ValidationError) add(fieldErr *FieldError) {
+	e.Errors = append(e.Errors, fieldErr)
+}
+
+func (e *ValidationError) has(field string) bool {
+	for _, fieldErr := range e.Errors {
+		if fieldErr.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// hasWithin reports whether the field or any field nested in it failed.
+func (e *ValidationError) hasWithin(field string) bool {
+	for _, fieldErr := range e.Errors {
+		if fieldErr.Field == field || strings.HasPrefix(fieldErr.Field, field+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// orNil returns the error only when a field failed, so callers can return it
+// directly.
+func (e *ValidationError) orNil() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+func newValidator() *validator.Validate {
+	validator := validator.New()
+	validator.RegisterValidation("validateIdentifier", validation.ValidatorIdentifier)
+	validator.RegisterValidation("validateEmail", validation.ValidatorEmail)
+	validator.RegisterValidation("validatePixType", validation.ValidatorPixType)
+	validator.RegisterValidation("validatePixKey", validation.ValidatorPixKey)
+	validator.RegisterValidation("validateDigits", validation.ValidatorDigits)
+	validator.RegisterValidation("validateCheckDigit", validation.ValidatorCheckDigit)
+	validator.RegisterValidation("validateBankCode", validation.ValidatorBankCode)
+	return validator
+}
+
+// validateInput runs the validate tags of input and converts every failure
+// into a FieldError.
+func validateInput(input interface{}) *ValidationError {
+	result := &ValidationError{}
+
+	var validationErrors validator.ValidationErrors
+	if err := newValidator().Struct(input); errors.As(err, &validationErrors) {
+		for _, fieldErr := range validationErrors {
+			result.add(tagFieldError(fieldErr))
+		}
+	}
+
+	return result
+}
+
+func tagFieldError(fieldErr validator.FieldError) *FieldError {
+	label := fieldLabel(fieldErr.StructField())
+	result := &FieldError{
+		Code:  ERROR_CODE_INVALID_FORMAT,
+		Field: fieldPath(fieldErr.StructNamespace()),
+		Err:   fieldErr,
+	}
+
+	switch fieldErr.Tag() {
+	case "required":
+		result.Code = ERROR_CODE_REQUIRED
+		result.Message = fmt.Sprintf("%s is required", label)
+	case "max":
+		result.Code = ERROR_CODE_TOO_LONG
+		result.Message = fmt.Sprintf("%s must have at most %s characters", label, fieldErr.Param())
+	case "min":
+		result.Code = ERROR_CODE_INVALID_VALUE
+		result.Message = fmt.Sprintf("%s must be at least %s", label, fieldErr.Param())
+	case "alphanum":
+		result.Message = fmt.Sprintf("%s must contain only letters and digits", label)
+	case "validateEmail":
+		result.Message = fmt.Sprintf("%s must be a valid email", label)
+	case "validateIdentifier":
+		result.Message = fmt.Sprintf("%s must be a CPF or CNPJ", label)
+	case "validatePixType":
+		result.Code = ERROR_CODE_INVALID_VALUE
+		result.Message = fmt.Sprintf("%s must be one of CPF, CNPJ, EMAIL, TELEFONE or CHAVE_ALEATORIA", label)
+	case "oneof":
+		result.Code = ERROR_CODE_INVALID_VALUE
+		result.Message = fmt.Sprintf("%s must be one of %s", label, strings.Join(strings.Fields(fieldErr.Param()), ", "))
+	case "len":
+		result.Message = fmt.Sprintf("%s must have %s characters", label, fieldErr.Param())
+	case "validateDigits":
+		result.Message = fmt.Sprintf("%s must contain only digits", label)
+	case "validateCheckDigit":
+		result.Message = fmt.Sprintf("%s must be a digit, X or P", label)
+	case "validateBankCode":
+		result.Code = ERROR_CODE_INVALID_VALUE
+		result.Message = fmt.Sprintf("%s is not a known bank", label)
+	case "validatePixKey":
+		result.Message = fmt.Sprintf("%s does not match the Pix Key Type", label)
+	default:
+		result.Message = fmt.Sprintf("%s is invalid", label)
+	}
+
+	return result
+}
+
+// documentFieldError converts the errors of validation.CheckIdentifier,
+// validation.CheckPixKey and validation.CheckBankAccount, keeping their
+// message.
+func documentFieldError(field string, err error) *FieldError {
+	code := ERROR_CODE_INVALID_FORMAT
+	switch {
+	case errors.Is(err, validation.ErrCPFRepeatedDigits), errors.Is(err, validation.ErrCNPJRepeatedDigits):
+		code = ERROR_CODE_REPEATED_DIGITS
+	case errors.Is(err, validation.ErrInvalidCPFCheckDigits), errors.Is(err, validation.ErrInvalidCNPJCheckDigits),
+		errors.Is(err, validation.ErrInvalidAgencyCheckDigit), errors.Is(err, validation.ErrInvalidAccountCheckDigit):
+		code = ERROR_CODE_INVALID_CHECK_DIGITS
+	}
+
+	return &FieldError{Code: code, Field: field, Message: err.Error(), Err: err}
+}
+
+// fieldName turns a struct field into its GraphQL input name: PixKey becomes
+// pixKey.
+func fieldName(structField string) string {
+	runes := []rune(structField)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+// fieldPath turns the namespace of a struct field into its path in the
+// GraphQL input: CreateReceiverInput.BankAccount.BankCode becomes
+// bankAccount.bankCode.
+func fieldPath(namespace string) string {
+	parts := strings.Split(namespace, ".")[1:]
+	for i, part := range parts {
+		parts[i] = fieldName(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// fieldLabel splits a struct field into words for messages: PixKeyType
+// becomes Pix Key Type.
+func fieldLabel(structField string) string {
+	var label strings.Builder
+	for i, r := range structField {
+		if i > 0 && unicode.IsUpper(r) {
+			label.WriteRune(' ')
+		}
+		label.WriteRune(r)
+	}
+	return label.String()
+}
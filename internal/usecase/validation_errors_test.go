@@ -0,0 +1,45 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+	"github.com/teste-transfeera/internal/usecase"
+	"github.com/teste-transfeera/pkg/validation"
+)
+
+// fieldErrors returns the field errors of a ValidationError without their
+// wrapped cause, so tests can compare them as values.
+func fieldErrors(t *testing.T, err error) []usecase.FieldError {
+	var validationErr *usecase.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+
+	result := make([]usecase.FieldError, len(validationErr.Errors))
+	for i, fieldErr := range validationErr.Errors {
+		result[i] = usecase.FieldError{Code: fieldErr.Code, Field: fieldErr.Field, Message: fieldErr.Message}
+	}
+	return result
+}
+
+func Test_ValidationError(t *testing.T) {
+	t.Run("Join the messages of every field error", func(t *testing.T) {
+		err := &usecase.ValidationError{Errors: []*usecase.FieldError{
+			{Code: usecase.ERROR_CODE_REQUIRED, Field: "name", Message: "Name is required"},
+			{Code: usecase.ERROR_CODE_INVALID_FORMAT, Field: "email", Message: "Email must be a valid email"},
+		}}
+
+		assert.Equal(t, "Name is required; Email must be a valid email", err.Error())
+	})
+
+	t.Run("Match the cause of any field error", func(t *testing.T) {
+		err := &usecase.ValidationError{Errors: []*usecase.FieldError{
+			{Code: usecase.ERROR_CODE_REQUIRED, Field: "name", Message: "Name is required"},
+			{Code: usecase.ERROR_CODE_INVALID_CHECK_DIGITS, Field: "identifier", Message: "CPF check digits are invalid", Err: validation.ErrInvalidCPFCheckDigits},
+		}}
+
+		assert.Equal(t, true, errors.Is(err, validation.ErrInvalidCPFCheckDigits))
+	})
+}
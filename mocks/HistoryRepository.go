@@ -0,0 +1,77 @@
+// Code generated by mockery v2.20.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	entity "github.com/teste-transfeera/internal/entity"
+)
+
+// HistoryRepository is an autogenerated mock type for the HistoryRepository type
+type HistoryRepository struct {
+	mock.Mock
+}
+
+// Append provides a mock function with given fields: ctx, entries
+func (_m *HistoryRepository) Append(ctx context.Context, entries ...entity.HistoryEntry) error {
+	_va := make([]interface{}, len(entries))
+	for _i := range entries {
+		_va[_i] = entries[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...entity.HistoryEntry) error); ok {
+		r0 = rf(ctx, entries...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// List provides a mock function with given fields: ctx, receiverID, limit, after
+func (_m *HistoryRepository) List(ctx context.Context, receiverID string, limit int, after *string) (*entity.HistoryPage, error) {
+	ret := _m.Called(ctx, receiverID, limit, after)
+
+	var r0 *entity.HistoryPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, *string) (*entity.HistoryPage, error)); ok {
+		return rf(ctx, receiverID, limit, after)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, *string) *entity.HistoryPage); ok {
+		r0 = rf(ctx, receiverID, limit, after)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.HistoryPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, *string) error); ok {
+		r1 = rf(ctx, receiverID, limit, after)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewHistoryRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewHistoryRepository creates a new instance of HistoryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewHistoryRepository(t mockConstructorTestingTNewHistoryRepository) *HistoryRepository {
+	mock := &HistoryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -14,6 +14,84 @@ type MutationResolver struct {
 	mock.Mock
 }
 
+// ArchiveReceiver provides a mock function with given fields: ctx, input
+func (_m *MutationResolver) ArchiveReceiver(ctx context.Context, input graph.ChangeReceiverStatus) (*graph.Receiver, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 *graph.Receiver
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, graph.ChangeReceiverStatus) (*graph.Receiver, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, graph.ChangeReceiverStatus) *graph.Receiver); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.Receiver)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, graph.ChangeReceiverStatus) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlockReceiver provides a mock function with given fields: ctx, input
+func (_m *MutationResolver) BlockReceiver(ctx context.Context, input graph.ChangeReceiverStatus) (*graph.Receiver, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 *graph.Receiver
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, graph.ChangeReceiverStatus) (*graph.Receiver, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, graph.ChangeReceiverStatus) *graph.Receiver); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.Receiver)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, graph.ChangeReceiverStatus) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangeTransferStatus provides a mock function with given fields: ctx, input
+func (_m *MutationResolver) ChangeTransferStatus(ctx context.Context, input graph.ChangeTransferStatus) (*graph.Transfer, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 *graph.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, graph.ChangeTransferStatus) (*graph.Transfer, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, graph.ChangeTransferStatus) *graph.Transfer); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, graph.ChangeTransferStatus) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateReceiver provides a mock function with given fields: ctx, input
 func (_m *MutationResolver) CreateReceiver(ctx context.Context, input graph.NewReceiver) (*graph.Receiver, error) {
 	ret := _m.Called(ctx, input)
@@ -40,6 +118,58 @@ func (_m *MutationResolver) CreateReceiver(ctx context.Context, input graph.NewR
 	return r0, r1
 }
 
+// CreateReceiverFromBRCode provides a mock function with given fields: ctx, payload, identifier, email
+func (_m *MutationResolver) CreateReceiverFromBRCode(ctx context.Context, payload string, identifier string, email string) (*graph.Receiver, error) {
+	ret := _m.Called(ctx, payload, identifier, email)
+
+	var r0 *graph.Receiver
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*graph.Receiver, error)); ok {
+		return rf(ctx, payload, identifier, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *graph.Receiver); ok {
+		r0 = rf(ctx, payload, identifier, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.Receiver)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, payload, identifier, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTransfer provides a mock function with given fields: ctx, input
+func (_m *MutationResolver) CreateTransfer(ctx context.Context, input graph.NewTransfer) (*graph.Transfer, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 *graph.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, graph.NewTransfer) (*graph.Transfer, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, graph.NewTransfer) *graph.Transfer); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, graph.NewTransfer) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteReceivers provides a mock function with given fields: ctx, ids
 func (_m *MutationResolver) DeleteReceivers(ctx context.Context, ids []string) (string, error) {
 	ret := _m.Called(ctx, ids)
@@ -64,19 +194,47 @@ func (_m *MutationResolver) DeleteReceivers(ctx context.Context, ids []string) (
 	return r0, r1
 }
 
+// UnblockReceiver provides a mock function with given fields: ctx, input
+func (_m *MutationResolver) UnblockReceiver(ctx context.Context, input graph.ChangeReceiverStatus) (*graph.Receiver, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 *graph.Receiver
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, graph.ChangeReceiverStatus) (*graph.Receiver, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, graph.ChangeReceiverStatus) *graph.Receiver); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.Receiver)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, graph.ChangeReceiverStatus) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateReceiver provides a mock function with given fields: ctx, input
-func (_m *MutationResolver) UpdateReceiver(ctx context.Context, input graph.UpdateReceiver) (string, error) {
+func (_m *MutationResolver) UpdateReceiver(ctx context.Context, input graph.UpdateReceiver) (*graph.Receiver, error) {
 	ret := _m.Called(ctx, input)
 
-	var r0 string
+	var r0 *graph.Receiver
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, graph.UpdateReceiver) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, graph.UpdateReceiver) (*graph.Receiver, error)); ok {
 		return rf(ctx, input)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, graph.UpdateReceiver) string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, graph.UpdateReceiver) *graph.Receiver); ok {
 		r0 = rf(ctx, input)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.Receiver)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, graph.UpdateReceiver) error); ok {
@@ -88,6 +246,32 @@ func (_m *MutationResolver) UpdateReceiver(ctx context.Context, input graph.Upda
 	return r0, r1
 }
 
+// ValidateReceiver provides a mock function with given fields: ctx, input
+func (_m *MutationResolver) ValidateReceiver(ctx context.Context, input graph.ChangeReceiverStatus) (*graph.Receiver, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 *graph.Receiver
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, graph.ChangeReceiverStatus) (*graph.Receiver, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, graph.ChangeReceiverStatus) *graph.Receiver); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.Receiver)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, graph.ChangeReceiverStatus) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewMutationResolver interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,56 @@
+// Code generated by mockery v2.20.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	dict "github.com/teste-transfeera/internal/dict"
+)
+
+// PixDirectory is an autogenerated mock type for the PixDirectory type
+type PixDirectory struct {
+	mock.Mock
+}
+
+// Lookup provides a mock function with given fields: ctx, key
+func (_m *PixDirectory) Lookup(ctx context.Context, key string) (*dict.Entry, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 *dict.Entry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*dict.Entry, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *dict.Entry); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dict.Entry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewPixDirectory interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPixDirectory creates a new instance of PixDirectory. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPixDirectory(t mockConstructorTestingTNewPixDirectory) *PixDirectory {
+	mock := &PixDirectory{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
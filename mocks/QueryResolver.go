@@ -14,25 +14,77 @@ type QueryResolver struct {
 	mock.Mock
 }
 
-// ListReceivers provides a mock function with given fields: ctx, first, after, status, name, keyType, key
-func (_m *QueryResolver) ListReceivers(ctx context.Context, first *int, after *string, status *string, name *string, keyType *string, key *string) (*graph.Receivers, error) {
-	ret := _m.Called(ctx, first, after, status, name, keyType, key)
+// Banks provides a mock function with given fields: ctx, search
+func (_m *QueryResolver) Banks(ctx context.Context, search *string) ([]*graph.Bank, error) {
+	ret := _m.Called(ctx, search)
+
+	var r0 []*graph.Bank
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *string) ([]*graph.Bank, error)); ok {
+		return rf(ctx, search)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *string) []*graph.Bank); ok {
+		r0 = rf(ctx, search)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*graph.Bank)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *string) error); ok {
+		r1 = rf(ctx, search)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReceivers provides a mock function with given fields: ctx, first, after, last, before, orderBy, status, name, keyType, key, search
+func (_m *QueryResolver) ListReceivers(ctx context.Context, first *int, after *string, last *int, before *string, orderBy *graph.ReceiverOrder, status *string, name *string, keyType *string, key *string, search *string) (*graph.Receivers, error) {
+	ret := _m.Called(ctx, first, after, last, before, orderBy, status, name, keyType, key, search)
 
 	var r0 *graph.Receivers
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *int, *string, *string, *string, *string, *string) (*graph.Receivers, error)); ok {
-		return rf(ctx, first, after, status, name, keyType, key)
+	if rf, ok := ret.Get(0).(func(context.Context, *int, *string, *int, *string, *graph.ReceiverOrder, *string, *string, *string, *string, *string) (*graph.Receivers, error)); ok {
+		return rf(ctx, first, after, last, before, orderBy, status, name, keyType, key, search)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *int, *string, *string, *string, *string, *string) *graph.Receivers); ok {
-		r0 = rf(ctx, first, after, status, name, keyType, key)
+	if rf, ok := ret.Get(0).(func(context.Context, *int, *string, *int, *string, *graph.ReceiverOrder, *string, *string, *string, *string, *string) *graph.Receivers); ok {
+		r0 = rf(ctx, first, after, last, before, orderBy, status, name, keyType, key, search)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*graph.Receivers)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *int, *string, *string, *string, *string, *string) error); ok {
-		r1 = rf(ctx, first, after, status, name, keyType, key)
+	if rf, ok := ret.Get(1).(func(context.Context, *int, *string, *int, *string, *graph.ReceiverOrder, *string, *string, *string, *string, *string) error); ok {
+		r1 = rf(ctx, first, after, last, before, orderBy, status, name, keyType, key, search)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTransfers provides a mock function with given fields: ctx, receiverID, status, first, after
+func (_m *QueryResolver) ListTransfers(ctx context.Context, receiverID *string, status *graph.TransferStatus, first *int, after *string) (*graph.Transfers, error) {
+	ret := _m.Called(ctx, receiverID, status, first, after)
+
+	var r0 *graph.Transfers
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *string, *graph.TransferStatus, *int, *string) (*graph.Transfers, error)); ok {
+		return rf(ctx, receiverID, status, first, after)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *string, *graph.TransferStatus, *int, *string) *graph.Transfers); ok {
+		r0 = rf(ctx, receiverID, status, first, after)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.Transfers)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *string, *graph.TransferStatus, *int, *string) error); ok {
+		r1 = rf(ctx, receiverID, status, first, after)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -66,6 +118,82 @@ func (_m *QueryResolver) Receiver(ctx context.Context, id string) (*graph.Receiv
 	return r0, r1
 }
 
+// ReceiverHistory provides a mock function with given fields: ctx, id, first, after
+func (_m *QueryResolver) ReceiverHistory(ctx context.Context, id string, first *int, after *string) (*graph.ReceiverHistory, error) {
+	ret := _m.Called(ctx, id, first, after)
+
+	var r0 *graph.ReceiverHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int, *string) (*graph.ReceiverHistory, error)); ok {
+		return rf(ctx, id, first, after)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int, *string) *graph.ReceiverHistory); ok {
+		r0 = rf(ctx, id, first, after)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.ReceiverHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *int, *string) error); ok {
+		r1 = rf(ctx, id, first, after)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceiverPixBRCode provides a mock function with given fields: ctx, id, amount, txid, description
+func (_m *QueryResolver) ReceiverPixBRCode(ctx context.Context, id string, amount *int, txid *string, description *string) (string, error) {
+	ret := _m.Called(ctx, id, amount, txid, description)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int, *string, *string) (string, error)); ok {
+		return rf(ctx, id, amount, txid, description)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int, *string, *string) string); ok {
+		r0 = rf(ctx, id, amount, txid, description)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *int, *string, *string) error); ok {
+		r1 = rf(ctx, id, amount, txid, description)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Transfer provides a mock function with given fields: ctx, id
+func (_m *QueryResolver) Transfer(ctx context.Context, id string) (*graph.Transfer, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *graph.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*graph.Transfer, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *graph.Transfer); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*graph.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewQueryResolver interface {
 	mock.TestingT
 	Cleanup(func())
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
 	entity "github.com/teste-transfeera/internal/entity"
 )
@@ -12,25 +14,49 @@ type ReceiverRepository struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: receiver
-func (_m *ReceiverRepository) Create(receiver entity.Receiver) (*entity.Receiver, error) {
-	ret := _m.Called(receiver)
+// Count provides a mock function with given fields: ctx, filter
+func (_m *ReceiverRepository) Count(ctx context.Context, filter map[string]string) (int64, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]string) (int64, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]string) int64); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, map[string]string) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, receiver
+func (_m *ReceiverRepository) Create(ctx context.Context, receiver entity.Receiver) (*entity.Receiver, error) {
+	ret := _m.Called(ctx, receiver)
 
 	var r0 *entity.Receiver
 	var r1 error
-	if rf, ok := ret.Get(0).(func(entity.Receiver) (*entity.Receiver, error)); ok {
-		return rf(receiver)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Receiver) (*entity.Receiver, error)); ok {
+		return rf(ctx, receiver)
 	}
-	if rf, ok := ret.Get(0).(func(entity.Receiver) *entity.Receiver); ok {
-		r0 = rf(receiver)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Receiver) *entity.Receiver); ok {
+		r0 = rf(ctx, receiver)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Receiver)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(entity.Receiver) error); ok {
-		r1 = rf(receiver)
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Receiver) error); ok {
+		r1 = rf(ctx, receiver)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -38,13 +64,13 @@ func (_m *ReceiverRepository) Create(receiver entity.Receiver) (*entity.Receiver
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: ids
-func (_m *ReceiverRepository) Delete(ids []string) error {
-	ret := _m.Called(ids)
+// Delete provides a mock function with given fields: ctx, ids
+func (_m *ReceiverRepository) Delete(ctx context.Context, ids []string) error {
+	ret := _m.Called(ctx, ids)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func([]string) error); ok {
-		r0 = rf(ids)
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, ids)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -52,25 +78,51 @@ func (_m *ReceiverRepository) Delete(ids []string) error {
 	return r0
 }
 
-// FindById provides a mock function with given fields: id
-func (_m *ReceiverRepository) FindById(id string) (*entity.Receiver, error) {
-	ret := _m.Called(id)
+// FindById provides a mock function with given fields: ctx, id
+func (_m *ReceiverRepository) FindById(ctx context.Context, id string) (*entity.Receiver, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *entity.Receiver
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Receiver, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Receiver); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Receiver)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByIdentifier provides a mock function with given fields: ctx, identifier
+func (_m *ReceiverRepository) FindByIdentifier(ctx context.Context, identifier string) (*entity.Receiver, error) {
+	ret := _m.Called(ctx, identifier)
 
 	var r0 *entity.Receiver
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*entity.Receiver, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Receiver, error)); ok {
+		return rf(ctx, identifier)
 	}
-	if rf, ok := ret.Get(0).(func(string) *entity.Receiver); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Receiver); ok {
+		r0 = rf(ctx, identifier)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Receiver)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, identifier)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -78,25 +130,51 @@ func (_m *ReceiverRepository) FindById(id string) (*entity.Receiver, error) {
 	return r0, r1
 }
 
-// List provides a mock function with given fields: filter
-func (_m *ReceiverRepository) List(filter map[string]string) ([]entity.Receiver, error) {
-	ret := _m.Called(filter)
+// FindByIds provides a mock function with given fields: ctx, ids
+func (_m *ReceiverRepository) FindByIds(ctx context.Context, ids []string) ([]entity.Receiver, error) {
+	ret := _m.Called(ctx, ids)
 
 	var r0 []entity.Receiver
 	var r1 error
-	if rf, ok := ret.Get(0).(func(map[string]string) ([]entity.Receiver, error)); ok {
-		return rf(filter)
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]entity.Receiver, error)); ok {
+		return rf(ctx, ids)
 	}
-	if rf, ok := ret.Get(0).(func(map[string]string) []entity.Receiver); ok {
-		r0 = rf(filter)
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []entity.Receiver); ok {
+		r0 = rf(ctx, ids)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]entity.Receiver)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(map[string]string) error); ok {
-		r1 = rf(filter)
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, filter, page
+func (_m *ReceiverRepository) List(ctx context.Context, filter map[string]string, page entity.PageRequest) (*entity.ReceiverPage, error) {
+	ret := _m.Called(ctx, filter, page)
+
+	var r0 *entity.ReceiverPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]string, entity.PageRequest) (*entity.ReceiverPage, error)); ok {
+		return rf(ctx, filter, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]string, entity.PageRequest) *entity.ReceiverPage); ok {
+		r0 = rf(ctx, filter, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ReceiverPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, map[string]string, entity.PageRequest) error); ok {
+		r1 = rf(ctx, filter, page)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -104,13 +182,13 @@ func (_m *ReceiverRepository) List(filter map[string]string) ([]entity.Receiver,
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: id, fields
-func (_m *ReceiverRepository) Update(id string, fields map[string]string) error {
-	ret := _m.Called(id, fields)
+// Update provides a mock function with given fields: ctx, id, version, fields
+func (_m *ReceiverRepository) Update(ctx context.Context, id string, version int64, fields map[string]string) error {
+	ret := _m.Called(ctx, id, version, fields)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, map[string]string) error); ok {
-		r0 = rf(id, fields)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, map[string]string) error); ok {
+		r0 = rf(ctx, id, version, fields)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3,9 +3,14 @@
 package mocks
 
 import (
-	mock "github.com/stretchr/testify/mock"
+	context "context"
+
+	bank "github.com/teste-transfeera/pkg/bank"
+
 	entity "github.com/teste-transfeera/internal/entity"
 
+	mock "github.com/stretchr/testify/mock"
+
 	usecase "github.com/teste-transfeera/internal/usecase"
 )
 
@@ -14,25 +19,25 @@ type ReceiverUseCases struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: input
-func (_m *ReceiverUseCases) Create(input *usecase.CreateReceiverInput) (*entity.Receiver, error) {
-	ret := _m.Called(input)
+// ChangeStatus provides a mock function with given fields: ctx, input
+func (_m *ReceiverUseCases) ChangeStatus(ctx context.Context, input *usecase.ChangeReceiverStatusInput) (*entity.Receiver, error) {
+	ret := _m.Called(ctx, input)
 
 	var r0 *entity.Receiver
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*usecase.CreateReceiverInput) (*entity.Receiver, error)); ok {
-		return rf(input)
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.ChangeReceiverStatusInput) (*entity.Receiver, error)); ok {
+		return rf(ctx, input)
 	}
-	if rf, ok := ret.Get(0).(func(*usecase.CreateReceiverInput) *entity.Receiver); ok {
-		r0 = rf(input)
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.ChangeReceiverStatusInput) *entity.Receiver); ok {
+		r0 = rf(ctx, input)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Receiver)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*usecase.CreateReceiverInput) error); ok {
-		r1 = rf(input)
+	if rf, ok := ret.Get(1).(func(context.Context, *usecase.ChangeReceiverStatusInput) error); ok {
+		r1 = rf(ctx, input)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -40,39 +45,49 @@ func (_m *ReceiverUseCases) Create(input *usecase.CreateReceiverInput) (*entity.
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: input
-func (_m *ReceiverUseCases) Delete(input *usecase.DeleteReceiverInput) error {
-	ret := _m.Called(input)
+// Count provides a mock function with given fields: ctx, filter
+func (_m *ReceiverUseCases) Count(ctx context.Context, filter map[string]string) (int64, error) {
+	ret := _m.Called(ctx, filter)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*usecase.DeleteReceiverInput) error); ok {
-		r0 = rf(input)
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]string) (int64, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]string) int64); ok {
+		r0 = rf(ctx, filter)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, map[string]string) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// List provides a mock function with given fields: filter
-func (_m *ReceiverUseCases) List(filter map[string]string) ([]entity.Receiver, error) {
-	ret := _m.Called(filter)
+// Create provides a mock function with given fields: ctx, input
+func (_m *ReceiverUseCases) Create(ctx context.Context, input *usecase.CreateReceiverInput) (*entity.Receiver, error) {
+	ret := _m.Called(ctx, input)
 
-	var r0 []entity.Receiver
+	var r0 *entity.Receiver
 	var r1 error
-	if rf, ok := ret.Get(0).(func(map[string]string) ([]entity.Receiver, error)); ok {
-		return rf(filter)
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.CreateReceiverInput) (*entity.Receiver, error)); ok {
+		return rf(ctx, input)
 	}
-	if rf, ok := ret.Get(0).(func(map[string]string) []entity.Receiver); ok {
-		r0 = rf(filter)
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.CreateReceiverInput) *entity.Receiver); ok {
+		r0 = rf(ctx, input)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]entity.Receiver)
+			r0 = ret.Get(0).(*entity.Receiver)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(map[string]string) error); ok {
-		r1 = rf(filter)
+	if rf, ok := ret.Get(1).(func(context.Context, *usecase.CreateReceiverInput) error); ok {
+		r1 = rf(ctx, input)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -80,25 +95,25 @@ func (_m *ReceiverUseCases) List(filter map[string]string) ([]entity.Receiver, e
 	return r0, r1
 }
 
-// ListById provides a mock function with given fields: input
-func (_m *ReceiverUseCases) ListById(input *usecase.ListReceiverByIdInput) (*entity.Receiver, error) {
-	ret := _m.Called(input)
+// CreateFromBRCode provides a mock function with given fields: ctx, input
+func (_m *ReceiverUseCases) CreateFromBRCode(ctx context.Context, input *usecase.CreateReceiverFromBRCodeInput) (*entity.Receiver, error) {
+	ret := _m.Called(ctx, input)
 
 	var r0 *entity.Receiver
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*usecase.ListReceiverByIdInput) (*entity.Receiver, error)); ok {
-		return rf(input)
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.CreateReceiverFromBRCodeInput) (*entity.Receiver, error)); ok {
+		return rf(ctx, input)
 	}
-	if rf, ok := ret.Get(0).(func(*usecase.ListReceiverByIdInput) *entity.Receiver); ok {
-		r0 = rf(input)
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.CreateReceiverFromBRCodeInput) *entity.Receiver); ok {
+		r0 = rf(ctx, input)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Receiver)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*usecase.ListReceiverByIdInput) error); ok {
-		r1 = rf(input)
+	if rf, ok := ret.Get(1).(func(context.Context, *usecase.CreateReceiverFromBRCodeInput) error); ok {
+		r1 = rf(ctx, input)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -106,13 +121,13 @@ func (_m *ReceiverUseCases) ListById(input *usecase.ListReceiverByIdInput) (*ent
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: input
-func (_m *ReceiverUseCases) Update(input *usecase.UpdateReceiverInput) error {
-	ret := _m.Called(input)
+// Delete provides a mock function with given fields: ctx, input
+func (_m *ReceiverUseCases) Delete(ctx context.Context, input *usecase.DeleteReceiverInput) error {
+	ret := _m.Called(ctx, input)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*usecase.UpdateReceiverInput) error); ok {
-		r0 = rf(input)
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.DeleteReceiverInput) error); ok {
+		r0 = rf(ctx, input)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -120,6 +135,160 @@ func (_m *ReceiverUseCases) Update(input *usecase.UpdateReceiverInput) error {
 	return r0
 }
 
+// GeneratePixBRCode provides a mock function with given fields: ctx, input
+func (_m *ReceiverUseCases) GeneratePixBRCode(ctx context.Context, input *usecase.GeneratePixBRCodeInput) (string, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.GeneratePixBRCodeInput) (string, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.GeneratePixBRCodeInput) string); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *usecase.GeneratePixBRCodeInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, filter, page
+func (_m *ReceiverUseCases) List(ctx context.Context, filter map[string]string, page entity.PageRequest) (*entity.ReceiverPage, error) {
+	ret := _m.Called(ctx, filter, page)
+
+	var r0 *entity.ReceiverPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]string, entity.PageRequest) (*entity.ReceiverPage, error)); ok {
+		return rf(ctx, filter, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]string, entity.PageRequest) *entity.ReceiverPage); ok {
+		r0 = rf(ctx, filter, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.ReceiverPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, map[string]string, entity.PageRequest) error); ok {
+		r1 = rf(ctx, filter, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListBanks provides a mock function with given fields: ctx, input
+func (_m *ReceiverUseCases) ListBanks(ctx context.Context, input *usecase.ListBanksInput) ([]bank.Institution, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 []bank.Institution
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.ListBanksInput) ([]bank.Institution, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.ListBanksInput) []bank.Institution); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bank.Institution)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *usecase.ListBanksInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListById provides a mock function with given fields: ctx, input
+func (_m *ReceiverUseCases) ListById(ctx context.Context, input *usecase.ListReceiverByIdInput) (*entity.Receiver, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 *entity.Receiver
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.ListReceiverByIdInput) (*entity.Receiver, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.ListReceiverByIdInput) *entity.Receiver); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Receiver)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *usecase.ListReceiverByIdInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListHistory provides a mock function with given fields: ctx, input
+func (_m *ReceiverUseCases) ListHistory(ctx context.Context, input *usecase.ListReceiverHistoryInput) (*entity.HistoryPage, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 *entity.HistoryPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.ListReceiverHistoryInput) (*entity.HistoryPage, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.ListReceiverHistoryInput) *entity.HistoryPage); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.HistoryPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *usecase.ListReceiverHistoryInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, input
+func (_m *ReceiverUseCases) Update(ctx context.Context, input *usecase.UpdateReceiverInput) (*entity.Receiver, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 *entity.Receiver
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.UpdateReceiverInput) (*entity.Receiver, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.UpdateReceiverInput) *entity.Receiver); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Receiver)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *usecase.UpdateReceiverInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewReceiverUseCases interface {
 	mock.TestingT
 	Cleanup(func())
@@ -44,6 +44,22 @@ func (_m *ResolverRoot) Query() graph.QueryResolver {
 	return r0
 }
 
+// Receiver provides a mock function with given fields:
+func (_m *ResolverRoot) Receiver() graph.ReceiverResolver {
+	ret := _m.Called()
+
+	var r0 graph.ReceiverResolver
+	if rf, ok := ret.Get(0).(func() graph.ReceiverResolver); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(graph.ReceiverResolver)
+		}
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewResolverRoot interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,150 @@
+// Code generated by mockery v2.20.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	entity "github.com/teste-transfeera/internal/entity"
+
+	repository "github.com/teste-transfeera/internal/repository"
+)
+
+// TransferRepository is an autogenerated mock type for the TransferRepository type
+type TransferRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, transfer
+func (_m *TransferRepository) Create(ctx context.Context, transfer entity.Transfer) (*entity.Transfer, error) {
+	ret := _m.Called(ctx, transfer)
+
+	var r0 *entity.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Transfer) (*entity.Transfer, error)); ok {
+		return rf(ctx, transfer)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Transfer) *entity.Transfer); ok {
+		r0 = rf(ctx, transfer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.Transfer) error); ok {
+		r1 = rf(ctx, transfer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindById provides a mock function with given fields: ctx, id
+func (_m *TransferRepository) FindById(ctx context.Context, id string) (*entity.Transfer, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *entity.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Transfer, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Transfer); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByIdempotencyKey provides a mock function with given fields: ctx, key
+func (_m *TransferRepository) FindByIdempotencyKey(ctx context.Context, key string) (*entity.Transfer, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 *entity.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.Transfer, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.Transfer); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, filter, limit, after
+func (_m *TransferRepository) List(ctx context.Context, filter repository.TransferFilter, limit int, after *string) (*entity.TransferPage, error) {
+	ret := _m.Called(ctx, filter, limit, after)
+
+	var r0 *entity.TransferPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.TransferFilter, int, *string) (*entity.TransferPage, error)); ok {
+		return rf(ctx, filter, limit, after)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.TransferFilter, int, *string) *entity.TransferPage); ok {
+		r0 = rf(ctx, filter, limit, after)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.TransferPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.TransferFilter, int, *string) error); ok {
+		r1 = rf(ctx, filter, limit, after)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, id, from, to
+func (_m *TransferRepository) UpdateStatus(ctx context.Context, id string, from entity.TransferStatus, to entity.TransferStatus) error {
+	ret := _m.Called(ctx, id, from, to)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.TransferStatus, entity.TransferStatus) error); ok {
+		r0 = rf(ctx, id, from, to)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewTransferRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTransferRepository creates a new instance of TransferRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTransferRepository(t mockConstructorTestingTNewTransferRepository) *TransferRepository {
+	mock := &TransferRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
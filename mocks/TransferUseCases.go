@@ -0,0 +1,136 @@
+// Code generated by mockery v2.20.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	entity "github.com/teste-transfeera/internal/entity"
+
+	usecase "github.com/teste-transfeera/internal/usecase"
+)
+
+// TransferUseCases is an autogenerated mock type for the TransferUseCases type
+type TransferUseCases struct {
+	mock.Mock
+}
+
+// ChangeStatus provides a mock function with given fields: ctx, input
+func (_m *TransferUseCases) ChangeStatus(ctx context.Context, input *usecase.ChangeTransferStatusInput) (*entity.Transfer, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 *entity.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.ChangeTransferStatusInput) (*entity.Transfer, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.ChangeTransferStatusInput) *entity.Transfer); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *usecase.ChangeTransferStatusInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, input
+func (_m *TransferUseCases) Create(ctx context.Context, input *usecase.CreateTransferInput) (*entity.Transfer, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 *entity.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.CreateTransferInput) (*entity.Transfer, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.CreateTransferInput) *entity.Transfer); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *usecase.CreateTransferInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, input
+func (_m *TransferUseCases) List(ctx context.Context, input *usecase.ListTransfersInput) (*entity.TransferPage, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 *entity.TransferPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.ListTransfersInput) (*entity.TransferPage, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.ListTransfersInput) *entity.TransferPage); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.TransferPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *usecase.ListTransfersInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListById provides a mock function with given fields: ctx, input
+func (_m *TransferUseCases) ListById(ctx context.Context, input *usecase.ListTransferByIdInput) (*entity.Transfer, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 *entity.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.ListTransferByIdInput) (*entity.Transfer, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *usecase.ListTransferByIdInput) *entity.Transfer); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *usecase.ListTransferByIdInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewTransferUseCases interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTransferUseCases creates a new instance of TransferUseCases. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTransferUseCases(t mockConstructorTestingTNewTransferUseCases) *TransferUseCases {
+	mock := &TransferUseCases{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,26 @@
+package actor
+
+import "context"
+
+// Anonymous is the actor of requests that do not identify who made them.
+const Anonymous = "anonymous"
+
+type contextKey struct{}
+
+// WithActor returns a copy of ctx carrying the name of who is making the
+// request. An empty name is stored as Anonymous.
+func WithActor(ctx context.Context, name string) context.Context {
+	if name == "" {
+		name = Anonymous
+	}
+	return context.WithValue(ctx, contextKey{}, name)
+}
+
+// FromContext returns the actor stored in ctx, or Anonymous when there is
+// none.
+func FromContext(ctx context.Context) string {
+	if name, ok := ctx.Value(contextKey{}).(string); ok {
+		return name
+	}
+	return Anonymous
+}
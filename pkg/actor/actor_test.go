@@ -0,0 +1,22 @@
+package actor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teste-transfeera/pkg/actor"
+)
+
+func Test_Actor(t *testing.T) {
+	t.Run("Actor stored in context is returned", func(t *testing.T) {
+		ctx := actor.WithActor(context.Background(), "maria@transfeera.com")
+
+		assert.Equal(t, "maria@transfeera.com", actor.FromContext(ctx))
+	})
+
+	t.Run("Context without actor returns anonymous", func(t *testing.T) {
+		assert.Equal(t, actor.Anonymous, actor.FromContext(context.Background()))
+		assert.Equal(t, actor.Anonymous, actor.FromContext(actor.WithActor(context.Background(), "")))
+	})
+}
@@ -0,0 +1,115 @@
+// Package bank is the directory of Brazilian financial institutions, embedded
+// from banks.json, which gen.go generates from the STR and SPI participants
+// lists published by the Central Bank.
+package bank
+
+import (
+	_ "embed"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/teste-transfeera/pkg/search"
+)
+
+// Institution is a financial institution of the directory. Code is the three
+// digit COMPE code and ISPB the eight digit identifier in the payments
+// system, which also identifies the institution in Pix.
+type Institution struct {
+	Code           string `json:"code"`
+	ISPB           string `json:"ispb"`
+	ShortName      string `json:"shortName"`
+	Name           string `json:"name"`
+	PixParticipant bool   `json:"pixParticipant"`
+}
+
+//go:generate go run gen.go
+
+//go:embed banks.json
+var banksJSON []byte
+
+var (
+	institutions []Institution
+	byCode       = map[string]Institution{}
+	byISPB       = map[string]Institution{}
+)
+
+func init() {
+	if err := json.Unmarshal(banksJSON, &institutions); err != nil {
+		panic("bank: invalid banks.json: " + err.Error())
+	}
+
+	sort.Slice(institutions, func(i, j int) bool {
+		return institutions[i].Code < institutions[j].Code
+	})
+	for _, institution := range institutions {
+		byCode[institution.Code] = institution
+		byISPB[institution.ISPB] = institution
+	}
+}
+
+// ByCode returns the institution with the COMPE code.
+func ByCode(code string) (Institution, bool) {
+	institution, ok := byCode[code]
+	return institution, ok
+}
+
+// ByISPB returns the institution with the ISPB.
+func ByISPB(ispb string) (Institution, bool) {
+	institution, ok := byISPB[ispb]
+	return institution, ok
+}
+
+// ByName returns the institution a free text bank name, such as "Bradesco"
+// or "Banco do Brasil", refers to. Among the institutions matching every word
+// it takes the one with the shortest name, so "Bradesco" is Banco Bradesco
+// rather than a longer namesake, and finds none on a tie.
+func ByName(name string) (Institution, bool) {
+	if len(search.Words(name)) == 0 {
+		return Institution{}, false
+	}
+
+	var found Institution
+	shortest, tie := 0, false
+	for _, institution := range Search(name) {
+		length := len(search.Words(institution.Name))
+		switch {
+		case shortest == 0 || length < shortest:
+			found, shortest, tie = institution, length, false
+		case length == shortest:
+			tie = true
+		}
+	}
+	return found, shortest > 0 && !tie
+}
+
+// Search returns the institutions, ordered by code, whose code, ISPB or names
+// contain every word of the query. An empty query returns all of them.
+func Search(query string) []Institution {
+	words := search.Query(query)
+
+	result := []Institution{}
+	for _, institution := range institutions {
+		terms := search.Terms(institution.Code, institution.ISPB, institution.ShortName, institution.Name)
+		if matchesAll(terms, words) {
+			result = append(result, institution)
+		}
+	}
+	return result
+}
+
+func matchesAll(terms []string, words []string) bool {
+	for _, word := range words {
+		found := false
+		for _, term := range terms {
+			if strings.HasPrefix(term, word) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
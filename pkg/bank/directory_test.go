@@ -0,0 +1,85 @@
+package bank_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teste-transfeera/pkg/bank"
+)
+
+func Test_Bank_Lookup(t *testing.T) {
+	t.Run("Should find an institution by COMPE code and ISPB", func(t *testing.T) {
+		byCode, ok := bank.ByCode("237")
+		assert.True(t, ok)
+		assert.Equal(t, "60746948", byCode.ISPB)
+		assert.Equal(t, "BCO BRADESCO S.A.", byCode.ShortName)
+
+		byISPB, ok := bank.ByISPB("60746948")
+		assert.True(t, ok)
+		assert.Equal(t, byCode, byISPB)
+	})
+
+	t.Run("Should not find an unknown code", func(t *testing.T) {
+		_, ok := bank.ByCode("999")
+		assert.False(t, ok)
+	})
+}
+
+func Test_Bank_ByName(t *testing.T) {
+	t.Run("Should find the institution of a free text name", func(t *testing.T) {
+		for name, code := range map[string]string{
+			"Bradesco":        "237",
+			"Banco do Brasil": "001",
+			"Santander":       "033",
+			"Itaú":            "341",
+			"caixa":           "104",
+		} {
+			institution, ok := bank.ByName(name)
+			assert.True(t, ok, name)
+			assert.Equal(t, code, institution.Code, name)
+		}
+	})
+
+	t.Run("Should not find an unknown or empty name", func(t *testing.T) {
+		_, ok := bank.ByName("Banco Imaginario")
+		assert.False(t, ok)
+
+		_, ok = bank.ByName("")
+		assert.False(t, ok)
+	})
+}
+
+func Test_Bank_Search(t *testing.T) {
+	t.Run("Should return every institution ordered by code for an empty query", func(t *testing.T) {
+		result := bank.Search("")
+
+		assert.NotEmpty(t, result)
+		assert.Equal(t, "001", result[0].Code)
+		for i := 1; i < len(result); i++ {
+			assert.Less(t, result[i-1].Code, result[i].Code)
+		}
+	})
+
+	t.Run("Should match names ignoring case and accents", func(t *testing.T) {
+		result := bank.Search("itau")
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, "341", result[0].Code)
+	})
+
+	t.Run("Should match every word of the query", func(t *testing.T) {
+		result := bank.Search("banco brasil")
+
+		var codes []string
+		for _, institution := range result {
+			codes = append(codes, institution.Code)
+		}
+		assert.Contains(t, codes, "001")
+		assert.NotContains(t, codes, "237")
+	})
+
+	t.Run("Should match codes and ISPBs", func(t *testing.T) {
+		assert.Equal(t, "260", bank.Search("260")[0].Code)
+		assert.Equal(t, "104", bank.Search("00360305")[0].Code)
+	})
+}
@@ -0,0 +1,234 @@
+//go:build ignore
+
+// gen rewrites banks.json from the participants lists published by the
+// Central Bank: the STR list, which has the COMPE code of every institution
+// that has one, and the SPI list, which has the Pix participants. Each flag
+// takes a URL or a file path. Without -spi, pixParticipant is kept from the
+// current banks.json.
+//
+//	go generate ./pkg/bank
+//	go run gen.go -spi lista-participantes-instituicoes-em-adesao-pix.csv
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const strURL = "https://www.bcb.gov.br/pom/spb/estatistica/port/ParticipantesSTRport.csv"
+
+type institution struct {
+	Code           string `json:"code"`
+	ISPB           string `json:"ispb"`
+	ShortName      string `json:"shortName"`
+	Name           string `json:"name"`
+	PixParticipant bool   `json:"pixParticipant"`
+}
+
+func main() {
+	strSource := flag.String("str", strURL, "URL or path of the STR participants CSV")
+	spiSource := flag.String("spi", "", "URL or path of the SPI participants CSV")
+	output := flag.String("o", "banks.json", "file to write")
+	flag.Parse()
+
+	institutions, err := readSTR(*strSource)
+	if err != nil {
+		log.Fatalf("str: %v", err)
+	}
+
+	var pix map[string]bool
+	if *spiSource != "" {
+		pix, err = readSPI(*spiSource)
+	} else {
+		pix, err = readCurrentPix(*output)
+	}
+	if err != nil {
+		log.Fatalf("spi: %v", err)
+	}
+	for i := range institutions {
+		institutions[i].PixParticipant = pix[institutions[i].ISPB]
+	}
+
+	if err := write(*output, institutions); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("%d institutions written to %s", len(institutions), *output)
+}
+
+// readSTR reads the institutions with a COMPE code, whose header is
+// ISPB, Nome_Reduzido, Número_Código, Participa_da_Compe, Acesso_Principal,
+// Nome_Extenso and Início_da_Operação.
+func readSTR(source string) ([]institution, error) {
+	records, err := readCSV(source)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("no institutions")
+	}
+
+	header := columns(records[0])
+	ispb, shortName, code, name := header.index("ISPB"), header.index("Nome_Reduzido"), header.index("Número_Código"), header.index("Nome_Extenso")
+	if ispb < 0 || shortName < 0 || code < 0 || name < 0 {
+		return nil, fmt.Errorf("unexpected header %v", records[0])
+	}
+
+	byCode := map[string]institution{}
+	for _, record := range records[1:] {
+		if len(record) < len(header) {
+			continue
+		}
+		number, err := strconv.Atoi(strings.TrimSpace(record[code]))
+		if err != nil {
+			continue
+		}
+		result := institution{
+			Code:      fmt.Sprintf("%03d", number),
+			ISPB:      padLeft(strings.TrimSpace(record[ispb]), 8),
+			ShortName: strings.TrimSpace(record[shortName]),
+			Name:      strings.TrimSpace(record[name]),
+		}
+		byCode[result.Code] = result
+	}
+
+	institutions := make([]institution, 0, len(byCode))
+	for _, result := range byCode {
+		institutions = append(institutions, result)
+	}
+	sort.Slice(institutions, func(i, j int) bool {
+		return institutions[i].Code < institutions[j].Code
+	})
+	return institutions, nil
+}
+
+// readSPI returns the ISPBs of the Pix participants, read from the ISPB
+// column of the list.
+func readSPI(source string) (map[string]bool, error) {
+	records, err := readCSV(source)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("no participants")
+	}
+
+	ispb := columns(records[0]).index("ISPB")
+	if ispb < 0 {
+		return nil, fmt.Errorf("unexpected header %v", records[0])
+	}
+
+	pix := map[string]bool{}
+	for _, record := range records[1:] {
+		if ispb < len(record) {
+			pix[padLeft(strings.TrimSpace(record[ispb]), 8)] = true
+		}
+	}
+	return pix, nil
+}
+
+func readCurrentPix(path string) (map[string]bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var current []institution
+	if err := json.Unmarshal(data, &current); err != nil {
+		return nil, err
+	}
+
+	pix := map[string]bool{}
+	for _, institution := range current {
+		pix[institution.ISPB] = institution.PixParticipant
+	}
+	return pix, nil
+}
+
+// readCSV reads a list separated by commas or, as some lists of the Central
+// Bank are, by semicolons.
+func readCSV(source string) ([][]string, error) {
+	data, err := open(source)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	if firstLine, _, _ := bytes.Cut(data, []byte("\n")); bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+	return reader.ReadAll()
+}
+
+func open(source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		return os.ReadFile(source)
+	}
+
+	response, err := http.Get(source)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", source, response.Status)
+	}
+	return io.ReadAll(response.Body)
+}
+
+// columns is the header of a list, whose index is -1 for names it does not
+// have.
+type columns []string
+
+func (c columns) index(name string) int {
+	for i, column := range c {
+		if strings.TrimSpace(column) == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func padLeft(value string, length int) string {
+	if len(value) >= length {
+		return value
+	}
+	return strings.Repeat("0", length-len(value)) + value
+}
+
+// write keeps the layout of banks.json, one institution per line.
+func write(path string, institutions []institution) error {
+	var buffer bytes.Buffer
+	buffer.WriteString("[\n")
+	for i, institution := range institutions {
+		fmt.Fprintf(&buffer, `  {"code": %s, "ispb": %s, "shortName": %s, "name": %s, "pixParticipant": %t}`,
+			quote(institution.Code), quote(institution.ISPB), quote(institution.ShortName), quote(institution.Name), institution.PixParticipant)
+		if i < len(institutions)-1 {
+			buffer.WriteString(",")
+		}
+		buffer.WriteString("\n")
+	}
+	buffer.WriteString("]\n")
+
+	return os.WriteFile(path, buffer.Bytes(), 0o644)
+}
+
+func quote(value string) string {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(value)
+	return strings.TrimSpace(buffer.String())
+}
@@ -0,0 +1,106 @@
+package cursor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/teste-transfeera/internal/entity"
+)
+
+const version = "v1"
+
+var (
+	ErrMalformed          = errors.New("Malformed cursor")
+	ErrUnsupportedVersion = errors.New("Unsupported cursor version")
+	ErrInvalidSignature   = errors.New("Cursor signature is invalid")
+	ErrSortMismatch       = errors.New("Cursor was issued for a different ordering")
+	ErrKindMismatch       = errors.New("Cursor was issued for a different list")
+)
+
+// Kind names the list a cursor belongs to, so a cursor of one connection is
+// not accepted by another that happens to share its ordering.
+type Kind string
+
+type payload struct {
+	Kind      string  `json:"t"`
+	Field     string  `json:"f"`
+	Direction string  `json:"d"`
+	Value     *string `json:"k,omitempty"`
+	ID        string  `json:"id"`
+}
+
+// Codec turns cursors into opaque strings of the form
+// "<version>.<payload>.<signature>", signing the version and payload with
+// HMAC-SHA256 so clients cannot forge or alter them.
+type Codec struct {
+	secret []byte
+}
+
+func NewCodec(secret []byte) *Codec {
+	return &Codec{
+		secret: secret,
+	}
+}
+
+func (c *Codec) Encode(kind Kind, cursor entity.Cursor, sort entity.Sort) string {
+	data, _ := json.Marshal(payload{
+		Kind:      string(kind),
+		Field:     string(sort.Field),
+		Direction: string(sort.Direction),
+		Value:     cursor.Value,
+		ID:        cursor.ID,
+	})
+
+	body := version + "." + base64.RawURLEncoding.EncodeToString(data)
+	return body + "." + base64.RawURLEncoding.EncodeToString(c.sign(body))
+}
+
+func (c *Codec) Decode(encoded string, kind Kind, sort entity.Sort) (*entity.Cursor, error) {
+	parts := strings.Split(encoded, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformed
+	}
+	if parts[0] != version {
+		return nil, ErrUnsupportedVersion
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	if !hmac.Equal(signature, c.sign(parts[0]+"."+parts[1])) {
+		return nil, ErrInvalidSignature
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+
+	var p payload
+	if err := json.Unmarshal(data, &p); err != nil || p.ID == "" {
+		return nil, ErrMalformed
+	}
+
+	if p.Kind != string(kind) {
+		return nil, ErrKindMismatch
+	}
+	if p.Field != string(sort.Field) || p.Direction != string(sort.Direction) {
+		return nil, ErrSortMismatch
+	}
+
+	return &entity.Cursor{
+		ID:    p.ID,
+		Value: p.Value,
+	}, nil
+}
+
+func (c *Codec) sign(body string) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(body))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,97 @@
+package cursor_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/pkg/cursor"
+	"github.com/teste-transfeera/pkg/shared"
+)
+
+const kind = cursor.Kind("receiver")
+
+func Test_Codec_Success(t *testing.T) {
+	codec := cursor.NewCodec([]byte("secret"))
+
+	t.Run("Should decode a cursor with sort key successfully", func(t *testing.T) {
+		sort := entity.Sort{Field: entity.SortByName, Direction: entity.Descending}
+		input := entity.Cursor{ID: "63f8c8d6c6ce914b5b00b88e", Value: shared.GetPointerStr("Receiver 1")}
+
+		result, err := codec.Decode(codec.Encode(kind, input, sort), kind, sort)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &input, result)
+	})
+
+	t.Run("Should decode a cursor without sort key successfully", func(t *testing.T) {
+		sort := entity.Sort{Field: entity.SortByUpdatedAt, Direction: entity.Ascending}
+		input := entity.Cursor{ID: "63f8c8d6c6ce914b5b00b88e"}
+
+		result, err := codec.Decode(codec.Encode(kind, input, sort), kind, sort)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &input, result)
+	})
+}
+
+func Test_Codec_Error(t *testing.T) {
+	codec := cursor.NewCodec([]byte("secret"))
+	input := entity.Cursor{ID: "63f8c8d6c6ce914b5b00b88e", Value: shared.GetPointerStr("Receiver 1")}
+	sort := entity.Sort{Field: entity.SortByName, Direction: entity.Ascending}
+
+	t.Run("Should get error for cursor signed with another secret", func(t *testing.T) {
+		encoded := cursor.NewCodec([]byte("other")).Encode(kind, input, sort)
+
+		result, err := codec.Decode(encoded, kind, sort)
+
+		assert.Equal(t, cursor.ErrInvalidSignature, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Should get error for tampered cursor payload", func(t *testing.T) {
+		parts := strings.Split(codec.Encode(kind, input, sort), ".")
+		forged := codec.Encode(kind, entity.Cursor{ID: "63f8c8d6c6ce914b5b00b88f"}, sort)
+		parts[1] = strings.Split(forged, ".")[1]
+
+		result, err := codec.Decode(strings.Join(parts, "."), kind, sort)
+
+		assert.Equal(t, cursor.ErrInvalidSignature, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Should get error for cursor issued for another ordering", func(t *testing.T) {
+		encoded := codec.Encode(kind, input, sort)
+
+		result, err := codec.Decode(encoded, kind, entity.Sort{Field: entity.SortByName, Direction: entity.Descending})
+
+		assert.Equal(t, cursor.ErrSortMismatch, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Should get error for cursor issued for another list with the same ordering", func(t *testing.T) {
+		encoded := codec.Encode(kind, input, sort)
+
+		result, err := codec.Decode(encoded, cursor.Kind("history"), sort)
+
+		assert.Equal(t, cursor.ErrKindMismatch, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Should get error for legacy base64 cursor", func(t *testing.T) {
+		result, err := codec.Decode(shared.EncodeBase64([]byte(input.ID)), kind, sort)
+
+		assert.Equal(t, cursor.ErrMalformed, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Should get error for unknown cursor version", func(t *testing.T) {
+		encoded := "v0" + strings.TrimPrefix(codec.Encode(kind, input, sort), "v1")
+
+		result, err := codec.Decode(encoded, kind, sort)
+
+		assert.Equal(t, cursor.ErrUnsupportedVersion, err)
+		assert.Nil(t, result)
+	})
+}
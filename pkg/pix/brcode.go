@@ -0,0 +1,167 @@
+// Package pix builds the BR Code, the EMV QR Code payload the Central Bank
+// defines for Pix charges.
+package pix
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/skip2/go-qrcode"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// GUI identifies Pix inside the merchant account information.
+const GUI = "br.gov.bcb.pix"
+
+// DefaultTxID is the transaction id of static charges without one.
+const DefaultTxID = "***"
+
+const (
+	idPayloadFormat       = "00"
+	idMerchantAccount     = "26"
+	idMerchantCategory    = "52"
+	idCurrency            = "53"
+	idAmount              = "54"
+	idCountry             = "58"
+	idMerchantName        = "59"
+	idMerchantCity        = "60"
+	idAdditionalData      = "62"
+	idCRC                 = "63"
+	idAccountGUI          = "00"
+	idAccountKey          = "01"
+	idAccountDescription  = "02"
+	idAdditionalDataTxID  = "05"
+	merchantNameMaxLength = 25
+	merchantCityMaxLength = 15
+	fieldMaxLength        = 99
+)
+
+var (
+	ErrMerchantAccountTooLong = errors.New("Pix key and description must have at most 99 characters together")
+	ErrInvalidTxID            = errors.New("TxID must have up to 25 letters or digits")
+	ErrInvalidAmount          = errors.New("Amount must be positive")
+	ErrInvalidMerchantName    = errors.New("Name must have letters or digits that can be written without accents")
+	ErrInvalidMerchantCity    = errors.New("City must have letters or digits that can be written without accents")
+)
+
+// BRCode is a static Pix charge. Amount is in cents, 0 letting the payer
+// choose it. Name and City are written without accents and cut to the
+// lengths the specification allows, and are required: a name made only of
+// characters outside Latin script is rejected rather than left empty.
+type BRCode struct {
+	Key         string
+	Name        string
+	City        string
+	Amount      int64
+	TxID        string
+	Description string
+}
+
+// Payload encodes the charge as EMV fields ending with their CRC16.
+func (c BRCode) Payload() (string, error) {
+	if c.Amount < 0 {
+		return "", ErrInvalidAmount
+	}
+
+	txID := c.TxID
+	if txID == "" {
+		txID = DefaultTxID
+	} else if !validTxID(txID) {
+		return "", ErrInvalidTxID
+	}
+
+	account := field(idAccountGUI, GUI) + field(idAccountKey, c.Key)
+	if description := asciiText(c.Description, fieldMaxLength); description != "" {
+		account += field(idAccountDescription, description)
+	}
+	if len(account) > fieldMaxLength {
+		return "", ErrMerchantAccountTooLong
+	}
+
+	name := asciiText(c.Name, merchantNameMaxLength)
+	if name == "" {
+		return "", ErrInvalidMerchantName
+	}
+	city := asciiText(c.City, merchantCityMaxLength)
+	if city == "" {
+		return "", ErrInvalidMerchantCity
+	}
+
+	var payload strings.Builder
+	payload.WriteString(field(idPayloadFormat, "01"))
+	payload.WriteString(field(idMerchantAccount, account))
+	payload.WriteString(field(idMerchantCategory, "0000"))
+	payload.WriteString(field(idCurrency, "986"))
+	if c.Amount > 0 {
+		payload.WriteString(field(idAmount, fmt.Sprintf("%d.%02d", c.Amount/100, c.Amount%100)))
+	}
+	payload.WriteString(field(idCountry, "BR"))
+	payload.WriteString(field(idMerchantName, name))
+	payload.WriteString(field(idMerchantCity, city))
+	payload.WriteString(field(idAdditionalData, field(idAdditionalDataTxID, txID)))
+	payload.WriteString(idCRC + "04")
+
+	return payload.String() + CRC16(payload.String()), nil
+}
+
+// QRCodePNG renders the payload as a PNG QR code of size by size pixels.
+func QRCodePNG(payload string, size int) ([]byte, error) {
+	return qrcode.Encode(payload, qrcode.Medium, size)
+}
+
+// CRC16 is the CRC16-CCITT (polynomial 0x1021, initial value 0xFFFF) of the
+// payload in four uppercase hex digits.
+func CRC16(payload string) string {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(payload); i++ {
+		crc ^= uint16(payload[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return fmt.Sprintf("%04X", crc)
+}
+
+// field writes an EMV field: its id, the value length in two digits and the
+// value.
+func field(id string, value string) string {
+	return fmt.Sprintf("%s%02d%s", id, len(value), value)
+}
+
+func validTxID(txID string) bool {
+	if len(txID) > 25 {
+		return false
+	}
+	for _, char := range txID {
+		if !(char >= '0' && char <= '9' || char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// asciiText strips the accents of text, drops what is left outside printable
+// ASCII and cuts it to maxLength characters.
+func asciiText(text string, maxLength int) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	stripped, _, err := transform.String(t, text)
+	if err != nil {
+		stripped = text
+	}
+
+	var result strings.Builder
+	for _, char := range strings.TrimSpace(stripped) {
+		if char >= ' ' && char <= '~' && result.Len() < maxLength {
+			result.WriteRune(char)
+		}
+	}
+	return strings.TrimSpace(result.String())
+}
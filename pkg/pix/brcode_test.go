@@ -0,0 +1,82 @@
+package pix_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teste-transfeera/pkg/pix"
+)
+
+func Test_Pix_BRCode_Payload(t *testing.T) {
+	t.Run("Should build the example payload of the specification", func(t *testing.T) {
+		code := pix.BRCode{
+			Key:  "123e4567-e12b-12d1-a456-426655440000",
+			Name: "Fulano de Tal",
+			City: "BRASILIA",
+		}
+
+		payload, err := code.Payload()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D", payload)
+	})
+
+	t.Run("Should include amount, description and txid", func(t *testing.T) {
+		code := pix.BRCode{
+			Key:         "52998224725",
+			Name:        "João da Silva",
+			City:        "Florianópolis",
+			Amount:      1050,
+			TxID:        "PEDIDO123",
+			Description: "Pagamento",
+		}
+
+		payload, err := code.Payload()
+
+		assert.NoError(t, err)
+		assert.Contains(t, payload, "26460014br.gov.bcb.pix0111529982247250209Pagamento")
+		assert.Contains(t, payload, "540510.50")
+		assert.Contains(t, payload, "5913Joao da Silva6013Florianopolis")
+		assert.Contains(t, payload, "62130509PEDIDO123")
+		assert.Equal(t, pix.CRC16(payload[:len(payload)-4]), payload[len(payload)-4:])
+	})
+
+	t.Run("Should cut the name to 25 characters", func(t *testing.T) {
+		code := pix.BRCode{Key: "52998224725", Name: strings.Repeat("A", 30), City: "BRASILIA"}
+
+		payload, err := code.Payload()
+
+		assert.NoError(t, err)
+		assert.Contains(t, payload, "5925"+strings.Repeat("A", 25)+"6008")
+	})
+
+	t.Run("Should reject invalid txid, amount and long description", func(t *testing.T) {
+		_, err := pix.BRCode{Key: "52998224725", TxID: "pedido-1"}.Payload()
+		assert.Equal(t, pix.ErrInvalidTxID, err)
+
+		_, err = pix.BRCode{Key: "52998224725", Amount: -1}.Payload()
+		assert.Equal(t, pix.ErrInvalidAmount, err)
+
+		_, err = pix.BRCode{Key: "52998224725", Description: strings.Repeat("a", 70)}.Payload()
+		assert.Equal(t, pix.ErrMerchantAccountTooLong, err)
+	})
+
+	t.Run("Should reject names and cities with nothing left without accents", func(t *testing.T) {
+		_, err := pix.BRCode{Key: "52998224725", Name: "张伟", City: "BRASILIA"}.Payload()
+		assert.Equal(t, pix.ErrInvalidMerchantName, err)
+
+		_, err = pix.BRCode{Key: "52998224725", Name: "Joao da Silva", City: " "}.Payload()
+		assert.Equal(t, pix.ErrInvalidMerchantCity, err)
+	})
+}
+
+func Test_Pix_QRCodePNG(t *testing.T) {
+	t.Run("Should render a PNG image", func(t *testing.T) {
+		image, err := pix.QRCodePNG("00020126580014br.gov.bcb.pix", 256)
+
+		assert.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(image, []byte("\x89PNG")))
+	})
+}
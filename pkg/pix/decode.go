@@ -0,0 +1,182 @@
+package pix
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidTag    = errors.New("field id must have two digits")
+	ErrInvalidLength = errors.New("field length must have two digits and fit in the payload")
+	ErrInvalidCRC    = errors.New("CRC does not match the payload")
+	ErrMissingCRC    = errors.New("payload must end with the CRC field 6304")
+	ErrNotPix        = errors.New("payload has no Pix merchant account")
+	ErrMissingKey    = errors.New("Pix merchant account has no key")
+	ErrMissingName   = errors.New("payload has no merchant name")
+)
+
+// DecodeError tells where a payload stopped making sense. Position is the
+// index of the field in the payload, and Tag its id when it could be read.
+type DecodeError struct {
+	Position int
+	Tag      string
+	Err      error
+}
+
+func (e *DecodeError) Error() string {
+	if e.Tag == "" {
+		return fmt.Sprintf("Invalid BR Code at position %d: %s", e.Position, e.Err)
+	}
+	return fmt.Sprintf("Invalid BR Code at position %d, field %s: %s", e.Position, e.Tag, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// tlv is a field read from a payload, Position being where its id starts.
+type tlv struct {
+	ID       string
+	Value    string
+	Position int
+}
+
+// Decode reads a static or dynamic BR Code back into its charge. The CRC is
+// checked before anything else, and errors are *DecodeError wrapping one of
+// the Err values above.
+func Decode(payload string) (*BRCode, error) {
+	payload = strings.TrimSpace(payload)
+
+	fields, err := decodeFields(payload, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	last := fields[len(fields)-1]
+	if last.ID != idCRC || len(last.Value) != 4 {
+		return nil, &DecodeError{Position: last.Position, Tag: last.ID, Err: ErrMissingCRC}
+	}
+	expected := CRC16(payload[:len(payload)-4])
+	if !strings.EqualFold(expected, last.Value) {
+		return nil, &DecodeError{
+			Position: last.Position,
+			Tag:      last.ID,
+			Err:      fmt.Errorf("%w: expected %s, found %s", ErrInvalidCRC, expected, last.Value),
+		}
+	}
+
+	code := &BRCode{}
+	foundAccount := false
+	for _, f := range fields {
+		switch {
+		case f.ID >= "26" && f.ID <= "51" && !foundAccount:
+			account, err := decodeFields(f.Value, f.Position+4)
+			if err != nil {
+				return nil, err
+			}
+			if !strings.EqualFold(valueOf(account, idAccountGUI), GUI) {
+				continue
+			}
+			foundAccount = true
+			code.Key = valueOf(account, idAccountKey)
+			code.Description = valueOf(account, idAccountDescription)
+			if code.Key == "" {
+				return nil, &DecodeError{Position: f.Position, Tag: f.ID, Err: ErrMissingKey}
+			}
+		case f.ID == idAmount:
+			amount, err := parseAmount(f.Value)
+			if err != nil {
+				return nil, &DecodeError{Position: f.Position, Tag: f.ID, Err: err}
+			}
+			code.Amount = amount
+		case f.ID == idMerchantName:
+			code.Name = f.Value
+		case f.ID == idMerchantCity:
+			code.City = f.Value
+		case f.ID == idAdditionalData:
+			additional, err := decodeFields(f.Value, f.Position+4)
+			if err != nil {
+				return nil, err
+			}
+			if txID := valueOf(additional, idAdditionalDataTxID); txID != DefaultTxID {
+				code.TxID = txID
+			}
+		}
+	}
+
+	if !foundAccount {
+		return nil, &DecodeError{Err: ErrNotPix}
+	}
+	if code.Name == "" {
+		return nil, &DecodeError{Err: ErrMissingName}
+	}
+
+	return code, nil
+}
+
+// decodeFields splits data into its fields. offset is the position of data
+// inside the whole payload, so that errors of nested fields point to it.
+func decodeFields(data string, offset int) ([]tlv, error) {
+	var fields []tlv
+	for i := 0; i < len(data); {
+		position := offset + i
+		if i+2 > len(data) || !digits(data[i:i+2]) {
+			return nil, &DecodeError{Position: position, Err: ErrInvalidTag}
+		}
+		id := data[i : i+2]
+
+		if i+4 > len(data) || !digits(data[i+2:i+4]) {
+			return nil, &DecodeError{Position: position, Tag: id, Err: ErrInvalidLength}
+		}
+		length, _ := strconv.Atoi(data[i+2 : i+4])
+		if length == 0 || i+4+length > len(data) {
+			return nil, &DecodeError{Position: position, Tag: id, Err: ErrInvalidLength}
+		}
+
+		fields = append(fields, tlv{ID: id, Value: data[i+4 : i+4+length], Position: position})
+		i += 4 + length
+	}
+
+	if len(fields) == 0 {
+		return nil, &DecodeError{Position: offset, Err: ErrInvalidTag}
+	}
+
+	return fields, nil
+}
+
+func valueOf(fields []tlv, id string) string {
+	for _, f := range fields {
+		if f.ID == id {
+			return f.Value
+		}
+	}
+	return ""
+}
+
+// parseAmount reads an amount such as "10.50" or "10" into cents.
+func parseAmount(value string) (int64, error) {
+	units, cents, hasCents := strings.Cut(value, ".")
+	if units == "" || !digits(units) || hasCents && (len(cents) == 0 || len(cents) > 2 || !digits(cents)) {
+		return 0, ErrInvalidAmount
+	}
+	for len(cents) < 2 {
+		cents += "0"
+	}
+
+	amount, err := strconv.ParseInt(units+cents, 10, 64)
+	if err != nil || amount <= 0 {
+		return 0, ErrInvalidAmount
+	}
+	return amount, nil
+}
+
+func digits(text string) bool {
+	for i := 0; i < len(text); i++ {
+		if text[i] < '0' || text[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,107 @@
+package pix_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teste-transfeera/pkg/pix"
+)
+
+const specificationPayload = "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D"
+
+func Test_Pix_Decode(t *testing.T) {
+	t.Run("Should decode the example payload of the specification", func(t *testing.T) {
+		code, err := pix.Decode(specificationPayload)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &pix.BRCode{
+			Key:  "123e4567-e12b-12d1-a456-426655440000",
+			Name: "Fulano de Tal",
+			City: "BRASILIA",
+		}, code)
+	})
+
+	t.Run("Should decode what Payload encodes", func(t *testing.T) {
+		expected := pix.BRCode{
+			Key:         "52998224725",
+			Name:        "Joao da Silva",
+			City:        "SAO PAULO",
+			Amount:      1050,
+			TxID:        "PEDIDO123",
+			Description: "Pagamento",
+		}
+		payload, _ := expected.Payload()
+
+		code, err := pix.Decode(payload)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &expected, code)
+	})
+
+	t.Run("Should accept a lowercase CRC", func(t *testing.T) {
+		_, err := pix.Decode(specificationPayload[:len(specificationPayload)-4] + "1d3d")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should reject a bad CRC", func(t *testing.T) {
+		_, err := pix.Decode(specificationPayload[:len(specificationPayload)-4] + "1D3E")
+
+		assert.ErrorIs(t, err, pix.ErrInvalidCRC)
+		assert.EqualError(t, err, "Invalid BR Code at position 129, field 63: CRC does not match the payload: expected 1D3D, found 1D3E")
+	})
+
+	t.Run("Should reject a payload without CRC", func(t *testing.T) {
+		_, err := pix.Decode("000201")
+
+		assert.ErrorIs(t, err, pix.ErrMissingCRC)
+	})
+
+	t.Run("Should reject a bad tag", func(t *testing.T) {
+		_, err := pix.Decode("0002012X580014br.gov.bcb.pix")
+
+		var decodeErr *pix.DecodeError
+		assert.ErrorAs(t, err, &decodeErr)
+		assert.ErrorIs(t, err, pix.ErrInvalidTag)
+		assert.Equal(t, 6, decodeErr.Position)
+	})
+
+	t.Run("Should reject a bad length", func(t *testing.T) {
+		_, err := pix.Decode("0002012699br.gov.bcb.pix")
+		assert.ErrorIs(t, err, pix.ErrInvalidLength)
+		assert.EqualError(t, err, "Invalid BR Code at position 6, field 26: field length must have two digits and fit in the payload")
+
+		_, err = pix.Decode("00020126A8")
+		assert.ErrorIs(t, err, pix.ErrInvalidLength)
+	})
+
+	t.Run("Should reject a bad nested field", func(t *testing.T) {
+		payload := withCRC("00020126100014br.gov52040000")
+
+		_, err := pix.Decode(payload)
+
+		assert.ErrorIs(t, err, pix.ErrInvalidLength)
+		assert.EqualError(t, err, "Invalid BR Code at position 10, field 00: field length must have two digits and fit in the payload")
+	})
+
+	t.Run("Should reject a payload without Pix account, key or name", func(t *testing.T) {
+		_, err := pix.Decode(withCRC("00020126180014br.gov.bcb.abc5903Ana"))
+		assert.ErrorIs(t, err, pix.ErrNotPix)
+
+		_, err = pix.Decode(withCRC("00020126180014br.gov.bcb.pix5903Ana"))
+		assert.ErrorIs(t, err, pix.ErrMissingKey)
+
+		_, err = pix.Decode(withCRC("00020126250014br.gov.bcb.pix0103abc5802BR"))
+		assert.ErrorIs(t, err, pix.ErrMissingName)
+	})
+
+	t.Run("Should reject an invalid amount", func(t *testing.T) {
+		_, err := pix.Decode(withCRC("00020126250014br.gov.bcb.pix0103abc54041,005903Ana"))
+
+		assert.ErrorIs(t, err, pix.ErrInvalidAmount)
+	})
+}
+
+func withCRC(payload string) string {
+	return payload + "6304" + pix.CRC16(payload+"6304")
+}
@@ -0,0 +1,83 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalize lowercases the text and strips its accents, so "João" and "joao"
+// compare equal.
+func Normalize(text string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	normalized, _, err := transform.String(t, text)
+	if err != nil {
+		normalized = text
+	}
+	return strings.ToLower(normalized)
+}
+
+// Words splits normalized text into its alphanumeric words.
+func Words(text string) []string {
+	return strings.FieldsFunc(Normalize(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// MaxTermLength is the length, in runes, terms are cut to. A longer word is
+// still found by any prefix up to that length.
+const MaxTermLength = 32
+
+// TermsVersion identifies how Terms builds terms. The backfills rebuild the
+// terms of receivers stored with an older version.
+const TermsVersion = 2
+
+// Terms builds the search terms stored for a set of values: every word, plus
+// each value with its separators removed, so "290.551" is found by "290551".
+// Query words match terms by prefix, which an anchored prefix regex or GLOB
+// answers from an index.
+func Terms(values ...string) []string {
+	seen := make(map[string]bool)
+	terms := []string{}
+
+	add := func(word string) {
+		term := truncate(word)
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+
+	for _, value := range values {
+		words := Words(value)
+		for _, word := range words {
+			add(word)
+		}
+		if len(words) > 1 {
+			add(strings.Join(words, ""))
+		}
+	}
+
+	return terms
+}
+
+// Query splits a search into the words matched against terms by prefix, cut
+// as the terms are.
+func Query(text string) []string {
+	words := Words(text)
+	for i, word := range words {
+		words[i] = truncate(word)
+	}
+	return words
+}
+
+func truncate(word string) string {
+	w := []rune(word)
+	if len(w) <= MaxTermLength {
+		return word
+	}
+	return string(w[:MaxTermLength])
+}
@@ -0,0 +1,43 @@
+package search_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teste-transfeera/pkg/search"
+)
+
+func Test_Search_Normalize(t *testing.T) {
+	t.Run("Should remove accents and lowercase text", func(t *testing.T) {
+		assert.Equal(t, "joao da silva ltda", search.Normalize("João da Silva LTDA"))
+	})
+
+	t.Run("Should split normalized text into words", func(t *testing.T) {
+		assert.Equal(t, []string{"receiver1", "gmail", "com"}, search.Words("RECEIVER1@GMAIL.COM"))
+	})
+}
+
+func Test_Search_Terms(t *testing.T) {
+	t.Run("Should build words and compacted values", func(t *testing.T) {
+		terms := search.Terms("Zé Lú", "290.551", "ze@lu")
+
+		assert.ElementsMatch(t, []string{"ze", "lu", "zelu", "290", "551", "290551"}, terms)
+	})
+
+	t.Run("Should cut terms to the maximum length", func(t *testing.T) {
+		long := strings.Repeat("a", search.MaxTermLength+10)
+
+		terms := search.Terms(long + " b")
+
+		assert.ElementsMatch(t, []string{strings.Repeat("a", search.MaxTermLength), "b"}, terms)
+	})
+}
+
+func Test_Search_Query(t *testing.T) {
+	t.Run("Should cut words as terms are cut", func(t *testing.T) {
+		long := strings.Repeat("á", search.MaxTermLength+10)
+
+		assert.Equal(t, []string{strings.Repeat("a", search.MaxTermLength), "jo"}, search.Query(long+" Jo"))
+	})
+}
@@ -13,6 +13,14 @@ func GetPointerStr(s string) *string {
 	return &s
 }
 
+func GetPointerBool(b bool) *bool {
+	return &b
+}
+
+func GetPointerInt64(i int64) *int64 {
+	return &i
+}
+
 func EncodeBase64(cursor []byte) string {
 	return base64.StdEncoding.EncodeToString(cursor)
 }
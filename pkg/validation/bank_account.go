@@ -0,0 +1,37 @@
+package validation
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/teste-transfeera/pkg/bank"
+)
+
+var (
+	digitsPattern     = regexp.MustCompile(`^[0-9]+$`)
+	checkDigitPattern = regexp.MustCompile(`(?i)^[0-9XP]$`)
+)
+
+// ValidatorDigits accepts only ASCII digits, unlike the numeric tag, which
+// also takes signs and decimals.
+func ValidatorDigits(fl validator.FieldLevel) bool {
+	return digitsPattern.MatchString(fl.Field().String())
+}
+
+// ValidatorCheckDigit accepts the check digit of an agency or account: a
+// digit, or the X or P some banks use for 10.
+func ValidatorCheckDigit(fl validator.FieldLevel) bool {
+	return checkDigitPattern.MatchString(fl.Field().String())
+}
+
+// ValidatorBankCode accepts only the COMPE codes of the bank directory.
+func ValidatorBankCode(fl validator.FieldLevel) bool {
+	_, ok := bank.ByCode(fl.Field().String())
+	return ok
+}
+
+// NormalizeCheckDigit stores X and P check digits in uppercase.
+func NormalizeCheckDigit(digit string) string {
+	return strings.ToUpper(digit)
+}
@@ -0,0 +1,206 @@
+package validation
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrInvalidAgencyFormat      = errors.New("Agency does not match the format of the bank")
+	ErrInvalidAgencyCheckDigit  = errors.New("Agency check digit is invalid")
+	ErrInvalidAccountFormat     = errors.New("Account does not match the format of the bank")
+	ErrInvalidAccountCheckDigit = errors.New("Account check digit is invalid")
+)
+
+// BankCheckDigits holds the check digit algorithms of a bank. The agency is
+// padded with zeros to 4 digits and the account to AccountLength digits
+// before they are called. Agency is nil for banks whose agencies have no
+// check digit.
+type BankCheckDigits struct {
+	AccountLength int
+	Agency        func(agency string) string
+	Account       func(agency string, account string) string
+}
+
+var (
+	checkDigitsMu sync.RWMutex
+	checkDigits   = map[string]BankCheckDigits{}
+)
+
+// RegisterBankCheckDigits sets the algorithms of the bank with the COMPE
+// code, replacing any registered before.
+func RegisterBankCheckDigits(bankCode string, algorithms BankCheckDigits) {
+	checkDigitsMu.Lock()
+	defer checkDigitsMu.Unlock()
+
+	checkDigits[bankCode] = algorithms
+}
+
+// CheckBankAccount verifies the agency and account check digits with the
+// algorithms of the bank. It reports verified as false, without an error,
+// for banks with no registered algorithm, whose accounts can only be checked
+// for format, and an error for agencies or accounts that are not digits or
+// are longer than the bank uses. An empty agency digit is accepted, but the account is only
+// reported as verified when the agency digit of a bank that has one was
+// checked too.
+func CheckBankAccount(bankCode, agency, agencyDigit, account, accountDigit string) (verified bool, err error) {
+	checkDigitsMu.RLock()
+	algorithms, ok := checkDigits[bankCode]
+	checkDigitsMu.RUnlock()
+	if !ok {
+		return false, nil
+	}
+
+	if len(agency) > 4 || !digitsPattern.MatchString(agency) {
+		return false, ErrInvalidAgencyFormat
+	}
+	if !digitsPattern.MatchString(account) {
+		return false, ErrInvalidAccountFormat
+	}
+
+	agency = padDigits(agency, 4)
+	if algorithms.Agency == nil && agencyDigit != "" {
+		return false, ErrInvalidAgencyFormat
+	}
+	if algorithms.Agency != nil && agencyDigit != "" && !strings.EqualFold(algorithms.Agency(agency), agencyDigit) {
+		return false, ErrInvalidAgencyCheckDigit
+	}
+
+	account = strings.TrimLeft(account, "0")
+	if len(account) > algorithms.AccountLength {
+		return false, ErrInvalidAccountFormat
+	}
+	if !strings.EqualFold(algorithms.Account(agency, padDigits(account, algorithms.AccountLength)), accountDigit) {
+		return false, ErrInvalidAccountCheckDigit
+	}
+
+	return algorithms.Agency == nil || agencyDigit != "", nil
+}
+
+func init() {
+	RegisterBankCheckDigits("001", BankCheckDigits{AccountLength: 8, Agency: bancoDoBrasilDigit, Account: bancoDoBrasilAccountDigit})
+	RegisterBankCheckDigits("237", BankCheckDigits{AccountLength: 7, Agency: bradescoAgencyDigit, Account: bradescoAccountDigit})
+	RegisterBankCheckDigits("341", BankCheckDigits{AccountLength: 5, Account: itauAccountDigit})
+	RegisterBankCheckDigits("033", BankCheckDigits{AccountLength: 8, Account: santanderAccountDigit})
+	RegisterBankCheckDigits("104", BankCheckDigits{AccountLength: 11, Account: caixaAccountDigit})
+	RegisterBankCheckDigits("260", BankCheckDigits{AccountLength: 10, Account: mod11AccountDigit})
+	RegisterBankCheckDigits("077", BankCheckDigits{AccountLength: 10, Account: mod11AccountDigit})
+}
+
+// bancoDoBrasilDigit weighs the agency from 5 down to 2. A result of 10 is
+// written as X and 11 as 0.
+func bancoDoBrasilDigit(agency string) string {
+	return bancoDoBrasilMod11(agency)
+}
+
+// bancoDoBrasilAccountDigit weighs the account from 9 down to 2, as the
+// agency.
+func bancoDoBrasilAccountDigit(_ string, account string) string {
+	return bancoDoBrasilMod11(account)
+}
+
+func bancoDoBrasilMod11(value string) string {
+	switch digit := 11 - weightedSum(value, descendingWeights(len(value)+1))%11; digit {
+	case 10:
+		return "X"
+	case 11:
+		return "0"
+	default:
+		return strconv.Itoa(digit)
+	}
+}
+
+// bradescoAgencyDigit weighs the agency from 5 down to 2. A result of 10 is
+// written as P and 11 as 0.
+func bradescoAgencyDigit(agency string) string {
+	return bradescoMod11(weightedSum(agency, []int{5, 4, 3, 2}))
+}
+
+// bradescoAccountDigit weighs the seven account digits by 2, 7, 6, 5, 4, 3
+// and 2, with the same results as the agency.
+func bradescoAccountDigit(_ string, account string) string {
+	return bradescoMod11(weightedSum(account, []int{2, 7, 6, 5, 4, 3, 2}))
+}
+
+func bradescoMod11(sum int) string {
+	switch digit := 11 - sum%11; digit {
+	case 10:
+		return "P"
+	case 11:
+		return "0"
+	default:
+		return strconv.Itoa(digit)
+	}
+}
+
+// itauAccountDigit is the mod-10 digit of agency and account together,
+// weighing them alternately by 2 and 1 and adding the digits of each
+// product.
+func itauAccountDigit(agency string, account string) string {
+	sum := 0
+	for i, digit := range digitValues(agency + account) {
+		product := digit * (2 - i%2)
+		sum += product/10 + product%10
+	}
+	return strconv.Itoa((10 - sum%10) % 10)
+}
+
+// santanderAccountDigit is the mod-10 digit of the agency, two zeros and the
+// account, adding the last digit of each product by the weights below.
+func santanderAccountDigit(agency string, account string) string {
+	weights := []int{9, 7, 3, 1, 0, 0, 9, 7, 1, 3, 1, 9, 7, 3}
+	sum := 0
+	for i, digit := range digitValues(agency + "00" + account) {
+		sum += digit * weights[i] % 10
+	}
+	return strconv.Itoa((10 - sum%10) % 10)
+}
+
+// caixaAccountDigit covers the agency and the account with its three digit
+// operation code, weighed from 8 down to 2 and again from 9 down to 2. The
+// digit is the sum times 10 mod 11, 10 being written as 0.
+func caixaAccountDigit(agency string, account string) string {
+	weights := []int{8, 7, 6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	return strconv.Itoa(weightedSum(agency+account, weights) * 10 % 11 % 10)
+}
+
+// mod11AccountDigit is the digit used by Nubank and Inter, whose agency is
+// always 0001: the account weighed from right to left cycling from 2 to 9,
+// as the CNPJ.
+func mod11AccountDigit(_ string, account string) string {
+	return strconv.Itoa(cnpjCheckDigit(digitValues(account)))
+}
+
+func weightedSum(value string, weights []int) int {
+	sum := 0
+	for i, digit := range digitValues(value) {
+		sum += digit * weights[i]
+	}
+	return sum
+}
+
+// descendingWeights returns the weights from first down to 2.
+func descendingWeights(first int) []int {
+	weights := make([]int, first-1)
+	for i := range weights {
+		weights[i] = first - i
+	}
+	return weights
+}
+
+func digitValues(value string) []int {
+	digits := make([]int, len(value))
+	for i, char := range value {
+		digits[i] = int(char - '0')
+	}
+	return digits
+}
+
+func padDigits(value string, length int) string {
+	if len(value) >= length {
+		return value
+	}
+	return strings.Repeat("0", length-len(value)) + value
+}
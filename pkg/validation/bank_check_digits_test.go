@@ -0,0 +1,95 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teste-transfeera/pkg/validation"
+)
+
+func Test_Validation_CheckBankAccount(t *testing.T) {
+	t.Run("Should verify accounts of the major banks", func(t *testing.T) {
+		accounts := []struct {
+			bank, agency, agencyDigit, account, accountDigit string
+		}{
+			{"001", "1234", "3", "00210169", "6"},
+			{"001", "0006", "x", "210169", "6"},
+			{"237", "1425", "7", "0238069", "2"},
+			{"237", "0006", "P", "238069", "2"},
+			{"341", "2545", "", "02366", "1"},
+			{"033", "2006", "", "01008407", "4"},
+			{"104", "0235", "", "00100000307", "3"},
+			{"260", "0001", "", "12345678", "9"},
+			{"077", "0001", "", "12345678", "9"},
+		}
+
+		for _, a := range accounts {
+			verified, err := validation.CheckBankAccount(a.bank, a.agency, a.agencyDigit, a.account, a.accountDigit)
+			assert.NoError(t, err, a.bank)
+			assert.True(t, verified, a.bank)
+		}
+	})
+
+	t.Run("Should accept an empty agency digit without verifying the account", func(t *testing.T) {
+		verified, err := validation.CheckBankAccount("001", "1234", "", "00210169", "6")
+
+		assert.NoError(t, err)
+		assert.False(t, verified)
+	})
+
+	t.Run("Should reject wrong check digits", func(t *testing.T) {
+		_, err := validation.CheckBankAccount("001", "1234", "4", "00210169", "6")
+		assert.Equal(t, validation.ErrInvalidAgencyCheckDigit, err)
+
+		_, err = validation.CheckBankAccount("341", "2545", "", "02366", "2")
+		assert.Equal(t, validation.ErrInvalidAccountCheckDigit, err)
+	})
+
+	t.Run("Should reject formats the bank does not use", func(t *testing.T) {
+		_, err := validation.CheckBankAccount("341", "2545", "1", "02366", "1")
+		assert.Equal(t, validation.ErrInvalidAgencyFormat, err)
+
+		_, err = validation.CheckBankAccount("341", "2545", "", "123456", "1")
+		assert.Equal(t, validation.ErrInvalidAccountFormat, err)
+	})
+
+	t.Run("Should reject agencies and accounts longer than the bank uses", func(t *testing.T) {
+		_, err := validation.CheckBankAccount("237", "12345", "1", "1234567", "0")
+		assert.Equal(t, validation.ErrInvalidAgencyFormat, err)
+
+		_, err = validation.CheckBankAccount("033", "2006", "", "123456789", "4")
+		assert.Equal(t, validation.ErrInvalidAccountFormat, err)
+
+		_, err = validation.CheckBankAccount("104", "0235", "", "123456789012", "3")
+		assert.Equal(t, validation.ErrInvalidAccountFormat, err)
+	})
+
+	t.Run("Should reject agencies and accounts that are not digits", func(t *testing.T) {
+		_, err := validation.CheckBankAccount("237", "14-5", "7", "0238069", "2")
+		assert.Equal(t, validation.ErrInvalidAgencyFormat, err)
+
+		_, err = validation.CheckBankAccount("033", "2006", "", "0100840-", "4")
+		assert.Equal(t, validation.ErrInvalidAccountFormat, err)
+	})
+
+	t.Run("Should not verify banks without a known algorithm", func(t *testing.T) {
+		verified, err := validation.CheckBankAccount("748", "0101", "", "123456", "0")
+
+		assert.NoError(t, err)
+		assert.False(t, verified)
+	})
+
+	t.Run("Should use algorithms registered for other banks", func(t *testing.T) {
+		validation.RegisterBankCheckDigits("999", validation.BankCheckDigits{
+			AccountLength: 6,
+			Account:       func(agency string, account string) string { return account[5:] },
+		})
+
+		verified, err := validation.CheckBankAccount("999", "0001", "", "123456", "6")
+		assert.NoError(t, err)
+		assert.True(t, verified)
+
+		_, err = validation.CheckBankAccount("999", "0001", "", "123456", "7")
+		assert.Equal(t, validation.ErrInvalidAccountCheckDigit, err)
+	})
+}
@@ -0,0 +1,168 @@
+package validation
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrInvalidIdentifier = errors.New("Identifier must be a CPF or CNPJ")
+
+	ErrInvalidCPFLength      = errors.New("CPF must have 11 digits")
+	ErrCPFRepeatedDigits     = errors.New("CPF must not be a sequence of repeated digits")
+	ErrInvalidCPFCheckDigits = errors.New("CPF check digits are invalid")
+
+	ErrInvalidCNPJLength      = errors.New("CNPJ must have 14 characters")
+	ErrInvalidCNPJCharacters  = errors.New("CNPJ must have 12 letters or digits followed by 2 check digits")
+	ErrCNPJRepeatedDigits     = errors.New("CNPJ must not be a sequence of repeated digits")
+	ErrInvalidCNPJCheckDigits = errors.New("CNPJ check digits are invalid")
+)
+
+var (
+	cpfPattern  = regexp.MustCompile(`^[0-9]{3}[\.]?[0-9]{3}[\.]?[0-9]{3}[-]?[0-9]{2}$`)
+	cnpjPattern = regexp.MustCompile(`^[0-9A-Za-z]{2}[\.]?[0-9A-Za-z]{3}[\.]?[0-9A-Za-z]{3}[\/]?[0-9A-Za-z]{4}[-]?[0-9]{2}$`)
+)
+
+// CheckIdentifier validates a CPF or CNPJ, formatted or not, and returns an
+// error telling which rule failed.
+func CheckIdentifier(identifier string) error {
+	switch {
+	case cpfPattern.MatchString(identifier):
+		return CheckCPF(identifier)
+	case cnpjPattern.MatchString(identifier):
+		return CheckCNPJ(identifier)
+	}
+
+	return ErrInvalidIdentifier
+}
+
+// CheckCPF verifies the two mod-11 check digits of a CPF. Punctuation is
+// ignored.
+func CheckCPF(cpf string) error {
+	digits := onlyDigits(cpf)
+	if len(digits) != 11 {
+		return ErrInvalidCPFLength
+	}
+	if repeatedDigits(digits) {
+		return ErrCPFRepeatedDigits
+	}
+
+	first := cpfCheckDigit(digits[:9])
+	second := cpfCheckDigit(append(digits[:9:9], first))
+	if digits[9] != first || digits[10] != second {
+		return ErrInvalidCPFCheckDigits
+	}
+
+	return nil
+}
+
+// CheckCNPJ verifies the two mod-11 check digits of a CNPJ, numeric or
+// alphanumeric. Punctuation is ignored and letters may be in any case.
+func CheckCNPJ(cnpj string) error {
+	normalized := NormalizeCNPJ(cnpj)
+	if len(normalized) != 14 {
+		return ErrInvalidCNPJLength
+	}
+
+	values := cnpjValues(normalized)
+	if values == nil {
+		return ErrInvalidCNPJCharacters
+	}
+	if repeatedDigits(values) {
+		return ErrCNPJRepeatedDigits
+	}
+
+	first := cnpjCheckDigit(values[:12])
+	second := cnpjCheckDigit(append(values[:12:12], first))
+	if values[12] != first || values[13] != second {
+		return ErrInvalidCNPJCheckDigits
+	}
+
+	return nil
+}
+
+// NormalizeCNPJ removes the punctuation of a CNPJ and uppercases its letters.
+func NormalizeCNPJ(cnpj string) string {
+	return strings.ToUpper(strings.NewReplacer(".", "", "/", "", "-", "").Replace(cnpj))
+}
+
+// FormatCNPJ returns the CNPJ in the XX.XXX.XXX/XXXX-XX display format. Values
+// that are not 14 characters long are returned unchanged.
+func FormatCNPJ(cnpj string) string {
+	normalized := NormalizeCNPJ(cnpj)
+	if len(normalized) != 14 {
+		return cnpj
+	}
+
+	return normalized[:2] + "." + normalized[2:5] + "." + normalized[5:8] + "/" + normalized[8:12] + "-" + normalized[12:]
+}
+
+// cnpjValues converts a normalized CNPJ into the values used by the check
+// digit calculation: the ASCII code minus 48, so digits keep their value and
+// letters go from 17 (A) to 42 (Z). Only digits are allowed in the last two
+// positions.
+func cnpjValues(normalized string) []int {
+	values := make([]int, len(normalized))
+	for i, char := range normalized {
+		isDigit := char >= '0' && char <= '9'
+		isLetter := char >= 'A' && char <= 'Z'
+		if !isDigit && !(isLetter && i < 12) {
+			return nil
+		}
+		values[i] = int(char - '0')
+	}
+	return values
+}
+
+// cpfCheckDigit weighs the digits from len+1 down to 2.
+func cpfCheckDigit(digits []int) int {
+	sum := 0
+	for i, digit := range digits {
+		sum += digit * (len(digits) + 1 - i)
+	}
+	return mod11(sum)
+}
+
+// cnpjCheckDigit weighs the digits from right to left cycling from 2 to 9.
+func cnpjCheckDigit(digits []int) int {
+	sum := 0
+	weight := 2
+	for i := len(digits) - 1; i >= 0; i-- {
+		sum += digits[i] * weight
+		weight++
+		if weight > 9 {
+			weight = 2
+		}
+	}
+	return mod11(sum)
+}
+
+func mod11(sum int) int {
+	rest := sum % 11
+	if rest < 2 {
+		return 0
+	}
+	return 11 - rest
+}
+
+func onlyDigits(value string) []int {
+	var digits []int
+	for _, char := range value {
+		if char >= '0' && char <= '9' {
+			digits = append(digits, int(char-'0'))
+		} else if !strings.ContainsRune(".-/", char) {
+			return nil
+		}
+	}
+	return digits
+}
+
+func repeatedDigits(digits []int) bool {
+	for _, digit := range digits[1:] {
+		if digit != digits[0] {
+			return false
+		}
+	}
+	return true
+}
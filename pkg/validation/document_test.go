@@ -0,0 +1,118 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teste-transfeera/internal/entity"
+	"github.com/teste-transfeera/pkg/validation"
+)
+
+func Test_Validation_CheckIdentifier(t *testing.T) {
+	t.Run("Should accept valid CPF and CNPJ with or without punctuation", func(t *testing.T) {
+		for _, identifier := range []string{"529.982.247-25", "52998224725", "11.222.333/0001-81", "11222333000181", "12.ABC.345/01DE-35"} {
+			assert.NoError(t, validation.CheckIdentifier(identifier), identifier)
+		}
+	})
+
+	t.Run("Should reject trailing characters after a valid identifier", func(t *testing.T) {
+		assert.Equal(t, validation.ErrInvalidIdentifier, validation.CheckIdentifier("529.982.247-25abc"))
+		assert.Equal(t, validation.ErrInvalidIdentifier, validation.CheckIdentifier("123.456.789-099"))
+	})
+
+	t.Run("Should reject identifiers with repeated digits", func(t *testing.T) {
+		assert.Equal(t, validation.ErrCPFRepeatedDigits, validation.CheckIdentifier("111.111.111-11"))
+		assert.Equal(t, validation.ErrCNPJRepeatedDigits, validation.CheckIdentifier("00.000.000/0000-00"))
+	})
+
+	t.Run("Should reject identifiers with wrong check digits", func(t *testing.T) {
+		assert.Equal(t, validation.ErrInvalidCPFCheckDigits, validation.CheckIdentifier("529.982.247-24"))
+		assert.Equal(t, validation.ErrInvalidCNPJCheckDigits, validation.CheckIdentifier("11.222.333/0001-80"))
+	})
+}
+
+func Test_Validation_CheckCPF(t *testing.T) {
+	t.Run("Should reject CPF with wrong number of digits", func(t *testing.T) {
+		assert.Equal(t, validation.ErrInvalidCPFLength, validation.CheckCPF("529.982.247-2"))
+	})
+
+	t.Run("Should accept CPF whose check digit is zero", func(t *testing.T) {
+		assert.NoError(t, validation.CheckCPF("123.456.789-09"))
+	})
+}
+
+func Test_Validation_CheckCNPJ(t *testing.T) {
+	t.Run("Should reject CNPJ with wrong number of characters", func(t *testing.T) {
+		assert.Equal(t, validation.ErrInvalidCNPJLength, validation.CheckCNPJ("11.222.333/0001-8"))
+	})
+
+	t.Run("Should accept alphanumeric CNPJ in any case", func(t *testing.T) {
+		for _, cnpj := range []string{"12.ABC.345/01DE-35", "12ABC34501DE35", "12abc34501de35"} {
+			assert.NoError(t, validation.CheckCNPJ(cnpj), cnpj)
+		}
+	})
+
+	t.Run("Should reject alphanumeric CNPJ with wrong check digits", func(t *testing.T) {
+		assert.Equal(t, validation.ErrInvalidCNPJCheckDigits, validation.CheckCNPJ("12.ABC.345/01DE-36"))
+	})
+
+	t.Run("Should reject letters in the check digits", func(t *testing.T) {
+		assert.Equal(t, validation.ErrInvalidCNPJCharacters, validation.CheckCNPJ("12.ABC.345/01DE-3A"))
+	})
+}
+
+func Test_Validation_FormatCNPJ(t *testing.T) {
+	t.Run("Should normalize numeric and alphanumeric CNPJ", func(t *testing.T) {
+		assert.Equal(t, "11222333000181", validation.NormalizeCNPJ("11.222.333/0001-81"))
+		assert.Equal(t, "12ABC34501DE35", validation.NormalizeCNPJ("12.abc.345/01de-35"))
+	})
+
+	t.Run("Should format numeric and alphanumeric CNPJ for display", func(t *testing.T) {
+		assert.Equal(t, "11.222.333/0001-81", validation.FormatCNPJ("11222333000181"))
+		assert.Equal(t, "12.ABC.345/01DE-35", validation.FormatCNPJ("12abc34501de35"))
+	})
+
+	t.Run("Should keep values that are not a CNPJ unchanged", func(t *testing.T) {
+		assert.Equal(t, "123", validation.FormatCNPJ("123"))
+	})
+}
+
+func Test_Validation_CheckPixKey(t *testing.T) {
+	t.Run("Should verify check digits of CPF and CNPJ keys", func(t *testing.T) {
+		assert.NoError(t, validation.CheckPixKey("529.982.247-25", "CPF"))
+		assert.NoError(t, validation.CheckPixKey("12.ABC.345/01DE-35", "CNPJ"))
+
+		err := validation.CheckPixKey("11.222.333/0001-80", "CNPJ")
+
+		assert.ErrorIs(t, err, validation.ErrInvalidCNPJCheckDigits)
+		assert.Equal(t, "Invalid Pix Key for CNPJ Key Type: CNPJ check digits are invalid", err.Error())
+	})
+
+	t.Run("Should reject key that does not match its type", func(t *testing.T) {
+		assert.EqualError(t, validation.CheckPixKey("a@a", "CPF"), "Invalid Pix Key for CPF Key Type")
+	})
+}
+
+func Test_Validation_InferPixKeyType(t *testing.T) {
+	t.Run("Should infer the type of each kind of key", func(t *testing.T) {
+		keys := map[string]entity.PixKeyType{
+			"52998224725":                          entity.CPF,
+			"11222333000181":                       entity.CNPJ,
+			"fulano@transfeera.com":                entity.Email,
+			"+5511987654321":                       entity.Phone,
+			"123e4567-e12b-12d1-a456-426655440000": entity.RandomKey,
+		}
+
+		for key, expected := range keys {
+			keyType, ok := validation.InferPixKeyType(key)
+			assert.True(t, ok, key)
+			assert.Equal(t, expected, keyType, key)
+		}
+	})
+
+	t.Run("Should not infer a type for invalid keys", func(t *testing.T) {
+		_, ok := validation.InferPixKeyType("not a key")
+
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,108 @@
+package validation
+
+import (
+	"strings"
+
+	"github.com/teste-transfeera/internal/entity"
+)
+
+// NormalizeIdentifier returns the canonical form of a CPF or CNPJ: only its
+// digits and uppercase letters.
+func NormalizeIdentifier(identifier string) string {
+	return NormalizeCNPJ(identifier)
+}
+
+// FormatIdentifier returns the CPF or CNPJ with its display punctuation.
+func FormatIdentifier(identifier string) string {
+	normalized := NormalizeIdentifier(identifier)
+	if len(normalized) == 11 {
+		return FormatCPF(normalized)
+	}
+	return FormatCNPJ(normalized)
+}
+
+// FormatCPF returns the CPF in the XXX.XXX.XXX-XX display format. Values that
+// are not 11 digits long are returned unchanged.
+func FormatCPF(cpf string) string {
+	normalized := NormalizeIdentifier(cpf)
+	if len(normalized) != 11 {
+		return cpf
+	}
+
+	return normalized[:3] + "." + normalized[3:6] + "." + normalized[6:9] + "-" + normalized[9:]
+}
+
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NormalizePhone returns the phone in E.164 format, adding the +55 country
+// code when it is missing.
+func NormalizePhone(phone string) string {
+	digits := strings.TrimPrefix(phone, "+")
+	if len(digits) == 11 {
+		digits = "55" + digits
+	}
+	return "+" + digits
+}
+
+// FormatPhone returns the phone in the +55 (DD) 9XXXX-XXXX display format.
+func FormatPhone(phone string) string {
+	normalized := NormalizePhone(phone)
+	if len(normalized) != 14 {
+		return phone
+	}
+
+	return normalized[:3] + " (" + normalized[3:5] + ") " + normalized[5:10] + "-" + normalized[10:]
+}
+
+// NormalizePixKey returns the canonical form of a key of the given type. Keys
+// of unknown types are returned unchanged.
+func NormalizePixKey(key string, keyTypeStr string) string {
+	keyType, _ := entity.GetKeyType(keyTypeStr)
+	switch keyType {
+	case entity.CPF, entity.CNPJ:
+		return NormalizeIdentifier(key)
+	case entity.Email:
+		return NormalizeEmail(key)
+	case entity.Phone:
+		return NormalizePhone(key)
+	case entity.RandomKey:
+		return strings.ToLower(key)
+	}
+
+	return key
+}
+
+// FormatPixKey returns the display form of a key of the given type.
+func FormatPixKey(key string, keyTypeStr string) string {
+	keyType, _ := entity.GetKeyType(keyTypeStr)
+	switch keyType {
+	case entity.CPF:
+		return FormatCPF(key)
+	case entity.CNPJ:
+		return FormatCNPJ(key)
+	case entity.Phone:
+		return FormatPhone(key)
+	}
+
+	return NormalizePixKey(key, keyTypeStr)
+}
+
+// NormalizeAnyPixKey normalizes a key whose type is unknown, as the type
+// InferPixKeyType finds, so 11 digits that are not a valid CPF are taken as
+// a phone. Keys valid for no type are normalized as the first type whose
+// format they match, and returned unchanged when there is none.
+func NormalizeAnyPixKey(key string) string {
+	if keyType, ok := InferPixKeyType(key); ok {
+		return NormalizePixKey(key, string(keyType))
+	}
+
+	for _, keyType := range pixKeyTypes {
+		if matchPixKey(key, string(keyType)) {
+			return NormalizePixKey(key, string(keyType))
+		}
+	}
+
+	return key
+}
@@ -0,0 +1,48 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teste-transfeera/pkg/validation"
+)
+
+func Test_Validation_NormalizeIdentifier(t *testing.T) {
+	t.Run("Should keep only digits and letters of CPF and CNPJ", func(t *testing.T) {
+		assert.Equal(t, "29055159026", validation.NormalizeIdentifier("290.551.590-26"))
+		assert.Equal(t, "29055159026", validation.NormalizeIdentifier("29055159026"))
+		assert.Equal(t, "12ABC34501DE35", validation.NormalizeIdentifier("12.abc.345/01de-35"))
+	})
+
+	t.Run("Should format CPF and CNPJ for display", func(t *testing.T) {
+		assert.Equal(t, "290.551.590-26", validation.FormatIdentifier("29055159026"))
+		assert.Equal(t, "11.222.333/0001-81", validation.FormatIdentifier("11222333000181"))
+	})
+}
+
+func Test_Validation_NormalizePixKey(t *testing.T) {
+	t.Run("Should normalize each key type", func(t *testing.T) {
+		assert.Equal(t, "29055159026", validation.NormalizePixKey("290.551.590-26", "CPF"))
+		assert.Equal(t, "11222333000181", validation.NormalizePixKey("11.222.333/0001-81", "CNPJ"))
+		assert.Equal(t, "receiver1@gmail.com", validation.NormalizePixKey("RECEIVER1@GMAIL.COM", "EMAIL"))
+		assert.Equal(t, "+5548991000001", validation.NormalizePixKey("48991000001", "TELEFONE"))
+		assert.Equal(t, "+5548991000001", validation.NormalizePixKey("5548991000001", "TELEFONE"))
+		assert.Equal(t, "+5548991000001", validation.NormalizePixKey("+5548991000001", "TELEFONE"))
+		assert.Equal(t, "1e0a8c36-5f6b-4c2e-9d0a-7b0e6c2f5d01", validation.NormalizePixKey("1E0A8C36-5F6B-4C2E-9D0A-7B0E6C2F5D01", "CHAVE_ALEATORIA"))
+	})
+
+	t.Run("Should format each key type for display", func(t *testing.T) {
+		assert.Equal(t, "290.551.590-26", validation.FormatPixKey("29055159026", "CPF"))
+		assert.Equal(t, "+55 (48) 99100-0001", validation.FormatPixKey("48991000001", "TELEFONE"))
+		assert.Equal(t, "receiver1@gmail.com", validation.FormatPixKey("RECEIVER1@GMAIL.COM", "EMAIL"))
+	})
+
+	t.Run("Should normalize key of unknown type as the first type it is valid for", func(t *testing.T) {
+		assert.Equal(t, "29055159026", validation.NormalizeAnyPixKey("290.551.590-26"))
+		assert.Equal(t, "+5548991000001", validation.NormalizeAnyPixKey("+5548991000001"))
+		assert.Equal(t, "+5548991000001", validation.NormalizeAnyPixKey("48991000001"))
+		assert.Equal(t, "11111111111", validation.NormalizeAnyPixKey("111.111.111-11"))
+		assert.Equal(t, "receiver1@gmail.com", validation.NormalizeAnyPixKey("RECEIVER1@GMAIL.COM"))
+		assert.Equal(t, "unknown", validation.NormalizeAnyPixKey("unknown"))
+	})
+}
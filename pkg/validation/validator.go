@@ -1,14 +1,19 @@
 package validation
 
 import (
+	"fmt"
 	"regexp"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/teste-transfeera/internal/entity"
 )
 
+// ValidatorIdentifier and ValidatorPixKey only check the format. The check
+// digits are verified afterwards by CheckIdentifier and CheckPixKey, so the
+// error can tell which rule failed.
 func ValidatorIdentifier(fl validator.FieldLevel) bool {
-	return ValidateIdentifier(fl.Field().String())
+	identifier := fl.Field().String()
+	return cpfPattern.MatchString(identifier) || cnpjPattern.MatchString(identifier)
 }
 
 func ValidatorEmail(fl validator.FieldLevel) bool {
@@ -20,16 +25,15 @@ func ValidatorPixType(fl validator.FieldLevel) bool {
 }
 
 func ValidatorPixKey(fl validator.FieldLevel) bool {
-	return ValidatePixKey(fl.Field().String(), fl.Parent().FieldByName("PixKeyType").String())
+	return matchPixKey(fl.Field().String(), fl.Parent().FieldByName("PixKeyType").String())
 }
 
 func ValidateIdentifier(identifier string) bool {
-	pattern := regexp.MustCompile(`^[0-9]{3}[\.]?[0-9]{3}[\.]?[0-9]{3}[-]?[0-9]{2}|[0-9]{2}[\.]?[0-9]{3}[\.]?[0-9]{3}[\/]?[0-9]{4}[-]?[0-9]{2}$`)
-	return pattern.MatchString(identifier)
+	return CheckIdentifier(identifier) == nil
 }
 
 func ValidateEmail(email string) bool {
-	pattern := regexp.MustCompile(`^[A-Z0-9+_.-]+@[A-Z0-9.-]+$`)
+	pattern := regexp.MustCompile(`(?i)^[A-Z0-9+_.-]+@[A-Z0-9.-]+$`)
 	return pattern.MatchString(email)
 }
 
@@ -42,6 +46,47 @@ func ValidatePixType(keyType string) bool {
 }
 
 func ValidatePixKey(key string, keyTypeStr string) bool {
+	return CheckPixKey(key, keyTypeStr) == nil
+}
+
+// pixKeyTypes is the order keys of unknown type are tried in.
+var pixKeyTypes = []entity.PixKeyType{entity.CPF, entity.CNPJ, entity.Email, entity.Phone, entity.RandomKey}
+
+// InferPixKeyType returns the first type the key is valid for, check digits
+// included, in the order CPF, CNPJ, Email, Phone and random key.
+func InferPixKeyType(key string) (entity.PixKeyType, bool) {
+	for _, keyType := range pixKeyTypes {
+		if ValidatePixKey(key, string(keyType)) {
+			return keyType, true
+		}
+	}
+
+	return "", false
+}
+
+// CheckPixKey validates the key against its type. CPF and CNPJ keys also have
+// their check digits verified.
+func CheckPixKey(key string, keyTypeStr string) error {
+	if !matchPixKey(key, keyTypeStr) {
+		return fmt.Errorf("Invalid Pix Key for %s Key Type", keyTypeStr)
+	}
+
+	var err error
+	keyType, _ := entity.GetKeyType(keyTypeStr)
+	switch keyType {
+	case entity.CPF:
+		err = CheckCPF(key)
+	case entity.CNPJ:
+		err = CheckCNPJ(key)
+	}
+	if err != nil {
+		return fmt.Errorf("Invalid Pix Key for %s Key Type: %w", keyTypeStr, err)
+	}
+
+	return nil
+}
+
+func matchPixKey(key string, keyTypeStr string) bool {
 	keyType, err := entity.GetKeyType(keyTypeStr)
 	if err != nil {
 		return false
@@ -49,12 +94,10 @@ func ValidatePixKey(key string, keyTypeStr string) bool {
 
 	switch keyType {
 	case entity.CPF:
-		pattern := regexp.MustCompile(`^[0-9]{3}[\.]?[0-9]{3}[\.]?[0-9]{3}[-]?[0-9]{2}$`)
-		return pattern.MatchString(key)
+		return cpfPattern.MatchString(key)
 
 	case entity.CNPJ:
-		pattern := regexp.MustCompile(`^[0-9]{2}[\.]?[0-9]{3}[\.]?[0-9]{3}[\/]?[0-9]{4}[-]?[0-9]{2}$`)
-		return pattern.MatchString(key)
+		return cnpjPattern.MatchString(key)
 
 	case entity.Email:
 		return ValidateEmail(key)
@@ -64,7 +107,7 @@ func ValidatePixKey(key string, keyTypeStr string) bool {
 		return pattern.MatchString(key)
 
 	case entity.RandomKey:
-		pattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+		pattern := regexp.MustCompile(`(?i)^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
 		return pattern.MatchString(key)
 	}
 